@@ -4,6 +4,9 @@
 package common
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/oapi-codegen/nullable"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
@@ -22,6 +25,19 @@ const (
 	Set    OperationType = "set"
 )
 
+// Defines values for ReadConsistency.
+const (
+	ReadConsistencyAny     ReadConsistency = "any"
+	ReadConsistencyBounded ReadConsistency = "bounded"
+	ReadConsistencyStrong  ReadConsistency = "strong"
+)
+
+// Defines values for ReplicationMode.
+const (
+	ReplicationModePrimary ReplicationMode = "primary"
+	ReplicationModeRaft    ReplicationMode = "raft"
+)
+
 // Defines values for Status.
 const (
 	Healthy       Status = "healthy"
@@ -29,6 +45,13 @@ const (
 	Uninitialized Status = "uninitialized"
 )
 
+// Defines values for WriteConcern.
+const (
+	WriteConcernAll      WriteConcern = "all"
+	WriteConcernMajority WriteConcern = "majority"
+	WriteConcernOne      WriteConcern = "1"
+)
+
 // DeleteResponse defines model for DeleteResponse.
 type DeleteResponse struct {
 	// Deleted Whether the key was successfully deleted
@@ -36,6 +59,12 @@ type DeleteResponse struct {
 
 	// Key The key that was requested for deletion
 	Key string `json:"key"`
+
+	// OperationId ID of the operation that applied the deletion on the partition's master
+	OperationId *int64 `json:"operationId,omitempty"`
+
+	// SessionToken Opaque token to pass to later reads so that they observe this deletion
+	SessionToken *string `json:"sessionToken,omitempty"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
@@ -52,6 +81,12 @@ type KeyValuePair struct {
 	// Key The key for the key-value pair
 	Key string `json:"key"`
 
+	// OperationId ID of the operation that applied the write on the partition's master
+	OperationId *int64 `json:"operationId,omitempty"`
+
+	// SessionToken Opaque token to pass to later reads so that they observe this write
+	SessionToken *string `json:"sessionToken,omitempty"`
+
 	// Value The value associated with the key
 	Value string `json:"value"`
 }
@@ -68,6 +103,18 @@ type KeyValueResponse struct {
 	Value nullable.Nullable[string] `json:"value"`
 }
 
+// LoadBalancer defines model for LoadBalancer.
+type LoadBalancer struct {
+	// Address Network address of the private API of the load balancer
+	Address string `json:"address"`
+
+	// Id Unique identifier for the load balancer
+	Id openapi_types.UUID `json:"id"`
+
+	// Status Health status of a node
+	Status Status `json:"status"`
+}
+
 // MigrationRange defines model for MigrationRange.
 type MigrationRange struct {
 	// Id Unique identifier for this migration range
@@ -106,15 +153,34 @@ type Node struct {
 	// Id Unique identifier for the node
 	Id openapi_types.UUID `json:"id"`
 
+	// IsDraining Whether the node is being removed, its partitions are moved to other nodes before it leaves the cluster
+	IsDraining *bool `json:"isDraining,omitempty"`
+
+	// Labels Failure domains a node lies in, replicas of a partition are spread over distinct domains
+	Labels *NodeLabels `json:"labels,omitempty"`
+
 	// Partitions Map of partition IDs to role information for this node
 	Partitions map[string]PartitionRole `json:"partitions"`
 
 	// Status Health status of a node
 	Status Status `json:"status"`
+
+	// Weight Relative capacity of the node, a node of weight 2 is given twice the load of a node of weight 1
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// NodeLabels Failure domains a node lies in, replicas of a partition are spread over distinct domains
+type NodeLabels struct {
+	Host *string `json:"host,omitempty"`
+	Rack *string `json:"rack,omitempty"`
+	Zone *string `json:"zone,omitempty"`
 }
 
 // Operation defines model for Operation.
 type Operation struct {
+	// Epoch Epoch of the partition master that created the operation
+	Epoch int64 `json:"epoch"`
+
 	// ID Serial(WAL Level) Unique operation ID
 	ID int64 `json:"id"`
 
@@ -124,6 +190,9 @@ type Operation struct {
 	// PartitionId Partition ID where this operation was applied
 	PartitionId nullable.Nullable[string] `json:"partitionId,omitempty"`
 
+	// Timestamp Time the master created the operation
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+
 	// Type Type of operation
 	Type OperationType `json:"type"`
 
@@ -136,12 +205,18 @@ type OperationType string
 
 // Partition defines model for Partition.
 type Partition struct {
+	// Epoch Incremented every time a new master is promoted for this partition
+	Epoch int64 `json:"epoch"`
+
 	// Id Unique identifier for the partition
 	Id string `json:"id"`
 
 	// IsMigrating Whether this partition is involved in migration
 	IsMigrating *bool `json:"isMigrating,omitempty"`
 
+	// LeavingNodeIds Nodes the partition is moving off, they leave it once the other nodes of the partition caught up with its master
+	LeavingNodeIds *[]openapi_types.UUID `json:"leavingNodeIds,omitempty"`
+
 	// MasterNodeId ID of the node that is currently the master for this partition
 	MasterNodeId openapi_types.UUID `json:"masterNodeId"`
 
@@ -151,6 +226,9 @@ type Partition struct {
 
 // PartitionRole defines model for PartitionRole.
 type PartitionRole struct {
+	// Epoch Epoch of the partition this role was assigned in
+	Epoch int64 `json:"epoch"`
+
 	// IsMaster Whether this node is the master for this partition
 	IsMaster bool `json:"isMaster"`
 
@@ -158,17 +236,179 @@ type PartitionRole struct {
 	IsSyncing bool `json:"isSyncing"`
 }
 
+// PartitionStatus defines model for PartitionStatus.
+type PartitionStatus struct {
+	// Epoch Epoch of the role the node holds for the partition, reports from an older epoch than the controller assigned are ignored
+	Epoch int64 `json:"epoch"`
+
+	// IsMaster Whether this node is the master for this partition
+	IsMaster bool `json:"isMaster"`
+
+	// IsSyncing Whether this partition is currently syncing data
+	IsSyncing bool `json:"isSyncing"`
+
+	// KeyCount Number of keys stored in the partition
+	KeyCount int64 `json:"keyCount"`
+
+	// LastAppliedAt Time the master created the last operation applied to the partition
+	LastAppliedAt *time.Time `json:"lastAppliedAt,omitempty"`
+
+	// LastAppliedOperationId ID of the last operation applied to the partition, -1 when none
+	LastAppliedOperationId int64 `json:"lastAppliedOperationId"`
+
+	// MemoryBytes Size of the keys and values stored in the partition
+	MemoryBytes int64 `json:"memoryBytes"`
+
+	// PartitionId ID of the partition
+	PartitionId string `json:"partitionId"`
+
+	// Replicas Replication progress of every replica, only reported by masters
+	Replicas *[]ReplicaStatus `json:"replicas,omitempty"`
+
+	// RequestRate Requests per second served for the partition over the last seconds
+	RequestRate float64 `json:"requestRate"`
+}
+
+// RaftAppendRequest defines model for RaftAppendRequest.
+type RaftAppendRequest struct {
+	// Entries Entries to store, empty for heartbeats
+	Entries []RaftEntry `json:"entries"`
+
+	// LeaderCommit Commit index of the leader
+	LeaderCommit int64 `json:"leaderCommit"`
+
+	// LeaderId ID of the leader node
+	LeaderId openapi_types.UUID `json:"leaderId"`
+
+	// PrevLogIndex Index of the entry immediately preceding the new ones
+	PrevLogIndex int64 `json:"prevLogIndex"`
+
+	// PrevLogTerm Term of the entry at prevLogIndex
+	PrevLogTerm int64 `json:"prevLogTerm"`
+
+	// Term Term of the leader
+	Term int64 `json:"term"`
+}
+
+// RaftAppendResponse defines model for RaftAppendResponse.
+type RaftAppendResponse struct {
+	// LastLogIndex Index of the follower's last log entry, used to find where the logs diverge
+	LastLogIndex int64 `json:"lastLogIndex"`
+
+	// Success Whether the follower had the entry at prevLogIndex and stored the entries
+	Success bool `json:"success"`
+
+	// Term Current term of the follower, for the leader to update itself
+	Term int64 `json:"term"`
+}
+
+// RaftEntry Entry of a Raft log
+type RaftEntry struct {
+	// Data Command of the entry, absent for the no-op a new leader appends
+	Data *[]byte `json:"data,omitempty"`
+
+	// Index Position of the entry in the log, starting at 1
+	Index int64 `json:"index"`
+
+	// Term Term of the leader that created the entry
+	Term int64 `json:"term"`
+}
+
+// RaftSnapshotRequest defines model for RaftSnapshotRequest.
+type RaftSnapshotRequest struct {
+	// Data State machine after applying every entry up to lastIncludedIndex
+	Data []byte `json:"data"`
+
+	// LastIncludedIndex Index of the last entry the snapshot covers
+	LastIncludedIndex int64 `json:"lastIncludedIndex"`
+
+	// LastIncludedTerm Term of the entry at lastIncludedIndex
+	LastIncludedTerm int64 `json:"lastIncludedTerm"`
+
+	// LeaderId ID of the leader node
+	LeaderId openapi_types.UUID `json:"leaderId"`
+
+	// Term Term of the leader
+	Term int64 `json:"term"`
+}
+
+// RaftSnapshotResponse defines model for RaftSnapshotResponse.
+type RaftSnapshotResponse struct {
+	// Term Current term of the follower, for the leader to update itself
+	Term int64 `json:"term"`
+}
+
+// RaftVoteRequest defines model for RaftVoteRequest.
+type RaftVoteRequest struct {
+	// CandidateId ID of the candidate node
+	CandidateId openapi_types.UUID `json:"candidateId"`
+
+	// LastLogIndex Index of the candidate's last log entry
+	LastLogIndex int64 `json:"lastLogIndex"`
+
+	// LastLogTerm Term of the candidate's last log entry
+	LastLogTerm int64 `json:"lastLogTerm"`
+
+	// Term Term of the candidate
+	Term int64 `json:"term"`
+}
+
+// RaftVoteResponse defines model for RaftVoteResponse.
+type RaftVoteResponse struct {
+	// Term Current term of the voter, for the candidate to update itself
+	Term int64 `json:"term"`
+
+	// VoteGranted Whether the candidate received the vote
+	VoteGranted bool `json:"voteGranted"`
+}
+
+// ReadConsistency Which nodes may serve a read, strong only reads from the master, bounded from replicas within the configured staleness of the master and any from every replica that is not syncing
+type ReadConsistency string
+
+// ReplicaStatus defines model for ReplicaStatus.
+type ReplicaStatus struct {
+	// Lag Number of operations applied on the master and not acknowledged by the replica
+	Lag int64 `json:"lag"`
+
+	// LastAcknowledgedOperationId ID of the last operation the replica acknowledged
+	LastAcknowledgedOperationId int64 `json:"lastAcknowledgedOperationId"`
+
+	// NodeId ID of the node hosting the replica
+	NodeId openapi_types.UUID `json:"nodeId"`
+
+	// QueueLength Number of operations waiting in the replica's send queue
+	QueueLength int `json:"queueLength"`
+}
+
+// ReplicationMode How partitions replicate writes, primary has the controller pick a master that streams operations to its replicas and raft has every partition's nodes run Raft
+type ReplicationMode string
+
 // SetValueRequest defines model for SetValueRequest.
 type SetValueRequest struct {
 	// Value The value to associate with the key
 	Value string `json:"value"`
 }
 
+// Snapshot defines model for Snapshot.
+type Snapshot struct {
+	// Data Point-in-time copy of every key-value pair in the partition
+	Data map[string]string `json:"data"`
+
+	// LastOperationId ID of the last operation included in the snapshot, -1 when empty
+	LastOperationId int64 `json:"lastOperationId"`
+
+	// PartitionId Partition ID the snapshot was taken from
+	PartitionId string `json:"partitionId"`
+}
+
 // State defines model for State.
 type State struct {
 	// IsResharding Whether the cluster is currently in re-sharding mode
 	IsResharding bool `json:"isResharding"`
 
+	// LoadBalancers Load balancers registered with the controller, they receive the state
+	LoadBalancers *[]LoadBalancer `json:"loadBalancers,omitempty"`
+
 	// MigrationRanges Hash ranges that need to be migrated during re-sharding
 	MigrationRanges *[]MigrationRange `json:"migrationRanges,omitempty"`
 
@@ -178,16 +418,43 @@ type State struct {
 	// Partitions Map of partitions in the distributed key-value store, keyed by partition ID
 	Partitions map[string]Partition `json:"partitions"`
 
+	// PendingMigrationCommits Migration ranges of a completed re-sharding whose source partitions still have to delete the migrated keys
+	PendingMigrationCommits *[]MigrationRange `json:"pendingMigrationCommits,omitempty"`
+
 	// ReplicaCount number of replicas each partition should have
 	ReplicaCount int `json:"replicaCount"`
 
+	// ReplicationMode How partitions replicate writes, primary has the controller pick a master that streams operations to its replicas and raft has every partition's nodes run Raft
+	ReplicationMode *ReplicationMode `json:"replicationMode,omitempty"`
+
 	// UnRegisteredNodes Array of nodes that have not been fully registered
 	UnRegisteredNodes []Node `json:"unRegisteredNodes"`
 
+	// Version Incremented by the controller on every change of the state
+	Version int64 `json:"version"`
+
 	// VirtualNodes Array of virtual nodes used for consistent hashing
 	VirtualNodes []VirtualNode `json:"virtualNodes"`
 }
 
+// StateAck defines model for StateAck.
+type StateAck struct {
+	// Version Version of the state the receiver holds, older states are ignored so it may be newer than the one sent
+	Version int64 `json:"version"`
+}
+
+// StatePatch Changes of the state since a version the receiver acknowledged
+type StatePatch struct {
+	// BaseVersion Version of the state the patch applies to
+	BaseVersion int64 `json:"baseVersion"`
+
+	// Patch JSON merge patch (RFC 7386) turning the base state into the new one
+	Patch json.RawMessage `json:"patch"`
+
+	// Version Version of the state the patch results in
+	Version int64 `json:"version"`
+}
+
 // Status Health status of a node
 type Status string
 
@@ -202,3 +469,6 @@ type VirtualNode struct {
 	// PartitionId ID of the partition this virtual node belongs to
 	PartitionId string `json:"partitionId"`
 }
+
+// WriteConcern Number of nodes that must apply a write before it is acknowledged, 1 for the master only, majority or all of the partition's nodes
+type WriteConcern string
@@ -0,0 +1,41 @@
+package common
+
+// Error codes returned in ErrorResponse.Error
+const (
+	// StaleEpochErrorCode rejects a write or a replicated operation routed
+	// with another epoch than the one the node knows for the partition
+	StaleEpochErrorCode = "STALE_EPOCH"
+	// WriteConcernTimeoutErrorCode reports a write applied on the master that not
+	// enough replicas acknowledged before the timeout
+	WriteConcernTimeoutErrorCode = "WRITE_CONCERN_TIMEOUT"
+	// WriteConcernFailedErrorCode reports a write applied on the master that too
+	// many replicas rejected or could not be reached for
+	WriteConcernFailedErrorCode = "WRITE_CONCERN_FAILED"
+	// ReplicaBehindErrorCode reports a read on a replica that did not apply the
+	// operation of the read's session in time
+	ReplicaBehindErrorCode = "REPLICA_BEHIND"
+	// NotLeaderErrorCode rejects a write sent to a node that is not the Raft
+	// leader of the partition, the message names the leader when known
+	NotLeaderErrorCode = "NOT_LEADER"
+	// NoLeaderErrorCode rejects a request to a controller instance while no
+	// instance leads the cluster
+	NoLeaderErrorCode = "NO_LEADER"
+	// InvalidRequestErrorCode rejects a change of the cluster with invalid arguments
+	InvalidRequestErrorCode = "INVALID_REQUEST"
+	// NodeNotFoundErrorCode rejects a change of a node the controller does not know
+	NodeNotFoundErrorCode = "NODE_NOT_FOUND"
+	// BalancerNotFoundErrorCode rejects a change of a load balancer the controller does not know
+	BalancerNotFoundErrorCode = "BALANCER_NOT_FOUND"
+	// NodeExistsErrorCode rejects adding a node whose ID or address is already known
+	NodeExistsErrorCode = "NODE_EXISTS"
+	// ClusterBusyErrorCode rejects a change of the cluster while a resharding or
+	// a move of partitions it conflicts with is in progress
+	ClusterBusyErrorCode = "CLUSTER_BUSY"
+	// NoCapacityErrorCode rejects a change of the cluster too few nodes are available for
+	NoCapacityErrorCode = "NO_CAPACITY"
+	// StateVersionMismatchErrorCode rejects a patch of the cluster state whose base
+	// version is not the version the receiver holds
+	StateVersionMismatchErrorCode = "STATE_VERSION_MISMATCH"
+	// InternalErrorCode reports an unexpected failure of the controller
+	InternalErrorCode = "INTERNAL_ERROR"
+)
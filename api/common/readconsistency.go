@@ -0,0 +1,13 @@
+package common
+
+import "fmt"
+
+// ParseReadConsistency validates a read consistency coming from configuration or a flag
+func ParseReadConsistency(consistency string) (ReadConsistency, error) {
+	switch ReadConsistency(consistency) {
+	case ReadConsistencyStrong, ReadConsistencyBounded, ReadConsistencyAny:
+		return ReadConsistency(consistency), nil
+	default:
+		return "", fmt.Errorf("unknown read consistency %q", consistency)
+	}
+}
@@ -0,0 +1,19 @@
+package common
+
+import "fmt"
+
+// ParseReplicationMode validates a replication mode coming from configuration
+func ParseReplicationMode(mode string) (ReplicationMode, error) {
+	switch ReplicationMode(mode) {
+	case ReplicationModePrimary, ReplicationModeRaft:
+		return ReplicationMode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown replication mode %q", mode)
+	}
+}
+
+// UsesRaft reports whether the partitions of the state replicate through Raft, states
+// without a replication mode use primary replication
+func (s State) UsesRaft() bool {
+	return s.ReplicationMode != nil && *s.ReplicationMode == ReplicationModeRaft
+}
@@ -6,14 +6,34 @@ import (
 	"sort"
 )
 
+// KeyHash returns the position of key on the consistent hashing ring
+func KeyHash(key string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+// Contains reports whether hash falls in the (RangeStart, RangeEnd] range,
+// wrapping around the end of the ring when RangeStart is not below RangeEnd
+func (m MigrationRange) Contains(hash int64) bool {
+	return HashInRange(hash, m.RangeStart, m.RangeEnd)
+}
+
+// HashInRange reports whether hash falls in the (start, end] range of the ring
+func HashInRange(hash, start, end int64) bool {
+	if start < end {
+		return hash > start && hash <= end
+	}
+
+	return hash > start || hash <= end
+}
+
 func (s *State) GetPartition(key string) (*Partition, error) {
 	if len(s.VirtualNodes) == 0 {
 		return nil, errors.New("no virtual nodes available")
 	}
 
-	h := fnv.New64a()
-	h.Write([]byte(key))
-	keyHash := int64(h.Sum64())
+	keyHash := KeyHash(key)
 
 	idx := s.findVirtualNode(keyHash)
 	if idx == -1 {
@@ -31,6 +51,33 @@ func (s *State) GetPartition(key string) (*Partition, error) {
 	return nil, errors.New("partition not found")
 }
 
+// GetMigrationRange returns the migration range of an ongoing reshard the key falls in
+func (s *State) GetMigrationRange(key string) (*MigrationRange, bool) {
+	if !s.IsResharding || s.MigrationRanges == nil {
+		return nil, false
+	}
+
+	keyHash := KeyHash(key)
+
+	for _, migrationRange := range *s.MigrationRanges {
+		if migrationRange.Contains(keyHash) {
+			return &migrationRange, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetPartitionByID returns the partition with the given ID
+func (s *State) GetPartitionByID(partitionID string) (*Partition, error) {
+	partition, found := s.Partitions[partitionID]
+	if !found {
+		return nil, errors.New("partition not found")
+	}
+
+	return &partition, nil
+}
+
 func (s *State) findVirtualNode(keyHash int64) int {
 	idx := sort.Search(len(s.VirtualNodes), func(i int) bool {
 		return s.VirtualNodes[i].Hash >= keyHash
@@ -0,0 +1,137 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrStateVersionMismatch rejects a patch whose base version is not the version of the state it is applied to
+var ErrStateVersionMismatch = errors.New("state version mismatch")
+
+// DiffStates returns the JSON merge patch turning base into target
+func DiffStates(base, target State) (StatePatch, error) {
+	baseDoc, err := stateDocument(base)
+	if err != nil {
+		return StatePatch{}, err
+	}
+
+	targetDoc, err := stateDocument(target)
+	if err != nil {
+		return StatePatch{}, err
+	}
+
+	patch, err := json.Marshal(mergeDiff(baseDoc, targetDoc))
+	if err != nil {
+		return StatePatch{}, fmt.Errorf("could not encode state patch: %w", err)
+	}
+
+	return StatePatch{BaseVersion: base.Version, Version: target.Version, Patch: patch}, nil
+}
+
+// ApplyStatePatch returns the state the patch turns s into, s must be the base version of the patch
+func (s State) ApplyStatePatch(patch StatePatch) (State, error) {
+	if s.Version != patch.BaseVersion {
+		return State{}, fmt.Errorf("%w: patch of version %d, state version %d",
+			ErrStateVersionMismatch, patch.BaseVersion, s.Version)
+	}
+
+	doc, err := stateDocument(s)
+	if err != nil {
+		return State{}, err
+	}
+
+	var changes map[string]any
+	if err := decodeDocument(patch.Patch, &changes); err != nil {
+		return State{}, fmt.Errorf("could not decode state patch: %w", err)
+	}
+
+	data, err := json.Marshal(mergePatch(doc, changes))
+	if err != nil {
+		return State{}, fmt.Errorf("could not encode patched state: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("could not decode patched state: %w", err)
+	}
+
+	return state, nil
+}
+
+// stateDocument returns the JSON object of a state, numbers are kept as json.Number so that
+// 64 bit hashes survive
+func stateDocument(state State) (map[string]any, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode state: %w", err)
+	}
+
+	var doc map[string]any
+	if err := decodeDocument(data, &doc); err != nil {
+		return nil, fmt.Errorf("could not decode state: %w", err)
+	}
+
+	return doc, nil
+}
+
+func decodeDocument(data []byte, doc *map[string]any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(doc)
+}
+
+// mergeDiff returns the merge patch turning the object base into target: removed members are
+// set to null, changed objects are diffed recursively and any other changed value is replaced
+func mergeDiff(base, target map[string]any) map[string]any {
+	patch := make(map[string]any)
+	for key := range base {
+		if _, found := target[key]; !found {
+			patch[key] = nil
+		}
+	}
+
+	for key, targetValue := range target {
+		baseValue, found := base[key]
+		if found && reflect.DeepEqual(baseValue, targetValue) {
+			continue
+		}
+
+		baseObject, baseIsObject := baseValue.(map[string]any)
+		targetObject, targetIsObject := targetValue.(map[string]any)
+		if found && baseIsObject && targetIsObject {
+			patch[key] = mergeDiff(baseObject, targetObject)
+			continue
+		}
+
+		patch[key] = targetValue
+	}
+
+	return patch
+}
+
+// mergePatch applies the merge patch to the object doc
+func mergePatch(doc, patch map[string]any) map[string]any {
+	if doc == nil {
+		doc = make(map[string]any)
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(doc, key)
+			continue
+		}
+
+		if object, isObject := value.(map[string]any); isObject {
+			docObject, _ := doc[key].(map[string]any)
+			doc[key] = mergePatch(docObject, object)
+			continue
+		}
+
+		doc[key] = value
+	}
+
+	return doc
+}
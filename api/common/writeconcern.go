@@ -0,0 +1,27 @@
+package common
+
+import "fmt"
+
+// ParseWriteConcern validates a write concern coming from configuration or a flag
+func ParseWriteConcern(concern string) (WriteConcern, error) {
+	switch WriteConcern(concern) {
+	case WriteConcernOne, WriteConcernMajority, WriteConcernAll:
+		return WriteConcern(concern), nil
+	default:
+		return "", fmt.Errorf("unknown write concern %q", concern)
+	}
+}
+
+// RequiredAcks returns how many of replicaCount replicas must acknowledge a write
+// besides the master to satisfy the write concern
+func (w WriteConcern) RequiredAcks(replicaCount int) int {
+	switch w {
+	case WriteConcernMajority:
+		// The master counts towards the majority of the partition's nodes
+		return (replicaCount + 1) / 2
+	case WriteConcernAll:
+		return replicaCount
+	default:
+		return 0
+	}
+}
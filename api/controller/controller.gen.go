@@ -15,14 +15,39 @@ import (
 
 	externalRef0 "github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// BalancerRegistration defines model for BalancerRegistration.
+type BalancerRegistration struct {
+	// Address Network address of the private API of the load balancer (host:port)
+	Address string `json:"address"`
+}
+
+// Heartbeat defines model for Heartbeat.
+type Heartbeat struct {
+	// Partitions Status of every partition the node hosts
+	Partitions []externalRef0.PartitionStatus `json:"partitions"`
+}
+
+// NodeAddition defines model for NodeAddition.
+type NodeAddition struct {
+	// Address Network address of the node (host:port)
+	Address string `json:"address"`
+}
+
 // NodeRegistration defines model for NodeRegistration.
 type NodeRegistration struct {
 	// Address Network address of the node (host:port)
 	Address string `json:"address"`
+
+	// Labels Failure domains a node lies in, replicas of a partition are spread over distinct domains
+	Labels *externalRef0.NodeLabels `json:"labels,omitempty"`
+
+	// Weight Relative capacity of the node, defaults to 1
+	Weight *float64 `json:"weight,omitempty"`
 }
 
 // NodeRegistrationResponse defines model for NodeRegistrationResponse.
@@ -34,9 +59,54 @@ type NodeRegistrationResponse struct {
 	Status externalRef0.Status `json:"status"`
 }
 
+// PartitionCountRequest defines model for PartitionCountRequest.
+type PartitionCountRequest struct {
+	// PartitionCount Number of partitions, at least 1
+	PartitionCount int `json:"partitionCount"`
+}
+
+// ReplicaCountRequest defines model for ReplicaCountRequest.
+type ReplicaCountRequest struct {
+	// ReplicaCount Number of replicas of every partition besides its master
+	ReplicaCount int `json:"replicaCount"`
+}
+
+// GetStateParams defines parameters for GetState.
+type GetStateParams struct {
+	// SinceVersion Version the caller holds, the state is only returned once it is newer
+	SinceVersion *int64 `form:"sinceVersion,omitempty" json:"sinceVersion,omitempty"`
+
+	// TimeoutSeconds Seconds to wait for a newer state, 30 by default and at most 60
+	TimeoutSeconds *int `form:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+}
+
+// PostBalancersRegisterJSONRequestBody defines body for PostBalancersRegister for application/json ContentType.
+type PostBalancersRegisterJSONRequestBody = BalancerRegistration
+
+// PostNodesJSONRequestBody defines body for PostNodes for application/json ContentType.
+type PostNodesJSONRequestBody = NodeAddition
+
 // PostNodesRegisterJSONRequestBody defines body for PostNodesRegister for application/json ContentType.
 type PostNodesRegisterJSONRequestBody = NodeRegistration
 
+// PostNodesNodeIdHeartbeatJSONRequestBody defines body for PostNodesNodeIdHeartbeat for application/json ContentType.
+type PostNodesNodeIdHeartbeatJSONRequestBody = Heartbeat
+
+// PutPartitionCountJSONRequestBody defines body for PutPartitionCount for application/json ContentType.
+type PutPartitionCountJSONRequestBody = PartitionCountRequest
+
+// PostRaftAppendJSONRequestBody defines body for PostRaftAppend for application/json ContentType.
+type PostRaftAppendJSONRequestBody = externalRef0.RaftAppendRequest
+
+// PostRaftSnapshotJSONRequestBody defines body for PostRaftSnapshot for application/json ContentType.
+type PostRaftSnapshotJSONRequestBody = externalRef0.RaftSnapshotRequest
+
+// PostRaftVoteJSONRequestBody defines body for PostRaftVote for application/json ContentType.
+type PostRaftVoteJSONRequestBody = externalRef0.RaftVoteRequest
+
+// PutReplicaCountJSONRequestBody defines body for PutReplicaCount for application/json ContentType.
+type PutReplicaCountJSONRequestBody = ReplicaCountRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -110,17 +180,69 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetBalancers request
+	GetBalancers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostBalancersRegisterWithBody request with any body
+	PostBalancersRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostBalancersRegister(ctx context.Context, body PostBalancersRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteBalancersBalancerId request
+	DeleteBalancersBalancerId(ctx context.Context, balancerID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostNodesWithBody request with any body
+	PostNodesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostNodes(ctx context.Context, body PostNodesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostNodesRegisterWithBody request with any body
 	PostNodesRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostNodesRegister(ctx context.Context, body PostNodesRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteNodesNodeId request
+	DeleteNodesNodeId(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostNodesNodeIdAdmit request
+	PostNodesNodeIdAdmit(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostNodesNodeIdHeartbeatWithBody request with any body
+	PostNodesNodeIdHeartbeatWithBody(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostNodesNodeIdHeartbeat(ctx context.Context, nodeID openapi_types.UUID, body PostNodesNodeIdHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutPartitionCountWithBody request with any body
+	PutPartitionCountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutPartitionCount(ctx context.Context, body PutPartitionCountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRaftAppendWithBody request with any body
+	PostRaftAppendWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostRaftAppend(ctx context.Context, body PostRaftAppendJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRaftSnapshotWithBody request with any body
+	PostRaftSnapshotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostRaftSnapshot(ctx context.Context, body PostRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRaftVoteWithBody request with any body
+	PostRaftVoteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostRaftVote(ctx context.Context, body PostRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutReplicaCountWithBody request with any body
+	PutReplicaCountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutReplicaCount(ctx context.Context, body PutReplicaCountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetState request
-	GetState(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetState(ctx context.Context, params *GetStateParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) PostNodesRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostNodesRegisterRequestWithBody(c.Server, contentType, body)
+func (c *Client) GetBalancers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBalancersRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -131,8 +253,8 @@ func (c *Client) PostNodesRegisterWithBody(ctx context.Context, contentType stri
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostNodesRegister(ctx context.Context, body PostNodesRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostNodesRegisterRequest(c.Server, body)
+func (c *Client) PostBalancersRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBalancersRegisterRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -143,8 +265,8 @@ func (c *Client) PostNodesRegister(ctx context.Context, body PostNodesRegisterJS
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetState(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetStateRequest(c.Server)
+func (c *Client) PostBalancersRegister(ctx context.Context, body PostBalancersRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBalancersRegisterRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -155,525 +277,3255 @@ func (c *Client) GetState(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	return c.Client.Do(req)
 }
 
-// NewPostNodesRegisterRequest calls the generic PostNodesRegister builder with application/json body
-func NewPostNodesRegisterRequest(server string, body PostNodesRegisterJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) DeleteBalancersBalancerId(ctx context.Context, balancerID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBalancersBalancerIdRequest(c.Server, balancerID)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostNodesRegisterRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostNodesRegisterRequestWithBody generates requests for PostNodesRegister with any type of body
-func NewPostNodesRegisterRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostNodesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNodesRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/nodes/register")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostNodes(ctx context.Context, body PostNodesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNodesRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetStateRequest generates requests for GetState
-func NewGetStateRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostNodesRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNodesRegisterRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/state")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostNodesRegister(ctx context.Context, body PostNodesRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNodesRegisterRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) DeleteNodesNodeId(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteNodesNodeIdRequest(c.Server, nodeID)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
-}
-
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) PostNodesNodeIdAdmit(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNodesNodeIdAdmitRequest(c.Server, nodeID)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
-
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostNodesRegisterWithBodyWithResponse request with any body
-	PostNodesRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error)
-
-	PostNodesRegisterWithResponse(ctx context.Context, body PostNodesRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error)
-
-	// GetStateWithResponse request
-	GetStateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStateResponse, error)
-}
-
-type PostNodesRegisterResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *NodeRegistrationResponse
-}
-
-// Status returns HTTPResponse.Status
-func (r PostNodesRegisterResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostNodesNodeIdHeartbeatWithBody(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNodesNodeIdHeartbeatRequestWithBody(c.Server, nodeID, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
-
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostNodesRegisterResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type GetStateResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.State
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetStateResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostNodesNodeIdHeartbeat(ctx context.Context, nodeID openapi_types.UUID, body PostNodesNodeIdHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNodesNodeIdHeartbeatRequest(c.Server, nodeID, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetStateResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PutPartitionCountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutPartitionCountRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// PostNodesRegisterWithBodyWithResponse request with arbitrary body returning *PostNodesRegisterResponse
-func (c *ClientWithResponses) PostNodesRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error) {
-	rsp, err := c.PostNodesRegisterWithBody(ctx, contentType, body, reqEditors...)
+func (c *Client) PutPartitionCount(ctx context.Context, body PutPartitionCountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutPartitionCountRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostNodesRegisterResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *ClientWithResponses) PostNodesRegisterWithResponse(ctx context.Context, body PostNodesRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error) {
-	rsp, err := c.PostNodesRegister(ctx, body, reqEditors...)
+func (c *Client) PostRaftAppendWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRaftAppendRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostNodesRegisterResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// GetStateWithResponse request returning *GetStateResponse
-func (c *ClientWithResponses) GetStateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStateResponse, error) {
-	rsp, err := c.GetState(ctx, reqEditors...)
+func (c *Client) PostRaftAppend(ctx context.Context, body PostRaftAppendJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRaftAppendRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetStateResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// ParsePostNodesRegisterResponse parses an HTTP response from a PostNodesRegisterWithResponse call
-func ParsePostNodesRegisterResponse(rsp *http.Response) (*PostNodesRegisterResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+func (c *Client) PostRaftSnapshotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRaftSnapshotRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	response := &PostNodesRegisterResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+func (c *Client) PostRaftSnapshot(ctx context.Context, body PostRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRaftSnapshotRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRaftVoteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRaftVoteRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRaftVote(ctx context.Context, body PostRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRaftVoteRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutReplicaCountWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutReplicaCountRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutReplicaCount(ctx context.Context, body PutReplicaCountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutReplicaCountRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetState(ctx context.Context, params *GetStateParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStateRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetBalancersRequest generates requests for GetBalancers
+func NewGetBalancersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/balancers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostBalancersRegisterRequest calls the generic PostBalancersRegister builder with application/json body
+func NewPostBalancersRegisterRequest(server string, body PostBalancersRegisterJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostBalancersRegisterRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostBalancersRegisterRequestWithBody generates requests for PostBalancersRegister with any type of body
+func NewPostBalancersRegisterRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/balancers/register")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteBalancersBalancerIdRequest generates requests for DeleteBalancersBalancerId
+func NewDeleteBalancersBalancerIdRequest(server string, balancerID openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "balancerId", runtime.ParamLocationPath, balancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/balancers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostNodesRequest calls the generic PostNodes builder with application/json body
+func NewPostNodesRequest(server string, body PostNodesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostNodesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostNodesRequestWithBody generates requests for PostNodes with any type of body
+func NewPostNodesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nodes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostNodesRegisterRequest calls the generic PostNodesRegister builder with application/json body
+func NewPostNodesRegisterRequest(server string, body PostNodesRegisterJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostNodesRegisterRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostNodesRegisterRequestWithBody generates requests for PostNodesRegister with any type of body
+func NewPostNodesRegisterRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nodes/register")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteNodesNodeIdRequest generates requests for DeleteNodesNodeId
+func NewDeleteNodesNodeIdRequest(server string, nodeID openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "nodeId", runtime.ParamLocationPath, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nodes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostNodesNodeIdAdmitRequest generates requests for PostNodesNodeIdAdmit
+func NewPostNodesNodeIdAdmitRequest(server string, nodeID openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "nodeId", runtime.ParamLocationPath, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nodes/%s/admit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostNodesNodeIdHeartbeatRequest calls the generic PostNodesNodeIdHeartbeat builder with application/json body
+func NewPostNodesNodeIdHeartbeatRequest(server string, nodeID openapi_types.UUID, body PostNodesNodeIdHeartbeatJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostNodesNodeIdHeartbeatRequestWithBody(server, nodeID, "application/json", bodyReader)
+}
+
+// NewPostNodesNodeIdHeartbeatRequestWithBody generates requests for PostNodesNodeIdHeartbeat with any type of body
+func NewPostNodesNodeIdHeartbeatRequestWithBody(server string, nodeID openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "nodeId", runtime.ParamLocationPath, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/nodes/%s/heartbeat", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPutPartitionCountRequest calls the generic PutPartitionCount builder with application/json body
+func NewPutPartitionCountRequest(server string, body PutPartitionCountJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutPartitionCountRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutPartitionCountRequestWithBody generates requests for PutPartitionCount with any type of body
+func NewPutPartitionCountRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partition-count")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostRaftAppendRequest calls the generic PostRaftAppend builder with application/json body
+func NewPostRaftAppendRequest(server string, body PostRaftAppendJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRaftAppendRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostRaftAppendRequestWithBody generates requests for PostRaftAppend with any type of body
+func NewPostRaftAppendRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/raft/append")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostRaftSnapshotRequest calls the generic PostRaftSnapshot builder with application/json body
+func NewPostRaftSnapshotRequest(server string, body PostRaftSnapshotJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRaftSnapshotRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostRaftSnapshotRequestWithBody generates requests for PostRaftSnapshot with any type of body
+func NewPostRaftSnapshotRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/raft/snapshot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostRaftVoteRequest calls the generic PostRaftVote builder with application/json body
+func NewPostRaftVoteRequest(server string, body PostRaftVoteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRaftVoteRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostRaftVoteRequestWithBody generates requests for PostRaftVote with any type of body
+func NewPostRaftVoteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/raft/vote")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPutReplicaCountRequest calls the generic PutReplicaCount builder with application/json body
+func NewPutReplicaCountRequest(server string, body PutReplicaCountJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutReplicaCountRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutReplicaCountRequestWithBody generates requests for PutReplicaCount with any type of body
+func NewPutReplicaCountRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/replica-count")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetStateRequest generates requests for GetState
+func NewGetStateRequest(server string, params *GetStateParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/state")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SinceVersion != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sinceVersion", runtime.ParamLocationQuery, *params.SinceVersion); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TimeoutSeconds != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "timeoutSeconds", runtime.ParamLocationQuery, *params.TimeoutSeconds); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetBalancersWithResponse request
+	GetBalancersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBalancersResponse, error)
+
+	// PostBalancersRegisterWithBodyWithResponse request with any body
+	PostBalancersRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBalancersRegisterResponse, error)
+
+	PostBalancersRegisterWithResponse(ctx context.Context, body PostBalancersRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBalancersRegisterResponse, error)
+
+	// DeleteBalancersBalancerIdWithResponse request
+	DeleteBalancersBalancerIdWithResponse(ctx context.Context, balancerID openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteBalancersBalancerIdResponse, error)
+
+	// PostNodesWithBodyWithResponse request with any body
+	PostNodesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesResponse, error)
+
+	PostNodesWithResponse(ctx context.Context, body PostNodesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesResponse, error)
+
+	// PostNodesRegisterWithBodyWithResponse request with any body
+	PostNodesRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error)
+
+	PostNodesRegisterWithResponse(ctx context.Context, body PostNodesRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error)
+
+	// DeleteNodesNodeIdWithResponse request
+	DeleteNodesNodeIdWithResponse(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteNodesNodeIdResponse, error)
+
+	// PostNodesNodeIdAdmitWithResponse request
+	PostNodesNodeIdAdmitWithResponse(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostNodesNodeIdAdmitResponse, error)
+
+	// PostNodesNodeIdHeartbeatWithBodyWithResponse request with any body
+	PostNodesNodeIdHeartbeatWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesNodeIdHeartbeatResponse, error)
+
+	PostNodesNodeIdHeartbeatWithResponse(ctx context.Context, nodeID openapi_types.UUID, body PostNodesNodeIdHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesNodeIdHeartbeatResponse, error)
+
+	// PutPartitionCountWithBodyWithResponse request with any body
+	PutPartitionCountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutPartitionCountResponse, error)
+
+	PutPartitionCountWithResponse(ctx context.Context, body PutPartitionCountJSONRequestBody, reqEditors ...RequestEditorFn) (*PutPartitionCountResponse, error)
+
+	// PostRaftAppendWithBodyWithResponse request with any body
+	PostRaftAppendWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRaftAppendResponse, error)
+
+	PostRaftAppendWithResponse(ctx context.Context, body PostRaftAppendJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRaftAppendResponse, error)
+
+	// PostRaftSnapshotWithBodyWithResponse request with any body
+	PostRaftSnapshotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRaftSnapshotResponse, error)
+
+	PostRaftSnapshotWithResponse(ctx context.Context, body PostRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRaftSnapshotResponse, error)
+
+	// PostRaftVoteWithBodyWithResponse request with any body
+	PostRaftVoteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRaftVoteResponse, error)
+
+	PostRaftVoteWithResponse(ctx context.Context, body PostRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRaftVoteResponse, error)
+
+	// PutReplicaCountWithBodyWithResponse request with any body
+	PutReplicaCountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutReplicaCountResponse, error)
+
+	PutReplicaCountWithResponse(ctx context.Context, body PutReplicaCountJSONRequestBody, reqEditors ...RequestEditorFn) (*PutReplicaCountResponse, error)
+
+	// GetStateWithResponse request
+	GetStateWithResponse(ctx context.Context, params *GetStateParams, reqEditors ...RequestEditorFn) (*GetStateResponse, error)
+}
+
+type GetBalancersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.LoadBalancer
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBalancersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBalancersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBalancersRegisterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *externalRef0.LoadBalancer
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBalancersRegisterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBalancersRegisterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBalancersBalancerIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBalancersBalancerIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBalancersBalancerIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostNodesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *externalRef0.Node
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostNodesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostNodesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostNodesRegisterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *NodeRegistrationResponse
+	JSON503      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostNodesRegisterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostNodesRegisterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteNodesNodeIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteNodesNodeIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteNodesNodeIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostNodesNodeIdAdmitResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Node
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostNodesNodeIdAdmitResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostNodesNodeIdAdmitResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostNodesNodeIdHeartbeatResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostNodesNodeIdHeartbeatResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostNodesNodeIdHeartbeatResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutPartitionCountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *externalRef0.State
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutPartitionCountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutPartitionCountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRaftAppendResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.RaftAppendResponse
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRaftAppendResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRaftAppendResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRaftSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.RaftSnapshotResponse
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRaftSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRaftSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRaftVoteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.RaftVoteResponse
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRaftVoteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRaftVoteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutReplicaCountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.State
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutReplicaCountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutReplicaCountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.State
+	JSON503      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetBalancersWithResponse request returning *GetBalancersResponse
+func (c *ClientWithResponses) GetBalancersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBalancersResponse, error) {
+	rsp, err := c.GetBalancers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBalancersResponse(rsp)
+}
+
+// PostBalancersRegisterWithBodyWithResponse request with arbitrary body returning *PostBalancersRegisterResponse
+func (c *ClientWithResponses) PostBalancersRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBalancersRegisterResponse, error) {
+	rsp, err := c.PostBalancersRegisterWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBalancersRegisterResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostBalancersRegisterWithResponse(ctx context.Context, body PostBalancersRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBalancersRegisterResponse, error) {
+	rsp, err := c.PostBalancersRegister(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBalancersRegisterResponse(rsp)
+}
+
+// DeleteBalancersBalancerIdWithResponse request returning *DeleteBalancersBalancerIdResponse
+func (c *ClientWithResponses) DeleteBalancersBalancerIdWithResponse(ctx context.Context, balancerID openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteBalancersBalancerIdResponse, error) {
+	rsp, err := c.DeleteBalancersBalancerId(ctx, balancerID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBalancersBalancerIdResponse(rsp)
+}
+
+// PostNodesWithBodyWithResponse request with arbitrary body returning *PostNodesResponse
+func (c *ClientWithResponses) PostNodesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesResponse, error) {
+	rsp, err := c.PostNodesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNodesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostNodesWithResponse(ctx context.Context, body PostNodesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesResponse, error) {
+	rsp, err := c.PostNodes(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNodesResponse(rsp)
+}
+
+// PostNodesRegisterWithBodyWithResponse request with arbitrary body returning *PostNodesRegisterResponse
+func (c *ClientWithResponses) PostNodesRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error) {
+	rsp, err := c.PostNodesRegisterWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNodesRegisterResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostNodesRegisterWithResponse(ctx context.Context, body PostNodesRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesRegisterResponse, error) {
+	rsp, err := c.PostNodesRegister(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNodesRegisterResponse(rsp)
+}
+
+// DeleteNodesNodeIdWithResponse request returning *DeleteNodesNodeIdResponse
+func (c *ClientWithResponses) DeleteNodesNodeIdWithResponse(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteNodesNodeIdResponse, error) {
+	rsp, err := c.DeleteNodesNodeId(ctx, nodeID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteNodesNodeIdResponse(rsp)
+}
+
+// PostNodesNodeIdAdmitWithResponse request returning *PostNodesNodeIdAdmitResponse
+func (c *ClientWithResponses) PostNodesNodeIdAdmitWithResponse(ctx context.Context, nodeID openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostNodesNodeIdAdmitResponse, error) {
+	rsp, err := c.PostNodesNodeIdAdmit(ctx, nodeID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNodesNodeIdAdmitResponse(rsp)
+}
+
+// PostNodesNodeIdHeartbeatWithBodyWithResponse request with arbitrary body returning *PostNodesNodeIdHeartbeatResponse
+func (c *ClientWithResponses) PostNodesNodeIdHeartbeatWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNodesNodeIdHeartbeatResponse, error) {
+	rsp, err := c.PostNodesNodeIdHeartbeatWithBody(ctx, nodeID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNodesNodeIdHeartbeatResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostNodesNodeIdHeartbeatWithResponse(ctx context.Context, nodeID openapi_types.UUID, body PostNodesNodeIdHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNodesNodeIdHeartbeatResponse, error) {
+	rsp, err := c.PostNodesNodeIdHeartbeat(ctx, nodeID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNodesNodeIdHeartbeatResponse(rsp)
+}
+
+// PutPartitionCountWithBodyWithResponse request with arbitrary body returning *PutPartitionCountResponse
+func (c *ClientWithResponses) PutPartitionCountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutPartitionCountResponse, error) {
+	rsp, err := c.PutPartitionCountWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutPartitionCountResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutPartitionCountWithResponse(ctx context.Context, body PutPartitionCountJSONRequestBody, reqEditors ...RequestEditorFn) (*PutPartitionCountResponse, error) {
+	rsp, err := c.PutPartitionCount(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutPartitionCountResponse(rsp)
+}
+
+// PostRaftAppendWithBodyWithResponse request with arbitrary body returning *PostRaftAppendResponse
+func (c *ClientWithResponses) PostRaftAppendWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRaftAppendResponse, error) {
+	rsp, err := c.PostRaftAppendWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRaftAppendResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRaftAppendWithResponse(ctx context.Context, body PostRaftAppendJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRaftAppendResponse, error) {
+	rsp, err := c.PostRaftAppend(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRaftAppendResponse(rsp)
+}
+
+// PostRaftSnapshotWithBodyWithResponse request with arbitrary body returning *PostRaftSnapshotResponse
+func (c *ClientWithResponses) PostRaftSnapshotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRaftSnapshotResponse, error) {
+	rsp, err := c.PostRaftSnapshotWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRaftSnapshotResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRaftSnapshotWithResponse(ctx context.Context, body PostRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRaftSnapshotResponse, error) {
+	rsp, err := c.PostRaftSnapshot(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRaftSnapshotResponse(rsp)
+}
+
+// PostRaftVoteWithBodyWithResponse request with arbitrary body returning *PostRaftVoteResponse
+func (c *ClientWithResponses) PostRaftVoteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRaftVoteResponse, error) {
+	rsp, err := c.PostRaftVoteWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRaftVoteResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRaftVoteWithResponse(ctx context.Context, body PostRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRaftVoteResponse, error) {
+	rsp, err := c.PostRaftVote(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRaftVoteResponse(rsp)
+}
+
+// PutReplicaCountWithBodyWithResponse request with arbitrary body returning *PutReplicaCountResponse
+func (c *ClientWithResponses) PutReplicaCountWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutReplicaCountResponse, error) {
+	rsp, err := c.PutReplicaCountWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutReplicaCountResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutReplicaCountWithResponse(ctx context.Context, body PutReplicaCountJSONRequestBody, reqEditors ...RequestEditorFn) (*PutReplicaCountResponse, error) {
+	rsp, err := c.PutReplicaCount(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutReplicaCountResponse(rsp)
+}
+
+// GetStateWithResponse request returning *GetStateResponse
+func (c *ClientWithResponses) GetStateWithResponse(ctx context.Context, params *GetStateParams, reqEditors ...RequestEditorFn) (*GetStateResponse, error) {
+	rsp, err := c.GetState(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStateResponse(rsp)
+}
+
+// ParseGetBalancersResponse parses an HTTP response from a GetBalancersWithResponse call
+func ParseGetBalancersResponse(rsp *http.Response) (*GetBalancersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBalancersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []externalRef0.LoadBalancer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBalancersRegisterResponse parses an HTTP response from a PostBalancersRegisterWithResponse call
+func ParsePostBalancersRegisterResponse(rsp *http.Response) (*PostBalancersRegisterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBalancersRegisterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest externalRef0.LoadBalancer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBalancersBalancerIdResponse parses an HTTP response from a DeleteBalancersBalancerIdWithResponse call
+func ParseDeleteBalancersBalancerIdResponse(rsp *http.Response) (*DeleteBalancersBalancerIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBalancersBalancerIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostNodesResponse parses an HTTP response from a PostNodesWithResponse call
+func ParsePostNodesResponse(rsp *http.Response) (*PostNodesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostNodesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest externalRef0.Node
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostNodesRegisterResponse parses an HTTP response from a PostNodesRegisterWithResponse call
+func ParsePostNodesRegisterResponse(rsp *http.Response) (*PostNodesRegisterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostNodesRegisterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
 		var dest NodeRegistrationResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteNodesNodeIdResponse parses an HTTP response from a DeleteNodesNodeIdWithResponse call
+func ParseDeleteNodesNodeIdResponse(rsp *http.Response) (*DeleteNodesNodeIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteNodesNodeIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostNodesNodeIdAdmitResponse parses an HTTP response from a PostNodesNodeIdAdmitWithResponse call
+func ParsePostNodesNodeIdAdmitResponse(rsp *http.Response) (*PostNodesNodeIdAdmitResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostNodesNodeIdAdmitResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.Node
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostNodesNodeIdHeartbeatResponse parses an HTTP response from a PostNodesNodeIdHeartbeatWithResponse call
+func ParsePostNodesNodeIdHeartbeatResponse(rsp *http.Response) (*PostNodesNodeIdHeartbeatResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostNodesNodeIdHeartbeatResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutPartitionCountResponse parses an HTTP response from a PutPartitionCountWithResponse call
+func ParsePutPartitionCountResponse(rsp *http.Response) (*PutPartitionCountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutPartitionCountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest externalRef0.State
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRaftAppendResponse parses an HTTP response from a PostRaftAppendWithResponse call
+func ParsePostRaftAppendResponse(rsp *http.Response) (*PostRaftAppendResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRaftAppendResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.RaftAppendResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRaftSnapshotResponse parses an HTTP response from a PostRaftSnapshotWithResponse call
+func ParsePostRaftSnapshotResponse(rsp *http.Response) (*PostRaftSnapshotResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRaftSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.RaftSnapshotResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRaftVoteResponse parses an HTTP response from a PostRaftVoteWithResponse call
+func ParsePostRaftVoteResponse(rsp *http.Response) (*PostRaftVoteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRaftVoteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.RaftVoteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutReplicaCountResponse parses an HTTP response from a PutReplicaCountWithResponse call
+func ParsePutReplicaCountResponse(rsp *http.Response) (*PutReplicaCountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutReplicaCountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.State
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStateResponse parses an HTTP response from a GetStateWithResponse call
+func ParseGetStateResponse(rsp *http.Response) (*GetStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.State
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List the registered load balancers
+	// (GET /balancers)
+	GetBalancers(w http.ResponseWriter, r *http.Request)
+	// Register a load balancer with the controller
+	// (POST /balancers/register)
+	PostBalancersRegister(w http.ResponseWriter, r *http.Request)
+	// Remove a load balancer, it no longer receives the state
+	// (DELETE /balancers/{balancerId})
+	DeleteBalancersBalancerId(w http.ResponseWriter, r *http.Request, balancerID openapi_types.UUID)
+	// Add a node to the cluster by its address
+	// (POST /nodes)
+	PostNodes(w http.ResponseWriter, r *http.Request)
+	// Register a new node with the controller
+	// (POST /nodes/register)
+	PostNodesRegister(w http.ResponseWriter, r *http.Request)
+	// Remove a node from the cluster
+	// (DELETE /nodes/{nodeId})
+	DeleteNodesNodeId(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID)
+	// Admit a node that registered itself into the cluster
+	// (POST /nodes/{nodeId}/admit)
+	PostNodesNodeIdAdmit(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID)
+	// Report that a node is alive together with the status of its partitions
+	// (POST /nodes/{nodeId}/heartbeat)
+	PostNodesNodeIdHeartbeat(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID)
+	// Change the number of partitions
+	// (PUT /partition-count)
+	PutPartitionCount(w http.ResponseWriter, r *http.Request)
+	// Replicate log entries of the leading controller instance, or heartbeat
+	// (POST /raft/append)
+	PostRaftAppend(w http.ResponseWriter, r *http.Request)
+	// Install the snapshot of the leading controller instance on a follower missing compacted entries
+	// (POST /raft/snapshot)
+	PostRaftSnapshot(w http.ResponseWriter, r *http.Request)
+	// Ask for the vote of a controller instance
+	// (POST /raft/vote)
+	PostRaftVote(w http.ResponseWriter, r *http.Request)
+	// Change the number of replicas of every partition
+	// (PUT /replica-count)
+	PutReplicaCount(w http.ResponseWriter, r *http.Request)
+
+	// (GET /state)
+	GetState(w http.ResponseWriter, r *http.Request, params GetStateParams)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List the registered load balancers
+// (GET /balancers)
+func (_ Unimplemented) GetBalancers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a load balancer with the controller
+// (POST /balancers/register)
+func (_ Unimplemented) PostBalancersRegister(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a load balancer, it no longer receives the state
+// (DELETE /balancers/{balancerId})
+func (_ Unimplemented) DeleteBalancersBalancerId(w http.ResponseWriter, r *http.Request, balancerID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a node to the cluster by its address
+// (POST /nodes)
+func (_ Unimplemented) PostNodes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a new node with the controller
+// (POST /nodes/register)
+func (_ Unimplemented) PostNodesRegister(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a node from the cluster
+// (DELETE /nodes/{nodeId})
+func (_ Unimplemented) DeleteNodesNodeId(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Admit a node that registered itself into the cluster
+// (POST /nodes/{nodeId}/admit)
+func (_ Unimplemented) PostNodesNodeIdAdmit(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Report that a node is alive together with the status of its partitions
+// (POST /nodes/{nodeId}/heartbeat)
+func (_ Unimplemented) PostNodesNodeIdHeartbeat(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the number of partitions
+// (PUT /partition-count)
+func (_ Unimplemented) PutPartitionCount(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replicate log entries of the leading controller instance, or heartbeat
+// (POST /raft/append)
+func (_ Unimplemented) PostRaftAppend(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Install the snapshot of the leading controller instance on a follower missing compacted entries
+// (POST /raft/snapshot)
+func (_ Unimplemented) PostRaftSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Ask for the vote of a controller instance
+// (POST /raft/vote)
+func (_ Unimplemented) PostRaftVote(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the number of replicas of every partition
+// (PUT /replica-count)
+func (_ Unimplemented) PutReplicaCount(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /state)
+func (_ Unimplemented) GetState(w http.ResponseWriter, r *http.Request, params GetStateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetBalancers operation middleware
+func (siw *ServerInterfaceWrapper) GetBalancers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBalancers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostBalancersRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostBalancersRegister(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostBalancersRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBalancersBalancerId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBalancersBalancerId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "balancerId" -------------
+	var balancerID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "balancerId", chi.URLParam(r, "balancerId"), &balancerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "balancerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBalancersBalancerId(w, r, balancerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostNodes operation middleware
+func (siw *ServerInterfaceWrapper) PostNodes(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostNodes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostNodesRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostNodesRegister(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostNodesRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteNodesNodeId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteNodesNodeId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "nodeId" -------------
+	var nodeID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "nodeId", chi.URLParam(r, "nodeId"), &nodeID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nodeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteNodesNodeId(w, r, nodeID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostNodesNodeIdAdmit operation middleware
+func (siw *ServerInterfaceWrapper) PostNodesNodeIdAdmit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "nodeId" -------------
+	var nodeID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "nodeId", chi.URLParam(r, "nodeId"), &nodeID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nodeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostNodesNodeIdAdmit(w, r, nodeID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostNodesNodeIdHeartbeat operation middleware
+func (siw *ServerInterfaceWrapper) PostNodesNodeIdHeartbeat(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "nodeId" -------------
+	var nodeID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "nodeId", chi.URLParam(r, "nodeId"), &nodeID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nodeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostNodesNodeIdHeartbeat(w, r, nodeID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutPartitionCount operation middleware
+func (siw *ServerInterfaceWrapper) PutPartitionCount(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutPartitionCount(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRaftAppend operation middleware
+func (siw *ServerInterfaceWrapper) PostRaftAppend(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRaftAppend(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRaftSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) PostRaftSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRaftSnapshot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRaftVote operation middleware
+func (siw *ServerInterfaceWrapper) PostRaftVote(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRaftVote(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutReplicaCount operation middleware
+func (siw *ServerInterfaceWrapper) PutReplicaCount(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutReplicaCount(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetState operation middleware
+func (siw *ServerInterfaceWrapper) GetState(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStateParams
+
+	// ------------- Optional query parameter "sinceVersion" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sinceVersion", r.URL.Query(), &params.SinceVersion)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sinceVersion", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "timeoutSeconds" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timeoutSeconds", r.URL.Query(), &params.TimeoutSeconds)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "timeoutSeconds", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetState(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/balancers", wrapper.GetBalancers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/balancers/register", wrapper.PostBalancersRegister)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/balancers/{balancerId}", wrapper.DeleteBalancersBalancerId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/nodes", wrapper.PostNodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/nodes/register", wrapper.PostNodesRegister)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/nodes/{nodeId}", wrapper.DeleteNodesNodeId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/nodes/{nodeId}/admit", wrapper.PostNodesNodeIdAdmit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/nodes/{nodeId}/heartbeat", wrapper.PostNodesNodeIdHeartbeat)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/partition-count", wrapper.PutPartitionCount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/raft/append", wrapper.PostRaftAppend)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/raft/snapshot", wrapper.PostRaftSnapshot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/raft/vote", wrapper.PostRaftVote)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/replica-count", wrapper.PutReplicaCount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/state", wrapper.GetState)
+	})
+
+	return r
+}
+
+type GetBalancersRequestObject struct {
+}
+
+type GetBalancersResponseObject interface {
+	VisitGetBalancersResponse(w http.ResponseWriter) error
+}
+
+type GetBalancers200JSONResponse []externalRef0.LoadBalancer
+
+func (response GetBalancers200JSONResponse) VisitGetBalancersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostBalancersRegisterRequestObject struct {
+	Body *PostBalancersRegisterJSONRequestBody
+}
+
+type PostBalancersRegisterResponseObject interface {
+	VisitPostBalancersRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostBalancersRegister201JSONResponse externalRef0.LoadBalancer
+
+func (response PostBalancersRegister201JSONResponse) VisitPostBalancersRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostBalancersRegisterdefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
+}
+
+func (response PostBalancersRegisterdefaultJSONResponse) VisitPostBalancersRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteBalancersBalancerIdRequestObject struct {
+	BalancerID openapi_types.UUID `json:"balancerId"`
+}
+
+type DeleteBalancersBalancerIdResponseObject interface {
+	VisitDeleteBalancersBalancerIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteBalancersBalancerId204Response struct {
+}
+
+func (response DeleteBalancersBalancerId204Response) VisitDeleteBalancersBalancerIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteBalancersBalancerIddefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
+}
+
+func (response DeleteBalancersBalancerIddefaultJSONResponse) VisitDeleteBalancersBalancerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostNodesRequestObject struct {
+	Body *PostNodesJSONRequestBody
+}
+
+type PostNodesResponseObject interface {
+	VisitPostNodesResponse(w http.ResponseWriter) error
+}
+
+type PostNodes201JSONResponse externalRef0.Node
+
+func (response PostNodes201JSONResponse) VisitPostNodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNodesdefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
+}
+
+func (response PostNodesdefaultJSONResponse) VisitPostNodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostNodesRegisterRequestObject struct {
+	Body *PostNodesRegisterJSONRequestBody
+}
+
+type PostNodesRegisterResponseObject interface {
+	VisitPostNodesRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostNodesRegister201JSONResponse NodeRegistrationResponse
+
+func (response PostNodesRegister201JSONResponse) VisitPostNodesRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNodesRegister400Response struct {
+}
+
+func (response PostNodesRegister400Response) VisitPostNodesRegisterResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostNodesRegister409Response struct {
+}
+
+func (response PostNodesRegister409Response) VisitPostNodesRegisterResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostNodesRegister503JSONResponse externalRef0.ErrorResponse
+
+func (response PostNodesRegister503JSONResponse) VisitPostNodesRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteNodesNodeIdRequestObject struct {
+	NodeID openapi_types.UUID `json:"nodeId"`
+}
+
+type DeleteNodesNodeIdResponseObject interface {
+	VisitDeleteNodesNodeIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteNodesNodeId202Response struct {
+}
+
+func (response DeleteNodesNodeId202Response) VisitDeleteNodesNodeIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type DeleteNodesNodeIddefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
+}
+
+func (response DeleteNodesNodeIddefaultJSONResponse) VisitDeleteNodesNodeIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostNodesNodeIdAdmitRequestObject struct {
+	NodeID openapi_types.UUID `json:"nodeId"`
+}
+
+type PostNodesNodeIdAdmitResponseObject interface {
+	VisitPostNodesNodeIdAdmitResponse(w http.ResponseWriter) error
+}
+
+type PostNodesNodeIdAdmit200JSONResponse externalRef0.Node
+
+func (response PostNodesNodeIdAdmit200JSONResponse) VisitPostNodesNodeIdAdmitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNodesNodeIdAdmitdefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
+}
+
+func (response PostNodesNodeIdAdmitdefaultJSONResponse) VisitPostNodesNodeIdAdmitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostNodesNodeIdHeartbeatRequestObject struct {
+	NodeID openapi_types.UUID `json:"nodeId"`
+	Body   *PostNodesNodeIdHeartbeatJSONRequestBody
+}
+
+type PostNodesNodeIdHeartbeatResponseObject interface {
+	VisitPostNodesNodeIdHeartbeatResponse(w http.ResponseWriter) error
+}
+
+type PostNodesNodeIdHeartbeat204Response struct {
+}
+
+func (response PostNodesNodeIdHeartbeat204Response) VisitPostNodesNodeIdHeartbeatResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostNodesNodeIdHeartbeatdefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
+}
+
+func (response PostNodesNodeIdHeartbeatdefaultJSONResponse) VisitPostNodesNodeIdHeartbeatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutPartitionCountRequestObject struct {
+	Body *PutPartitionCountJSONRequestBody
+}
+
+type PutPartitionCountResponseObject interface {
+	VisitPutPartitionCountResponse(w http.ResponseWriter) error
+}
+
+type PutPartitionCount202JSONResponse externalRef0.State
+
+func (response PutPartitionCount202JSONResponse) VisitPutPartitionCountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutPartitionCountdefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
+}
+
+func (response PutPartitionCountdefaultJSONResponse) VisitPutPartitionCountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostRaftAppendRequestObject struct {
+	Body *PostRaftAppendJSONRequestBody
+}
+
+type PostRaftAppendResponseObject interface {
+	VisitPostRaftAppendResponse(w http.ResponseWriter) error
+}
+
+type PostRaftAppend200JSONResponse externalRef0.RaftAppendResponse
+
+func (response PostRaftAppend200JSONResponse) VisitPostRaftAppendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	return response, nil
+type PostRaftAppend404JSONResponse externalRef0.ErrorResponse
+
+func (response PostRaftAppend404JSONResponse) VisitPostRaftAppendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseGetStateResponse parses an HTTP response from a GetStateWithResponse call
-func ParseGetStateResponse(rsp *http.Response) (*GetStateResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type PostRaftSnapshotRequestObject struct {
+	Body *PostRaftSnapshotJSONRequestBody
+}
 
-	response := &GetStateResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+type PostRaftSnapshotResponseObject interface {
+	VisitPostRaftSnapshotResponse(w http.ResponseWriter) error
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.State
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+type PostRaftSnapshot200JSONResponse externalRef0.RaftSnapshotResponse
 
-	}
+func (response PostRaftSnapshot200JSONResponse) VisitPostRaftSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	return response, nil
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Register a new node with the controller
-	// (POST /nodes/register)
-	PostNodesRegister(w http.ResponseWriter, r *http.Request)
+type PostRaftSnapshot404JSONResponse externalRef0.ErrorResponse
 
-	// (GET /state)
-	GetState(w http.ResponseWriter, r *http.Request)
+func (response PostRaftSnapshot404JSONResponse) VisitPostRaftSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+type PostRaftVoteRequestObject struct {
+	Body *PostRaftVoteJSONRequestBody
+}
 
-type Unimplemented struct{}
+type PostRaftVoteResponseObject interface {
+	VisitPostRaftVoteResponse(w http.ResponseWriter) error
+}
 
-// Register a new node with the controller
-// (POST /nodes/register)
-func (_ Unimplemented) PostNodesRegister(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+type PostRaftVote200JSONResponse externalRef0.RaftVoteResponse
+
+func (response PostRaftVote200JSONResponse) VisitPostRaftVoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// (GET /state)
-func (_ Unimplemented) GetState(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+type PostRaftVote404JSONResponse externalRef0.ErrorResponse
+
+func (response PostRaftVote404JSONResponse) VisitPostRaftVoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+type PutReplicaCountRequestObject struct {
+	Body *PutReplicaCountJSONRequestBody
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+type PutReplicaCountResponseObject interface {
+	VisitPutReplicaCountResponse(w http.ResponseWriter) error
+}
 
-// PostNodesRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostNodesRegister(w http.ResponseWriter, r *http.Request) {
+type PutReplicaCount200JSONResponse externalRef0.State
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostNodesRegister(w, r)
-	}))
+func (response PutReplicaCount200JSONResponse) VisitPutReplicaCountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler.ServeHTTP(w, r)
+type PutReplicaCountdefaultJSONResponse struct {
+	Body       externalRef0.ErrorResponse
+	StatusCode int
 }
 
-// GetState operation middleware
-func (siw *ServerInterfaceWrapper) GetState(w http.ResponseWriter, r *http.Request) {
+func (response PutReplicaCountdefaultJSONResponse) VisitPutReplicaCountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetState(w, r)
-	}))
+	return json.NewEncoder(w).Encode(response.Body)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+type GetStateRequestObject struct {
+	Params GetStateParams
+}
 
-	handler.ServeHTTP(w, r)
+type GetStateResponseObject interface {
+	VisitGetStateResponse(w http.ResponseWriter) error
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
+type GetState200JSONResponse externalRef0.State
+
+func (response GetState200JSONResponse) VisitGetStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+type GetState304Response struct {
 }
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
+func (response GetState304Response) VisitGetStateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(304)
+	return nil
 }
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
+type GetState503JSONResponse externalRef0.ErrorResponse
+
+func (response GetState503JSONResponse) VisitGetStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List the registered load balancers
+	// (GET /balancers)
+	GetBalancers(ctx context.Context, request GetBalancersRequestObject) (GetBalancersResponseObject, error)
+	// Register a load balancer with the controller
+	// (POST /balancers/register)
+	PostBalancersRegister(ctx context.Context, request PostBalancersRegisterRequestObject) (PostBalancersRegisterResponseObject, error)
+	// Remove a load balancer, it no longer receives the state
+	// (DELETE /balancers/{balancerId})
+	DeleteBalancersBalancerId(ctx context.Context, request DeleteBalancersBalancerIdRequestObject) (DeleteBalancersBalancerIdResponseObject, error)
+	// Add a node to the cluster by its address
+	// (POST /nodes)
+	PostNodes(ctx context.Context, request PostNodesRequestObject) (PostNodesResponseObject, error)
+	// Register a new node with the controller
+	// (POST /nodes/register)
+	PostNodesRegister(ctx context.Context, request PostNodesRegisterRequestObject) (PostNodesRegisterResponseObject, error)
+	// Remove a node from the cluster
+	// (DELETE /nodes/{nodeId})
+	DeleteNodesNodeId(ctx context.Context, request DeleteNodesNodeIdRequestObject) (DeleteNodesNodeIdResponseObject, error)
+	// Admit a node that registered itself into the cluster
+	// (POST /nodes/{nodeId}/admit)
+	PostNodesNodeIdAdmit(ctx context.Context, request PostNodesNodeIdAdmitRequestObject) (PostNodesNodeIdAdmitResponseObject, error)
+	// Report that a node is alive together with the status of its partitions
+	// (POST /nodes/{nodeId}/heartbeat)
+	PostNodesNodeIdHeartbeat(ctx context.Context, request PostNodesNodeIdHeartbeatRequestObject) (PostNodesNodeIdHeartbeatResponseObject, error)
+	// Change the number of partitions
+	// (PUT /partition-count)
+	PutPartitionCount(ctx context.Context, request PutPartitionCountRequestObject) (PutPartitionCountResponseObject, error)
+	// Replicate log entries of the leading controller instance, or heartbeat
+	// (POST /raft/append)
+	PostRaftAppend(ctx context.Context, request PostRaftAppendRequestObject) (PostRaftAppendResponseObject, error)
+	// Install the snapshot of the leading controller instance on a follower missing compacted entries
+	// (POST /raft/snapshot)
+	PostRaftSnapshot(ctx context.Context, request PostRaftSnapshotRequestObject) (PostRaftSnapshotResponseObject, error)
+	// Ask for the vote of a controller instance
+	// (POST /raft/vote)
+	PostRaftVote(ctx context.Context, request PostRaftVoteRequestObject) (PostRaftVoteResponseObject, error)
+	// Change the number of replicas of every partition
+	// (PUT /replica-count)
+	PutReplicaCount(ctx context.Context, request PutReplicaCountRequestObject) (PutReplicaCountResponseObject, error)
+
+	// (GET /state)
+	GetState(ctx context.Context, request GetStateRequestObject) (GetStateResponseObject, error)
 }
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 }
 
-type RequiredParamError struct {
-	ParamName string
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetBalancers operation middleware
+func (sh *strictHandler) GetBalancers(w http.ResponseWriter, r *http.Request) {
+	var request GetBalancersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBalancers(ctx, request.(GetBalancersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBalancers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBalancersResponseObject); ok {
+		if err := validResponse.VisitGetBalancersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostBalancersRegister operation middleware
+func (sh *strictHandler) PostBalancersRegister(w http.ResponseWriter, r *http.Request) {
+	var request PostBalancersRegisterRequestObject
+
+	var body PostBalancersRegisterJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostBalancersRegister(ctx, request.(PostBalancersRegisterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostBalancersRegister")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostBalancersRegisterResponseObject); ok {
+		if err := validResponse.VisitPostBalancersRegisterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteBalancersBalancerId operation middleware
+func (sh *strictHandler) DeleteBalancersBalancerId(w http.ResponseWriter, r *http.Request, balancerID openapi_types.UUID) {
+	var request DeleteBalancersBalancerIdRequestObject
+
+	request.BalancerID = balancerID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteBalancersBalancerId(ctx, request.(DeleteBalancersBalancerIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteBalancersBalancerId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteBalancersBalancerIdResponseObject); ok {
+		if err := validResponse.VisitDeleteBalancersBalancerIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostNodes operation middleware
+func (sh *strictHandler) PostNodes(w http.ResponseWriter, r *http.Request) {
+	var request PostNodesRequestObject
+
+	var body PostNodesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNodes(ctx, request.(PostNodesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNodes")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostNodesResponseObject); ok {
+		if err := validResponse.VisitPostNodesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+// PostNodesRegister operation middleware
+func (sh *strictHandler) PostNodesRegister(w http.ResponseWriter, r *http.Request) {
+	var request PostNodesRegisterRequestObject
+
+	var body PostNodesRegisterJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNodesRegister(ctx, request.(PostNodesRegisterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNodesRegister")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostNodesRegisterResponseObject); ok {
+		if err := validResponse.VisitPostNodesRegisterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+// DeleteNodesNodeId operation middleware
+func (sh *strictHandler) DeleteNodesNodeId(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	var request DeleteNodesNodeIdRequestObject
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	request.NodeID = nodeID
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteNodesNodeId(ctx, request.(DeleteNodesNodeIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteNodesNodeId")
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteNodesNodeIdResponseObject); ok {
+		if err := validResponse.VisitDeleteNodesNodeIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+// PostNodesNodeIdAdmit operation middleware
+func (sh *strictHandler) PostNodesNodeIdAdmit(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	var request PostNodesNodeIdAdmitRequestObject
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	request.NodeID = nodeID
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNodesNodeIdAdmit(ctx, request.(PostNodesNodeIdAdmitRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNodesNodeIdAdmit")
+	}
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostNodesNodeIdAdmitResponseObject); ok {
+		if err := validResponse.VisitPostNodesNodeIdAdmitResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
-}
+// PostNodesNodeIdHeartbeat operation middleware
+func (sh *strictHandler) PostNodesNodeIdHeartbeat(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	var request PostNodesNodeIdHeartbeatRequestObject
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+	request.NodeID = nodeID
 
-	if r == nil {
-		r = chi.NewRouter()
+	var body PostNodesNodeIdHeartbeatJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNodesNodeIdHeartbeat(ctx, request.(PostNodesNodeIdHeartbeatRequestObject))
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNodesNodeIdHeartbeat")
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/nodes/register", wrapper.PostNodesRegister)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/state", wrapper.GetState)
-	})
-
-	return r
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PostNodesRegisterRequestObject struct {
-	Body *PostNodesRegisterJSONRequestBody
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostNodesNodeIdHeartbeatResponseObject); ok {
+		if err := validResponse.VisitPostNodesNodeIdHeartbeatResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostNodesRegisterResponseObject interface {
-	VisitPostNodesRegisterResponse(w http.ResponseWriter) error
-}
+// PutPartitionCount operation middleware
+func (sh *strictHandler) PutPartitionCount(w http.ResponseWriter, r *http.Request) {
+	var request PutPartitionCountRequestObject
 
-type PostNodesRegister201JSONResponse NodeRegistrationResponse
+	var body PutPartitionCountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response PostNodesRegister201JSONResponse) VisitPostNodesRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutPartitionCount(ctx, request.(PutPartitionCountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutPartitionCount")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PostNodesRegister400Response struct {
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutPartitionCountResponseObject); ok {
+		if err := validResponse.VisitPutPartitionCountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response PostNodesRegister400Response) VisitPostNodesRegisterResponse(w http.ResponseWriter) error {
-	w.WriteHeader(400)
-	return nil
-}
+// PostRaftAppend operation middleware
+func (sh *strictHandler) PostRaftAppend(w http.ResponseWriter, r *http.Request) {
+	var request PostRaftAppendRequestObject
 
-type PostNodesRegister409Response struct {
-}
+	var body PostRaftAppendJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response PostNodesRegister409Response) VisitPostNodesRegisterResponse(w http.ResponseWriter) error {
-	w.WriteHeader(409)
-	return nil
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRaftAppend(ctx, request.(PostRaftAppendRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRaftAppend")
+	}
 
-type GetStateRequestObject struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetStateResponseObject interface {
-	VisitGetStateResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRaftAppendResponseObject); ok {
+		if err := validResponse.VisitPostRaftAppendResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetState200JSONResponse externalRef0.State
+// PostRaftSnapshot operation middleware
+func (sh *strictHandler) PostRaftSnapshot(w http.ResponseWriter, r *http.Request) {
+	var request PostRaftSnapshotRequestObject
 
-func (response GetState200JSONResponse) VisitGetStateResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var body PostRaftSnapshotJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRaftSnapshot(ctx, request.(PostRaftSnapshotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRaftSnapshot")
+	}
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Register a new node with the controller
-	// (POST /nodes/register)
-	PostNodesRegister(ctx context.Context, request PostNodesRegisterRequestObject) (PostNodesRegisterResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (GET /state)
-	GetState(ctx context.Context, request GetStateRequestObject) (GetStateResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRaftSnapshotResponseObject); ok {
+		if err := validResponse.VisitPostRaftSnapshotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// PostRaftVote operation middleware
+func (sh *strictHandler) PostRaftVote(w http.ResponseWriter, r *http.Request) {
+	var request PostRaftVoteRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	var body PostRaftVoteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRaftVote(ctx, request.(PostRaftVoteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRaftVote")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRaftVoteResponseObject); ok {
+		if err := validResponse.VisitPostRaftVoteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// PostNodesRegister operation middleware
-func (sh *strictHandler) PostNodesRegister(w http.ResponseWriter, r *http.Request) {
-	var request PostNodesRegisterRequestObject
+// PutReplicaCount operation middleware
+func (sh *strictHandler) PutReplicaCount(w http.ResponseWriter, r *http.Request) {
+	var request PutReplicaCountRequestObject
 
-	var body PostNodesRegisterJSONRequestBody
+	var body PutReplicaCountJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -681,18 +3533,18 @@ func (sh *strictHandler) PostNodesRegister(w http.ResponseWriter, r *http.Reques
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostNodesRegister(ctx, request.(PostNodesRegisterRequestObject))
+		return sh.ssi.PutReplicaCount(ctx, request.(PutReplicaCountRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostNodesRegister")
+		handler = middleware(handler, "PutReplicaCount")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostNodesRegisterResponseObject); ok {
-		if err := validResponse.VisitPostNodesRegisterResponse(w); err != nil {
+	} else if validResponse, ok := response.(PutReplicaCountResponseObject); ok {
+		if err := validResponse.VisitPutReplicaCountResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -701,9 +3553,11 @@ func (sh *strictHandler) PostNodesRegister(w http.ResponseWriter, r *http.Reques
 }
 
 // GetState operation middleware
-func (sh *strictHandler) GetState(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GetState(w http.ResponseWriter, r *http.Request, params GetStateParams) {
 	var request GetStateRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetState(ctx, request.(GetStateRequestObject))
 	}
@@ -20,18 +20,122 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// ImportRequest defines model for ImportRequest.
+type ImportRequest struct {
+	Pairs []externalRef0.KeyValuePair `json:"pairs"`
+}
+
+// ImportResponse defines model for ImportResponse.
+type ImportResponse struct {
+	// Imported Number of keys written, keys already present are left untouched
+	Imported int64 `json:"imported"`
+}
+
+// MigrationTask Request to copy every key of a partition whose hash falls in (rangeStart, rangeEnd] to the master of the target partition
+type MigrationTask struct {
+	// Id ID of the migration range being executed
+	Id openapi_types.UUID `json:"id"`
+
+	// RangeEnd End of the hash range (inclusive)
+	RangeEnd int64 `json:"rangeEnd"`
+
+	// RangeStart Start of the hash range (exclusive)
+	RangeStart int64 `json:"rangeStart"`
+
+	// TargetAddress Address of the master node of the target partition
+	TargetAddress string `json:"targetAddress"`
+
+	// TargetPartitionId Partition the keys are copied to
+	TargetPartitionId string `json:"targetPartitionId"`
+}
+
+// MigrationTaskStatus defines model for MigrationTaskStatus.
+type MigrationTaskStatus struct {
+	// Error Reason of the failure when the status is failed
+	Error *string `json:"error,omitempty"`
+
+	// Id ID of the migration range being executed
+	Id openapi_types.UUID `json:"id"`
+
+	// Progress Number of keys processed so far
+	Progress int64 `json:"progress"`
+
+	// Status Status of data migration for a hash range
+	Status externalRef0.MigrationStatus `json:"status"`
+}
+
 // NodeState defines model for NodeState.
 type NodeState = externalRef0.State
 
+// ReplicationAck defines model for ReplicationAck.
+type ReplicationAck struct {
+	// IsSyncing Whether the replica is catching up with its master
+	IsSyncing bool `json:"isSyncing"`
+
+	// LastAppliedOperationId ID of the last operation applied by the replica
+	LastAppliedOperationId int64 `json:"lastAppliedOperationId"`
+}
+
+// ApplyOperationsJSONBody defines parameters for ApplyOperations.
+type ApplyOperationsJSONBody = []externalRef0.Operation
+
+// DeleteKeyFromPartitionParams defines parameters for DeleteKeyFromPartition.
+type DeleteKeyFromPartitionParams struct {
+	// Epoch Partition epoch the caller routed the request with, the request is rejected with STALE_EPOCH when it does not match the epoch known by the node
+	Epoch *int64 `form:"epoch,omitempty" json:"epoch,omitempty"`
+
+	// W Write concern of the request, defaults to 1
+	W *externalRef0.WriteConcern `form:"w,omitempty" json:"w,omitempty"`
+}
+
+// GetValueFromPartitionParams defines parameters for GetValueFromPartition.
+type GetValueFromPartitionParams struct {
+	// MinOperationId ID of an operation the read must observe, a replica that did not apply it yet waits briefly for it
+	MinOperationId *int64 `form:"minOperationId,omitempty" json:"minOperationId,omitempty"`
+}
+
+// SetValueInPartitionParams defines parameters for SetValueInPartition.
+type SetValueInPartitionParams struct {
+	// Epoch Partition epoch the caller routed the request with, the request is rejected with STALE_EPOCH when it does not match the epoch known by the node
+	Epoch *int64 `form:"epoch,omitempty" json:"epoch,omitempty"`
+
+	// W Write concern of the request, defaults to 1
+	W *externalRef0.WriteConcern `form:"w,omitempty" json:"w,omitempty"`
+}
+
+// PatchNodeStateJSONRequestBody defines body for PatchNodeState for application/json ContentType.
+type PatchNodeStateJSONRequestBody = externalRef0.StatePatch
+
 // UpdateNodeStateJSONRequestBody defines body for UpdateNodeState for application/json ContentType.
 type UpdateNodeStateJSONRequestBody = NodeState
 
 // ApplyOperationJSONRequestBody defines body for ApplyOperation for application/json ContentType.
 type ApplyOperationJSONRequestBody = externalRef0.Operation
 
+// ApplyOperationsJSONRequestBody defines body for ApplyOperations for application/json ContentType.
+type ApplyOperationsJSONRequestBody = ApplyOperationsJSONBody
+
+// AppendRaftEntriesJSONRequestBody defines body for AppendRaftEntries for application/json ContentType.
+type AppendRaftEntriesJSONRequestBody = externalRef0.RaftAppendRequest
+
+// InstallRaftSnapshotJSONRequestBody defines body for InstallRaftSnapshot for application/json ContentType.
+type InstallRaftSnapshotJSONRequestBody = externalRef0.RaftSnapshotRequest
+
+// RequestRaftVoteJSONRequestBody defines body for RequestRaftVote for application/json ContentType.
+type RequestRaftVoteJSONRequestBody = externalRef0.RaftVoteRequest
+
+// ImportKeysJSONRequestBody defines body for ImportKeys for application/json ContentType.
+type ImportKeysJSONRequestBody = ImportRequest
+
 // SetValueInPartitionJSONRequestBody defines body for SetValueInPartition for application/json ContentType.
 type SetValueInPartitionJSONRequestBody = externalRef0.SetValueRequest
 
+// StartMigrationJSONRequestBody defines body for StartMigration for application/json ContentType.
+type StartMigrationJSONRequestBody = MigrationTask
+
+// CommitMigrationJSONRequestBody defines body for CommitMigration for application/json ContentType.
+type CommitMigrationJSONRequestBody = MigrationTask
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -108,32 +212,81 @@ type ClientInterface interface {
 	// GetClusterState request
 	GetClusterState(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PatchNodeStateWithBody request with any body
+	PatchNodeStateWithBody(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchNodeState(ctx context.Context, nodeID openapi_types.UUID, body PatchNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// UpdateNodeStateWithBody request with any body
 	UpdateNodeStateWithBody(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	UpdateNodeState(ctx context.Context, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetPartitionsStatus request
+	GetPartitionsStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ApplyOperationWithBody request with any body
 	ApplyOperationWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	ApplyOperation(ctx context.Context, partitionID string, body ApplyOperationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ApplyOperationsWithBody request with any body
+	ApplyOperationsWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ApplyOperations(ctx context.Context, partitionID string, body ApplyOperationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AppendRaftEntriesWithBody request with any body
+	AppendRaftEntriesWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AppendRaftEntries(ctx context.Context, partitionID string, body AppendRaftEntriesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// InstallRaftSnapshotWithBody request with any body
+	InstallRaftSnapshotWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	InstallRaftSnapshot(ctx context.Context, partitionID string, body InstallRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RequestRaftVoteWithBody request with any body
+	RequestRaftVoteWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RequestRaftVote(ctx context.Context, partitionID string, body RequestRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportKeysWithBody request with any body
+	ImportKeysWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportKeys(ctx context.Context, partitionID string, body ImportKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteKeyFromPartition request
-	DeleteKeyFromPartition(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	DeleteKeyFromPartition(ctx context.Context, partitionID string, key string, params *DeleteKeyFromPartitionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetValueFromPartition request
-	GetValueFromPartition(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetValueFromPartition(ctx context.Context, partitionID string, key string, params *GetValueFromPartitionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SetValueInPartitionWithBody request with any body
-	SetValueInPartitionWithBody(ctx context.Context, partitionID string, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	SetValueInPartitionWithBody(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	SetValueInPartition(ctx context.Context, partitionID string, key string, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	SetValueInPartition(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartMigrationWithBody request with any body
+	StartMigrationWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StartMigration(ctx context.Context, partitionID string, body StartMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMigrationStatus request
+	GetMigrationStatus(ctx context.Context, partitionID string, migrationID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CommitMigrationWithBody request with any body
+	CommitMigrationWithBody(ctx context.Context, partitionID string, migrationID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CommitMigration(ctx context.Context, partitionID string, migrationID openapi_types.UUID, body CommitMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetOperationsAfter request
 	GetOperationsAfter(ctx context.Context, partitionID string, lastOperationID int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetOperation request
 	GetOperation(ctx context.Context, partitionID string, operationID int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSnapshot request
+	GetSnapshot(ctx context.Context, partitionID string, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetClusterState(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -148,8 +301,8 @@ func (c *Client) GetClusterState(ctx context.Context, reqEditors ...RequestEdito
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateNodeStateWithBody(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateNodeStateRequestWithBody(c.Server, nodeID, contentType, body)
+func (c *Client) PatchNodeStateWithBody(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchNodeStateRequestWithBody(c.Server, nodeID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -160,8 +313,8 @@ func (c *Client) UpdateNodeStateWithBody(ctx context.Context, nodeID openapi_typ
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateNodeState(ctx context.Context, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateNodeStateRequest(c.Server, nodeID, body)
+func (c *Client) PatchNodeState(ctx context.Context, nodeID openapi_types.UUID, body PatchNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchNodeStateRequest(c.Server, nodeID, body)
 	if err != nil {
 		return nil, err
 	}
@@ -172,8 +325,8 @@ func (c *Client) UpdateNodeState(ctx context.Context, nodeID openapi_types.UUID,
 	return c.Client.Do(req)
 }
 
-func (c *Client) ApplyOperationWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewApplyOperationRequestWithBody(c.Server, partitionID, contentType, body)
+func (c *Client) UpdateNodeStateWithBody(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateNodeStateRequestWithBody(c.Server, nodeID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -184,8 +337,8 @@ func (c *Client) ApplyOperationWithBody(ctx context.Context, partitionID string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) ApplyOperation(ctx context.Context, partitionID string, body ApplyOperationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewApplyOperationRequest(c.Server, partitionID, body)
+func (c *Client) UpdateNodeState(ctx context.Context, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateNodeStateRequest(c.Server, nodeID, body)
 	if err != nil {
 		return nil, err
 	}
@@ -196,8 +349,8 @@ func (c *Client) ApplyOperation(ctx context.Context, partitionID string, body Ap
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteKeyFromPartition(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteKeyFromPartitionRequest(c.Server, partitionID, key)
+func (c *Client) GetPartitionsStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPartitionsStatusRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -208,8 +361,8 @@ func (c *Client) DeleteKeyFromPartition(ctx context.Context, partitionID string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetValueFromPartition(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetValueFromPartitionRequest(c.Server, partitionID, key)
+func (c *Client) ApplyOperationWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyOperationRequestWithBody(c.Server, partitionID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -220,8 +373,8 @@ func (c *Client) GetValueFromPartition(ctx context.Context, partitionID string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetValueInPartitionWithBody(ctx context.Context, partitionID string, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetValueInPartitionRequestWithBody(c.Server, partitionID, key, contentType, body)
+func (c *Client) ApplyOperation(ctx context.Context, partitionID string, body ApplyOperationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyOperationRequest(c.Server, partitionID, body)
 	if err != nil {
 		return nil, err
 	}
@@ -232,8 +385,8 @@ func (c *Client) SetValueInPartitionWithBody(ctx context.Context, partitionID st
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetValueInPartition(ctx context.Context, partitionID string, key string, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetValueInPartitionRequest(c.Server, partitionID, key, body)
+func (c *Client) ApplyOperationsWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyOperationsRequestWithBody(c.Server, partitionID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -244,8 +397,8 @@ func (c *Client) SetValueInPartition(ctx context.Context, partitionID string, ke
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetOperationsAfter(ctx context.Context, partitionID string, lastOperationID int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetOperationsAfterRequest(c.Server, partitionID, lastOperationID)
+func (c *Client) ApplyOperations(ctx context.Context, partitionID string, body ApplyOperationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyOperationsRequest(c.Server, partitionID, body)
 	if err != nil {
 		return nil, err
 	}
@@ -256,8 +409,8 @@ func (c *Client) GetOperationsAfter(ctx context.Context, partitionID string, las
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetOperation(ctx context.Context, partitionID string, operationID int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetOperationRequest(c.Server, partitionID, operationID)
+func (c *Client) AppendRaftEntriesWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAppendRaftEntriesRequestWithBody(c.Server, partitionID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -268,244 +421,244 @@ func (c *Client) GetOperation(ctx context.Context, partitionID string, operation
 	return c.Client.Do(req)
 }
 
-// NewGetClusterStateRequest generates requests for GetClusterState
-func NewGetClusterStateRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) AppendRaftEntries(ctx context.Context, partitionID string, body AppendRaftEntriesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAppendRaftEntriesRequest(c.Server, partitionID, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/cluster/state")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) InstallRaftSnapshotWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInstallRaftSnapshotRequestWithBody(c.Server, partitionID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewUpdateNodeStateRequest calls the generic UpdateNodeState builder with application/json body
-func NewUpdateNodeStateRequest(server string, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) InstallRaftSnapshot(ctx context.Context, partitionID string, body InstallRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInstallRaftSnapshotRequest(c.Server, partitionID, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewUpdateNodeStateRequestWithBody(server, nodeID, "application/json", bodyReader)
-}
-
-// NewUpdateNodeStateRequestWithBody generates requests for UpdateNodeState with any type of body
-func NewUpdateNodeStateRequestWithBody(server string, nodeID openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "nodeId", runtime.ParamLocationPath, nodeID)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) RequestRaftVoteWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRequestRaftVoteRequestWithBody(c.Server, partitionID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/nodes/%s/state", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) RequestRaftVote(ctx context.Context, partitionID string, body RequestRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRequestRaftVoteRequest(c.Server, partitionID, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewApplyOperationRequest calls the generic ApplyOperation builder with application/json body
-func NewApplyOperationRequest(server string, partitionID string, body ApplyOperationJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) ImportKeysWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportKeysRequestWithBody(c.Server, partitionID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewApplyOperationRequestWithBody(server, partitionID, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewApplyOperationRequestWithBody generates requests for ApplyOperation with any type of body
-func NewApplyOperationRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionID", runtime.ParamLocationPath, partitionID)
+func (c *Client) ImportKeys(ctx context.Context, partitionID string, body ImportKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportKeysRequest(c.Server, partitionID, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteKeyFromPartition(ctx context.Context, partitionID string, key string, params *DeleteKeyFromPartitionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteKeyFromPartitionRequest(c.Server, partitionID, key, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/partitions/%s/operations", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetValueFromPartition(ctx context.Context, partitionID string, key string, params *GetValueFromPartitionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetValueFromPartitionRequest(c.Server, partitionID, key, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewDeleteKeyFromPartitionRequest generates requests for DeleteKeyFromPartition
-func NewDeleteKeyFromPartitionRequest(server string, partitionID string, key string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+func (c *Client) SetValueInPartitionWithBody(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetValueInPartitionRequestWithBody(c.Server, partitionID, key, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "key", runtime.ParamLocationPath, key)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) SetValueInPartition(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetValueInPartitionRequest(c.Server, partitionID, key, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/partitions/%s/keys/%s", pathParam0, pathParam1)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) StartMigrationWithBody(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartMigrationRequestWithBody(c.Server, partitionID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetValueFromPartitionRequest generates requests for GetValueFromPartition
-func NewGetValueFromPartitionRequest(server string, partitionID string, key string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+func (c *Client) StartMigration(ctx context.Context, partitionID string, body StartMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartMigrationRequest(c.Server, partitionID, body)
 	if err != nil {
 		return nil, err
 	}
-
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "key", runtime.ParamLocationPath, key)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetMigrationStatus(ctx context.Context, partitionID string, migrationID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMigrationStatusRequest(c.Server, partitionID, migrationID)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/partitions/%s/keys/%s", pathParam0, pathParam1)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) CommitMigrationWithBody(ctx context.Context, partitionID string, migrationID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCommitMigrationRequestWithBody(c.Server, partitionID, migrationID, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewSetValueInPartitionRequest calls the generic SetValueInPartition builder with application/json body
-func NewSetValueInPartitionRequest(server string, partitionID string, key string, body SetValueInPartitionJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) CommitMigration(ctx context.Context, partitionID string, migrationID openapi_types.UUID, body CommitMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCommitMigrationRequest(c.Server, partitionID, migrationID, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewSetValueInPartitionRequestWithBody(server, partitionID, key, "application/json", bodyReader)
-}
-
-// NewSetValueInPartitionRequestWithBody generates requests for SetValueInPartition with any type of body
-func NewSetValueInPartitionRequestWithBody(server string, partitionID string, key string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+func (c *Client) GetOperationsAfter(ctx context.Context, partitionID string, lastOperationID int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOperationsAfterRequest(c.Server, partitionID, lastOperationID)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam1 string
+func (c *Client) GetOperation(ctx context.Context, partitionID string, operationID int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOperationRequest(c.Server, partitionID, operationID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "key", runtime.ParamLocationPath, key)
+func (c *Client) GetSnapshot(ctx context.Context, partitionID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSnapshotRequest(c.Server, partitionID)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetClusterStateRequest generates requests for GetClusterState
+func NewGetClusterStateRequest(server string) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/partitions/%s/keys/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/cluster/state")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -515,30 +668,32 @@ func NewSetValueInPartitionRequestWithBody(server string, partitionID string, ke
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewGetOperationsAfterRequest generates requests for GetOperationsAfter
-func NewGetOperationsAfterRequest(server string, partitionID string, lastOperationID int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+// NewPatchNodeStateRequest calls the generic PatchNodeState builder with application/json body
+func NewPatchNodeStateRequest(server string, nodeID openapi_types.UUID, body PatchNodeStateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchNodeStateRequestWithBody(server, nodeID, "application/json", bodyReader)
+}
 
-	var pathParam1 string
+// NewPatchNodeStateRequestWithBody generates requests for PatchNodeState with any type of body
+func NewPatchNodeStateRequestWithBody(server string, nodeID openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "lastOperationId", runtime.ParamLocationPath, lastOperationID)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "nodeId", runtime.ParamLocationPath, nodeID)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +703,7 @@ func NewGetOperationsAfterRequest(server string, partitionID string, lastOperati
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/replication/%s/checkpoint/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/nodes/%s/state", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -558,38 +713,73 @@ func NewGetOperationsAfterRequest(server string, partitionID string, lastOperati
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetOperationRequest generates requests for GetOperation
-func NewGetOperationRequest(server string, partitionID string, operationID int64) (*http.Request, error) {
+// NewUpdateNodeStateRequest calls the generic UpdateNodeState builder with application/json body
+func NewUpdateNodeStateRequest(server string, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateNodeStateRequestWithBody(server, nodeID, "application/json", bodyReader)
+}
+
+// NewUpdateNodeStateRequestWithBody generates requests for UpdateNodeState with any type of body
+func NewUpdateNodeStateRequestWithBody(server string, nodeID openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "nodeId", runtime.ParamLocationPath, nodeID)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam1 string
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "operationId", runtime.ParamLocationPath, operationID)
+	operationPath := fmt.Sprintf("/nodes/%s/state", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPartitionsStatusRequest generates requests for GetPartitionsStatus
+func NewGetPartitionsStatusRequest(server string) (*http.Request, error) {
+	var err error
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/replication/%s/operation/%s", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/partitions/status")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -607,385 +797,2052 @@ func NewGetOperationRequest(server string, partitionID string, operationID int64
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewApplyOperationRequest calls the generic ApplyOperation builder with application/json body
+func NewApplyOperationRequest(server string, partitionID string, body ApplyOperationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewApplyOperationRequestWithBody(server, partitionID, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewApplyOperationRequestWithBody generates requests for ApplyOperation with any type of body
+func NewApplyOperationRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionID", runtime.ParamLocationPath, partitionID)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetClusterStateWithResponse request
-	GetClusterStateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetClusterStateResponse, error)
+	operationPath := fmt.Sprintf("/partitions/%s/operations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// UpdateNodeStateWithBodyWithResponse request with any body
-	UpdateNodeStateWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	UpdateNodeStateWithResponse(ctx context.Context, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// ApplyOperationWithBodyWithResponse request with any body
-	ApplyOperationWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	ApplyOperationWithResponse(ctx context.Context, partitionID string, body ApplyOperationJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error)
+	return req, nil
+}
 
-	// DeleteKeyFromPartitionWithResponse request
-	DeleteKeyFromPartitionWithResponse(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*DeleteKeyFromPartitionResponse, error)
+// NewApplyOperationsRequest calls the generic ApplyOperations builder with application/json body
+func NewApplyOperationsRequest(server string, partitionID string, body ApplyOperationsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewApplyOperationsRequestWithBody(server, partitionID, "application/json", bodyReader)
+}
 
-	// GetValueFromPartitionWithResponse request
-	GetValueFromPartitionWithResponse(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*GetValueFromPartitionResponse, error)
+// NewApplyOperationsRequestWithBody generates requests for ApplyOperations with any type of body
+func NewApplyOperationsRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	// SetValueInPartitionWithBodyWithResponse request with any body
-	SetValueInPartitionWithBodyWithResponse(ctx context.Context, partitionID string, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error)
+	var pathParam0 string
 
-	SetValueInPartitionWithResponse(ctx context.Context, partitionID string, key string, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionID", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetOperationsAfterWithResponse request
-	GetOperationsAfterWithResponse(ctx context.Context, partitionID string, lastOperationID int64, reqEditors ...RequestEditorFn) (*GetOperationsAfterResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetOperationWithResponse request
-	GetOperationWithResponse(ctx context.Context, partitionID string, operationID int64, reqEditors ...RequestEditorFn) (*GetOperationResponse, error)
-}
+	operationPath := fmt.Sprintf("/partitions/%s/operations/batch", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-type GetClusterStateResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *NodeState
-	JSON500      *externalRef0.ErrorResponse
-}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetClusterStateResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetClusterStateResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewAppendRaftEntriesRequest calls the generic AppendRaftEntries builder with application/json body
+func NewAppendRaftEntriesRequest(server string, partitionID string, body AppendRaftEntriesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	bodyReader = bytes.NewReader(buf)
+	return NewAppendRaftEntriesRequestWithBody(server, partitionID, "application/json", bodyReader)
 }
 
-type UpdateNodeStateResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *externalRef0.ErrorResponse
-	JSON404      *externalRef0.ErrorResponse
-	JSON500      *externalRef0.ErrorResponse
-}
+// NewAppendRaftEntriesRequestWithBody generates requests for AppendRaftEntries with any type of body
+func NewAppendRaftEntriesRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// Status returns HTTPResponse.Status
-func (r UpdateNodeStateResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionID", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r UpdateNodeStateResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type ApplyOperationResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *externalRef0.ErrorResponse
-	JSONDefault  *externalRef0.ErrorResponse
-}
+	operationPath := fmt.Sprintf("/partitions/%s/raft/append", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r ApplyOperationResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ApplyOperationResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type DeleteKeyFromPartitionResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.DeleteResponse
-	JSON404      *externalRef0.ErrorResponse
-	JSON500      *externalRef0.ErrorResponse
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteKeyFromPartitionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewInstallRaftSnapshotRequest calls the generic InstallRaftSnapshot builder with application/json body
+func NewInstallRaftSnapshotRequest(server string, partitionID string, body InstallRaftSnapshotJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewInstallRaftSnapshotRequestWithBody(server, partitionID, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteKeyFromPartitionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewInstallRaftSnapshotRequestWithBody generates requests for InstallRaftSnapshot with any type of body
+func NewInstallRaftSnapshotRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionID", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetValueFromPartitionResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.KeyValueResponse
-	JSON404      *externalRef0.ErrorResponse
-	JSON500      *externalRef0.ErrorResponse
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/raft/snapshot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetValueFromPartitionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewRequestRaftVoteRequest calls the generic RequestRaftVote builder with application/json body
+func NewRequestRaftVoteRequest(server string, partitionID string, body RequestRaftVoteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewRequestRaftVoteRequestWithBody(server, partitionID, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetValueFromPartitionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewRequestRaftVoteRequestWithBody generates requests for RequestRaftVote with any type of body
+func NewRequestRaftVoteRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionID", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/raft/vote", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-type SetValueInPartitionResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.KeyValuePair
-	JSON400      *externalRef0.ErrorResponse
-	JSON404      *externalRef0.ErrorResponse
-	JSON500      *externalRef0.ErrorResponse
+// NewImportKeysRequest calls the generic ImportKeys builder with application/json body
+func NewImportKeysRequest(server string, partitionID string, body ImportKeysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewImportKeysRequestWithBody(server, partitionID, "application/json", bodyReader)
 }
 
-// Status returns HTTPResponse.Status
-func (r SetValueInPartitionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewImportKeysRequestWithBody generates requests for ImportKeys with any type of body
+func NewImportKeysRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/import", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r SetValueInPartitionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewDeleteKeyFromPartitionRequest generates requests for DeleteKeyFromPartition
+func NewDeleteKeyFromPartitionRequest(server string, partitionID string, key string, params *DeleteKeyFromPartitionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "key", runtime.ParamLocationPath, key)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/keys/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Epoch != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "epoch", runtime.ParamLocationQuery, *params.Epoch); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.W != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "w", runtime.ParamLocationQuery, *params.W); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
 }
 
-type GetOperationsAfterResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]externalRef0.Operation
+// NewGetValueFromPartitionRequest generates requests for GetValueFromPartition
+func NewGetValueFromPartitionRequest(server string, partitionID string, key string, params *GetValueFromPartitionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "key", runtime.ParamLocationPath, key)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/keys/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.MinOperationId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "minOperationId", runtime.ParamLocationQuery, *params.MinOperationId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetValueInPartitionRequest calls the generic SetValueInPartition builder with application/json body
+func NewSetValueInPartitionRequest(server string, partitionID string, key string, params *SetValueInPartitionParams, body SetValueInPartitionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetValueInPartitionRequestWithBody(server, partitionID, key, params, "application/json", bodyReader)
+}
+
+// NewSetValueInPartitionRequestWithBody generates requests for SetValueInPartition with any type of body
+func NewSetValueInPartitionRequestWithBody(server string, partitionID string, key string, params *SetValueInPartitionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "key", runtime.ParamLocationPath, key)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/keys/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Epoch != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "epoch", runtime.ParamLocationQuery, *params.Epoch); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.W != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "w", runtime.ParamLocationQuery, *params.W); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStartMigrationRequest calls the generic StartMigration builder with application/json body
+func NewStartMigrationRequest(server string, partitionID string, body StartMigrationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStartMigrationRequestWithBody(server, partitionID, "application/json", bodyReader)
+}
+
+// NewStartMigrationRequestWithBody generates requests for StartMigration with any type of body
+func NewStartMigrationRequestWithBody(server string, partitionID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/migrations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetMigrationStatusRequest generates requests for GetMigrationStatus
+func NewGetMigrationStatusRequest(server string, partitionID string, migrationID openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "migrationId", runtime.ParamLocationPath, migrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/migrations/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCommitMigrationRequest calls the generic CommitMigration builder with application/json body
+func NewCommitMigrationRequest(server string, partitionID string, migrationID openapi_types.UUID, body CommitMigrationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCommitMigrationRequestWithBody(server, partitionID, migrationID, "application/json", bodyReader)
+}
+
+// NewCommitMigrationRequestWithBody generates requests for CommitMigration with any type of body
+func NewCommitMigrationRequestWithBody(server string, partitionID string, migrationID openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "migrationId", runtime.ParamLocationPath, migrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/partitions/%s/migrations/%s/commit", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetOperationsAfterRequest generates requests for GetOperationsAfter
+func NewGetOperationsAfterRequest(server string, partitionID string, lastOperationID int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "lastOperationId", runtime.ParamLocationPath, lastOperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/replication/%s/checkpoint/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOperationRequest generates requests for GetOperation
+func NewGetOperationRequest(server string, partitionID string, operationID int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "operationId", runtime.ParamLocationPath, operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/replication/%s/operation/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSnapshotRequest generates requests for GetSnapshot
+func NewGetSnapshotRequest(server string, partitionID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "partitionId", runtime.ParamLocationPath, partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/replication/%s/snapshot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetClusterStateWithResponse request
+	GetClusterStateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetClusterStateResponse, error)
+
+	// PatchNodeStateWithBodyWithResponse request with any body
+	PatchNodeStateWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchNodeStateResponse, error)
+
+	PatchNodeStateWithResponse(ctx context.Context, nodeID openapi_types.UUID, body PatchNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchNodeStateResponse, error)
+
+	// UpdateNodeStateWithBodyWithResponse request with any body
+	UpdateNodeStateWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error)
+
+	UpdateNodeStateWithResponse(ctx context.Context, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error)
+
+	// GetPartitionsStatusWithResponse request
+	GetPartitionsStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPartitionsStatusResponse, error)
+
+	// ApplyOperationWithBodyWithResponse request with any body
+	ApplyOperationWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error)
+
+	ApplyOperationWithResponse(ctx context.Context, partitionID string, body ApplyOperationJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error)
+
+	// ApplyOperationsWithBodyWithResponse request with any body
+	ApplyOperationsWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyOperationsResponse, error)
+
+	ApplyOperationsWithResponse(ctx context.Context, partitionID string, body ApplyOperationsJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyOperationsResponse, error)
+
+	// AppendRaftEntriesWithBodyWithResponse request with any body
+	AppendRaftEntriesWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AppendRaftEntriesResponse, error)
+
+	AppendRaftEntriesWithResponse(ctx context.Context, partitionID string, body AppendRaftEntriesJSONRequestBody, reqEditors ...RequestEditorFn) (*AppendRaftEntriesResponse, error)
+
+	// InstallRaftSnapshotWithBodyWithResponse request with any body
+	InstallRaftSnapshotWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*InstallRaftSnapshotResponse, error)
+
+	InstallRaftSnapshotWithResponse(ctx context.Context, partitionID string, body InstallRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*InstallRaftSnapshotResponse, error)
+
+	// RequestRaftVoteWithBodyWithResponse request with any body
+	RequestRaftVoteWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RequestRaftVoteResponse, error)
+
+	RequestRaftVoteWithResponse(ctx context.Context, partitionID string, body RequestRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*RequestRaftVoteResponse, error)
+
+	// ImportKeysWithBodyWithResponse request with any body
+	ImportKeysWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportKeysResponse, error)
+
+	ImportKeysWithResponse(ctx context.Context, partitionID string, body ImportKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportKeysResponse, error)
+
+	// DeleteKeyFromPartitionWithResponse request
+	DeleteKeyFromPartitionWithResponse(ctx context.Context, partitionID string, key string, params *DeleteKeyFromPartitionParams, reqEditors ...RequestEditorFn) (*DeleteKeyFromPartitionResponse, error)
+
+	// GetValueFromPartitionWithResponse request
+	GetValueFromPartitionWithResponse(ctx context.Context, partitionID string, key string, params *GetValueFromPartitionParams, reqEditors ...RequestEditorFn) (*GetValueFromPartitionResponse, error)
+
+	// SetValueInPartitionWithBodyWithResponse request with any body
+	SetValueInPartitionWithBodyWithResponse(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error)
+
+	SetValueInPartitionWithResponse(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error)
+
+	// StartMigrationWithBodyWithResponse request with any body
+	StartMigrationWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartMigrationResponse, error)
+
+	StartMigrationWithResponse(ctx context.Context, partitionID string, body StartMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*StartMigrationResponse, error)
+
+	// GetMigrationStatusWithResponse request
+	GetMigrationStatusWithResponse(ctx context.Context, partitionID string, migrationID openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetMigrationStatusResponse, error)
+
+	// CommitMigrationWithBodyWithResponse request with any body
+	CommitMigrationWithBodyWithResponse(ctx context.Context, partitionID string, migrationID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CommitMigrationResponse, error)
+
+	CommitMigrationWithResponse(ctx context.Context, partitionID string, migrationID openapi_types.UUID, body CommitMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*CommitMigrationResponse, error)
+
+	// GetOperationsAfterWithResponse request
+	GetOperationsAfterWithResponse(ctx context.Context, partitionID string, lastOperationID int64, reqEditors ...RequestEditorFn) (*GetOperationsAfterResponse, error)
+
+	// GetOperationWithResponse request
+	GetOperationWithResponse(ctx context.Context, partitionID string, operationID int64, reqEditors ...RequestEditorFn) (*GetOperationResponse, error)
+
+	// GetSnapshotWithResponse request
+	GetSnapshotWithResponse(ctx context.Context, partitionID string, reqEditors ...RequestEditorFn) (*GetSnapshotResponse, error)
+}
+
+type GetClusterStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NodeState
+	JSON500      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetClusterStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetClusterStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchNodeStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.StateAck
+	JSON409      *externalRef0.ErrorResponse
+	JSON500      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchNodeStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchNodeStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateNodeStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.StateAck
+	JSON400      *externalRef0.ErrorResponse
+	JSON404      *externalRef0.ErrorResponse
+	JSON500      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateNodeStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateNodeStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPartitionsStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.PartitionStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPartitionsStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPartitionsStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ApplyOperationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *externalRef0.ErrorResponse
+	JSON409      *externalRef0.ErrorResponse
+	JSONDefault  *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ApplyOperationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApplyOperationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ApplyOperationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReplicationAck
+	JSON400      *externalRef0.ErrorResponse
+	JSON409      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ApplyOperationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApplyOperationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AppendRaftEntriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.RaftAppendResponse
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AppendRaftEntriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AppendRaftEntriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type InstallRaftSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.RaftSnapshotResponse
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r InstallRaftSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r InstallRaftSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RequestRaftVoteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.RaftVoteResponse
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RequestRaftVoteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RequestRaftVoteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportResponse
+	JSON409      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteKeyFromPartitionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.DeleteResponse
+	JSON404      *externalRef0.ErrorResponse
+	JSON409      *externalRef0.ErrorResponse
+	JSON500      *externalRef0.ErrorResponse
+	JSON504      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteKeyFromPartitionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteKeyFromPartitionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetValueFromPartitionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.KeyValueResponse
+	JSON404      *externalRef0.ErrorResponse
+	JSON500      *externalRef0.ErrorResponse
+	JSON503      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetValueFromPartitionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetValueFromPartitionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetValueInPartitionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.KeyValuePair
+	JSON400      *externalRef0.ErrorResponse
+	JSON404      *externalRef0.ErrorResponse
+	JSON409      *externalRef0.ErrorResponse
+	JSON500      *externalRef0.ErrorResponse
+	JSON504      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetValueInPartitionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetValueInPartitionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartMigrationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *MigrationTaskStatus
+	JSON409      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r StartMigrationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartMigrationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMigrationStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MigrationTaskStatus
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMigrationStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMigrationStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CommitMigrationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MigrationTaskStatus
+	JSON409      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CommitMigrationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CommitMigrationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOperationsAfterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.Operation
+	JSON410      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOperationsAfterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOperationsAfterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOperationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Operation
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOperationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOperationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Snapshot
+	JSON404      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetClusterStateWithResponse request returning *GetClusterStateResponse
+func (c *ClientWithResponses) GetClusterStateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetClusterStateResponse, error) {
+	rsp, err := c.GetClusterState(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetClusterStateResponse(rsp)
+}
+
+// PatchNodeStateWithBodyWithResponse request with arbitrary body returning *PatchNodeStateResponse
+func (c *ClientWithResponses) PatchNodeStateWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchNodeStateResponse, error) {
+	rsp, err := c.PatchNodeStateWithBody(ctx, nodeID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchNodeStateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchNodeStateWithResponse(ctx context.Context, nodeID openapi_types.UUID, body PatchNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchNodeStateResponse, error) {
+	rsp, err := c.PatchNodeState(ctx, nodeID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchNodeStateResponse(rsp)
+}
+
+// UpdateNodeStateWithBodyWithResponse request with arbitrary body returning *UpdateNodeStateResponse
+func (c *ClientWithResponses) UpdateNodeStateWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error) {
+	rsp, err := c.UpdateNodeStateWithBody(ctx, nodeID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateNodeStateResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateNodeStateWithResponse(ctx context.Context, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error) {
+	rsp, err := c.UpdateNodeState(ctx, nodeID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateNodeStateResponse(rsp)
+}
+
+// GetPartitionsStatusWithResponse request returning *GetPartitionsStatusResponse
+func (c *ClientWithResponses) GetPartitionsStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPartitionsStatusResponse, error) {
+	rsp, err := c.GetPartitionsStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPartitionsStatusResponse(rsp)
+}
+
+// ApplyOperationWithBodyWithResponse request with arbitrary body returning *ApplyOperationResponse
+func (c *ClientWithResponses) ApplyOperationWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error) {
+	rsp, err := c.ApplyOperationWithBody(ctx, partitionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApplyOperationResponse(rsp)
+}
+
+func (c *ClientWithResponses) ApplyOperationWithResponse(ctx context.Context, partitionID string, body ApplyOperationJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error) {
+	rsp, err := c.ApplyOperation(ctx, partitionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApplyOperationResponse(rsp)
+}
+
+// ApplyOperationsWithBodyWithResponse request with arbitrary body returning *ApplyOperationsResponse
+func (c *ClientWithResponses) ApplyOperationsWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyOperationsResponse, error) {
+	rsp, err := c.ApplyOperationsWithBody(ctx, partitionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApplyOperationsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ApplyOperationsWithResponse(ctx context.Context, partitionID string, body ApplyOperationsJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyOperationsResponse, error) {
+	rsp, err := c.ApplyOperations(ctx, partitionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApplyOperationsResponse(rsp)
+}
+
+// AppendRaftEntriesWithBodyWithResponse request with arbitrary body returning *AppendRaftEntriesResponse
+func (c *ClientWithResponses) AppendRaftEntriesWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AppendRaftEntriesResponse, error) {
+	rsp, err := c.AppendRaftEntriesWithBody(ctx, partitionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAppendRaftEntriesResponse(rsp)
+}
+
+func (c *ClientWithResponses) AppendRaftEntriesWithResponse(ctx context.Context, partitionID string, body AppendRaftEntriesJSONRequestBody, reqEditors ...RequestEditorFn) (*AppendRaftEntriesResponse, error) {
+	rsp, err := c.AppendRaftEntries(ctx, partitionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAppendRaftEntriesResponse(rsp)
+}
+
+// InstallRaftSnapshotWithBodyWithResponse request with arbitrary body returning *InstallRaftSnapshotResponse
+func (c *ClientWithResponses) InstallRaftSnapshotWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*InstallRaftSnapshotResponse, error) {
+	rsp, err := c.InstallRaftSnapshotWithBody(ctx, partitionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInstallRaftSnapshotResponse(rsp)
+}
+
+func (c *ClientWithResponses) InstallRaftSnapshotWithResponse(ctx context.Context, partitionID string, body InstallRaftSnapshotJSONRequestBody, reqEditors ...RequestEditorFn) (*InstallRaftSnapshotResponse, error) {
+	rsp, err := c.InstallRaftSnapshot(ctx, partitionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInstallRaftSnapshotResponse(rsp)
+}
+
+// RequestRaftVoteWithBodyWithResponse request with arbitrary body returning *RequestRaftVoteResponse
+func (c *ClientWithResponses) RequestRaftVoteWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RequestRaftVoteResponse, error) {
+	rsp, err := c.RequestRaftVoteWithBody(ctx, partitionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRequestRaftVoteResponse(rsp)
+}
+
+func (c *ClientWithResponses) RequestRaftVoteWithResponse(ctx context.Context, partitionID string, body RequestRaftVoteJSONRequestBody, reqEditors ...RequestEditorFn) (*RequestRaftVoteResponse, error) {
+	rsp, err := c.RequestRaftVote(ctx, partitionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRequestRaftVoteResponse(rsp)
+}
+
+// ImportKeysWithBodyWithResponse request with arbitrary body returning *ImportKeysResponse
+func (c *ClientWithResponses) ImportKeysWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportKeysResponse, error) {
+	rsp, err := c.ImportKeysWithBody(ctx, partitionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportKeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportKeysWithResponse(ctx context.Context, partitionID string, body ImportKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportKeysResponse, error) {
+	rsp, err := c.ImportKeys(ctx, partitionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportKeysResponse(rsp)
+}
+
+// DeleteKeyFromPartitionWithResponse request returning *DeleteKeyFromPartitionResponse
+func (c *ClientWithResponses) DeleteKeyFromPartitionWithResponse(ctx context.Context, partitionID string, key string, params *DeleteKeyFromPartitionParams, reqEditors ...RequestEditorFn) (*DeleteKeyFromPartitionResponse, error) {
+	rsp, err := c.DeleteKeyFromPartition(ctx, partitionID, key, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteKeyFromPartitionResponse(rsp)
+}
+
+// GetValueFromPartitionWithResponse request returning *GetValueFromPartitionResponse
+func (c *ClientWithResponses) GetValueFromPartitionWithResponse(ctx context.Context, partitionID string, key string, params *GetValueFromPartitionParams, reqEditors ...RequestEditorFn) (*GetValueFromPartitionResponse, error) {
+	rsp, err := c.GetValueFromPartition(ctx, partitionID, key, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetValueFromPartitionResponse(rsp)
+}
+
+// SetValueInPartitionWithBodyWithResponse request with arbitrary body returning *SetValueInPartitionResponse
+func (c *ClientWithResponses) SetValueInPartitionWithBodyWithResponse(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error) {
+	rsp, err := c.SetValueInPartitionWithBody(ctx, partitionID, key, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetValueInPartitionResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetValueInPartitionWithResponse(ctx context.Context, partitionID string, key string, params *SetValueInPartitionParams, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error) {
+	rsp, err := c.SetValueInPartition(ctx, partitionID, key, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetValueInPartitionResponse(rsp)
+}
+
+// StartMigrationWithBodyWithResponse request with arbitrary body returning *StartMigrationResponse
+func (c *ClientWithResponses) StartMigrationWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartMigrationResponse, error) {
+	rsp, err := c.StartMigrationWithBody(ctx, partitionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartMigrationResponse(rsp)
+}
+
+func (c *ClientWithResponses) StartMigrationWithResponse(ctx context.Context, partitionID string, body StartMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*StartMigrationResponse, error) {
+	rsp, err := c.StartMigration(ctx, partitionID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartMigrationResponse(rsp)
+}
+
+// GetMigrationStatusWithResponse request returning *GetMigrationStatusResponse
+func (c *ClientWithResponses) GetMigrationStatusWithResponse(ctx context.Context, partitionID string, migrationID openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetMigrationStatusResponse, error) {
+	rsp, err := c.GetMigrationStatus(ctx, partitionID, migrationID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMigrationStatusResponse(rsp)
+}
+
+// CommitMigrationWithBodyWithResponse request with arbitrary body returning *CommitMigrationResponse
+func (c *ClientWithResponses) CommitMigrationWithBodyWithResponse(ctx context.Context, partitionID string, migrationID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CommitMigrationResponse, error) {
+	rsp, err := c.CommitMigrationWithBody(ctx, partitionID, migrationID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCommitMigrationResponse(rsp)
+}
+
+func (c *ClientWithResponses) CommitMigrationWithResponse(ctx context.Context, partitionID string, migrationID openapi_types.UUID, body CommitMigrationJSONRequestBody, reqEditors ...RequestEditorFn) (*CommitMigrationResponse, error) {
+	rsp, err := c.CommitMigration(ctx, partitionID, migrationID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCommitMigrationResponse(rsp)
+}
+
+// GetOperationsAfterWithResponse request returning *GetOperationsAfterResponse
+func (c *ClientWithResponses) GetOperationsAfterWithResponse(ctx context.Context, partitionID string, lastOperationID int64, reqEditors ...RequestEditorFn) (*GetOperationsAfterResponse, error) {
+	rsp, err := c.GetOperationsAfter(ctx, partitionID, lastOperationID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOperationsAfterResponse(rsp)
+}
+
+// GetOperationWithResponse request returning *GetOperationResponse
+func (c *ClientWithResponses) GetOperationWithResponse(ctx context.Context, partitionID string, operationID int64, reqEditors ...RequestEditorFn) (*GetOperationResponse, error) {
+	rsp, err := c.GetOperation(ctx, partitionID, operationID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOperationResponse(rsp)
+}
+
+// GetSnapshotWithResponse request returning *GetSnapshotResponse
+func (c *ClientWithResponses) GetSnapshotWithResponse(ctx context.Context, partitionID string, reqEditors ...RequestEditorFn) (*GetSnapshotResponse, error) {
+	rsp, err := c.GetSnapshot(ctx, partitionID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSnapshotResponse(rsp)
+}
+
+// ParseGetClusterStateResponse parses an HTTP response from a GetClusterStateWithResponse call
+func ParseGetClusterStateResponse(rsp *http.Response) (*GetClusterStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetClusterStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NodeState
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchNodeStateResponse parses an HTTP response from a PatchNodeStateWithResponse call
+func ParsePatchNodeStateResponse(rsp *http.Response) (*PatchNodeStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchNodeStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.StateAck
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateNodeStateResponse parses an HTTP response from a UpdateNodeStateWithResponse call
+func ParseUpdateNodeStateResponse(rsp *http.Response) (*UpdateNodeStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateNodeStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.StateAck
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPartitionsStatusResponse parses an HTTP response from a GetPartitionsStatusWithResponse call
+func ParseGetPartitionsStatusResponse(rsp *http.Response) (*GetPartitionsStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPartitionsStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []externalRef0.PartitionStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseApplyOperationResponse parses an HTTP response from a ApplyOperationWithResponse call
+func ParseApplyOperationResponse(rsp *http.Response) (*ApplyOperationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApplyOperationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseApplyOperationsResponse parses an HTTP response from a ApplyOperationsWithResponse call
+func ParseApplyOperationsResponse(rsp *http.Response) (*ApplyOperationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApplyOperationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReplicationAck
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetOperationsAfterResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseAppendRaftEntriesResponse parses an HTTP response from a AppendRaftEntriesWithResponse call
+func ParseAppendRaftEntriesResponse(rsp *http.Response) (*AppendRaftEntriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetOperationsAfterResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &AppendRaftEntriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetOperationResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.Operation
-	JSON404      *externalRef0.ErrorResponse
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.RaftAppendResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetOperationResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetOperationResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
+
+	return response, nil
 }
 
-// GetClusterStateWithResponse request returning *GetClusterStateResponse
-func (c *ClientWithResponses) GetClusterStateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetClusterStateResponse, error) {
-	rsp, err := c.GetClusterState(ctx, reqEditors...)
+// ParseInstallRaftSnapshotResponse parses an HTTP response from a InstallRaftSnapshotWithResponse call
+func ParseInstallRaftSnapshotResponse(rsp *http.Response) (*InstallRaftSnapshotResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetClusterStateResponse(rsp)
-}
 
-// UpdateNodeStateWithBodyWithResponse request with arbitrary body returning *UpdateNodeStateResponse
-func (c *ClientWithResponses) UpdateNodeStateWithBodyWithResponse(ctx context.Context, nodeID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error) {
-	rsp, err := c.UpdateNodeStateWithBody(ctx, nodeID, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &InstallRaftSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseUpdateNodeStateResponse(rsp)
-}
 
-func (c *ClientWithResponses) UpdateNodeStateWithResponse(ctx context.Context, nodeID openapi_types.UUID, body UpdateNodeStateJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateNodeStateResponse, error) {
-	rsp, err := c.UpdateNodeState(ctx, nodeID, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.RaftSnapshotResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
-	return ParseUpdateNodeStateResponse(rsp)
+
+	return response, nil
 }
 
-// ApplyOperationWithBodyWithResponse request with arbitrary body returning *ApplyOperationResponse
-func (c *ClientWithResponses) ApplyOperationWithBodyWithResponse(ctx context.Context, partitionID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error) {
-	rsp, err := c.ApplyOperationWithBody(ctx, partitionID, contentType, body, reqEditors...)
+// ParseRequestRaftVoteResponse parses an HTTP response from a RequestRaftVoteWithResponse call
+func ParseRequestRaftVoteResponse(rsp *http.Response) (*RequestRaftVoteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseApplyOperationResponse(rsp)
-}
 
-func (c *ClientWithResponses) ApplyOperationWithResponse(ctx context.Context, partitionID string, body ApplyOperationJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyOperationResponse, error) {
-	rsp, err := c.ApplyOperation(ctx, partitionID, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &RequestRaftVoteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseApplyOperationResponse(rsp)
-}
 
-// DeleteKeyFromPartitionWithResponse request returning *DeleteKeyFromPartitionResponse
-func (c *ClientWithResponses) DeleteKeyFromPartitionWithResponse(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*DeleteKeyFromPartitionResponse, error) {
-	rsp, err := c.DeleteKeyFromPartition(ctx, partitionID, key, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.RaftVoteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
-	return ParseDeleteKeyFromPartitionResponse(rsp)
+
+	return response, nil
 }
 
-// GetValueFromPartitionWithResponse request returning *GetValueFromPartitionResponse
-func (c *ClientWithResponses) GetValueFromPartitionWithResponse(ctx context.Context, partitionID string, key string, reqEditors ...RequestEditorFn) (*GetValueFromPartitionResponse, error) {
-	rsp, err := c.GetValueFromPartition(ctx, partitionID, key, reqEditors...)
+// ParseImportKeysResponse parses an HTTP response from a ImportKeysWithResponse call
+func ParseImportKeysResponse(rsp *http.Response) (*ImportKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetValueFromPartitionResponse(rsp)
-}
 
-// SetValueInPartitionWithBodyWithResponse request with arbitrary body returning *SetValueInPartitionResponse
-func (c *ClientWithResponses) SetValueInPartitionWithBodyWithResponse(ctx context.Context, partitionID string, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error) {
-	rsp, err := c.SetValueInPartitionWithBody(ctx, partitionID, key, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ImportKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseSetValueInPartitionResponse(rsp)
-}
 
-func (c *ClientWithResponses) SetValueInPartitionWithResponse(ctx context.Context, partitionID string, key string, body SetValueInPartitionJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueInPartitionResponse, error) {
-	rsp, err := c.SetValueInPartition(ctx, partitionID, key, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	}
-	return ParseSetValueInPartitionResponse(rsp)
+
+	return response, nil
 }
 
-// GetOperationsAfterWithResponse request returning *GetOperationsAfterResponse
-func (c *ClientWithResponses) GetOperationsAfterWithResponse(ctx context.Context, partitionID string, lastOperationID int64, reqEditors ...RequestEditorFn) (*GetOperationsAfterResponse, error) {
-	rsp, err := c.GetOperationsAfter(ctx, partitionID, lastOperationID, reqEditors...)
+// ParseDeleteKeyFromPartitionResponse parses an HTTP response from a DeleteKeyFromPartitionWithResponse call
+func ParseDeleteKeyFromPartitionResponse(rsp *http.Response) (*DeleteKeyFromPartitionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetOperationsAfterResponse(rsp)
-}
 
-// GetOperationWithResponse request returning *GetOperationResponse
-func (c *ClientWithResponses) GetOperationWithResponse(ctx context.Context, partitionID string, operationID int64, reqEditors ...RequestEditorFn) (*GetOperationResponse, error) {
-	rsp, err := c.GetOperation(ctx, partitionID, operationID, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteKeyFromPartitionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetOperationResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.DeleteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseGetClusterStateResponse parses an HTTP response from a GetClusterStateWithResponse call
-func ParseGetClusterStateResponse(rsp *http.Response) (*GetClusterStateResponse, error) {
+// ParseGetValueFromPartitionResponse parses an HTTP response from a GetValueFromPartitionWithResponse call
+func ParseGetValueFromPartitionResponse(rsp *http.Response) (*GetValueFromPartitionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetClusterStateResponse{
+	response := &GetValueFromPartitionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest NodeState
+		var dest externalRef0.KeyValueResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest externalRef0.ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -993,25 +2850,39 @@ func ParseGetClusterStateResponse(rsp *http.Response) (*GetClusterStateResponse,
 		}
 		response.JSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseUpdateNodeStateResponse parses an HTTP response from a UpdateNodeStateWithResponse call
-func ParseUpdateNodeStateResponse(rsp *http.Response) (*UpdateNodeStateResponse, error) {
+// ParseSetValueInPartitionResponse parses an HTTP response from a SetValueInPartitionWithResponse call
+func ParseSetValueInPartitionResponse(rsp *http.Response) (*SetValueInPartitionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &UpdateNodeStateResponse{
+	response := &SetValueInPartitionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.KeyValuePair
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest externalRef0.ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1026,67 +2897,81 @@ func ParseUpdateNodeStateResponse(rsp *http.Response) (*UpdateNodeStateResponse,
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
 		var dest externalRef0.ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON504 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseApplyOperationResponse parses an HTTP response from a ApplyOperationWithResponse call
-func ParseApplyOperationResponse(rsp *http.Response) (*ApplyOperationResponse, error) {
+// ParseStartMigrationResponse parses an HTTP response from a StartMigrationWithResponse call
+func ParseStartMigrationResponse(rsp *http.Response) (*StartMigrationResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ApplyOperationResponse{
+	response := &StartMigrationResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest externalRef0.ErrorResponse
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest MigrationTaskStatus
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON202 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
 		var dest externalRef0.ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSONDefault = &dest
+		response.JSON409 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseDeleteKeyFromPartitionResponse parses an HTTP response from a DeleteKeyFromPartitionWithResponse call
-func ParseDeleteKeyFromPartitionResponse(rsp *http.Response) (*DeleteKeyFromPartitionResponse, error) {
+// ParseGetMigrationStatusResponse parses an HTTP response from a GetMigrationStatusWithResponse call
+func ParseGetMigrationStatusResponse(rsp *http.Response) (*GetMigrationStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteKeyFromPartitionResponse{
+	response := &GetMigrationStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.DeleteResponse
+		var dest MigrationTaskStatus
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1099,147 +2984,126 @@ func ParseDeleteKeyFromPartitionResponse(rsp *http.Response) (*DeleteKeyFromPart
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest externalRef0.ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
-
 	}
 
 	return response, nil
 }
 
-// ParseGetValueFromPartitionResponse parses an HTTP response from a GetValueFromPartitionWithResponse call
-func ParseGetValueFromPartitionResponse(rsp *http.Response) (*GetValueFromPartitionResponse, error) {
+// ParseCommitMigrationResponse parses an HTTP response from a CommitMigrationWithResponse call
+func ParseCommitMigrationResponse(rsp *http.Response) (*CommitMigrationResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetValueFromPartitionResponse{
+	response := &CommitMigrationResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.KeyValueResponse
+		var dest MigrationTaskStatus
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest externalRef0.ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
 		var dest externalRef0.ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON409 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseSetValueInPartitionResponse parses an HTTP response from a SetValueInPartitionWithResponse call
-func ParseSetValueInPartitionResponse(rsp *http.Response) (*SetValueInPartitionResponse, error) {
+// ParseGetOperationsAfterResponse parses an HTTP response from a GetOperationsAfterWithResponse call
+func ParseGetOperationsAfterResponse(rsp *http.Response) (*GetOperationsAfterResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SetValueInPartitionResponse{
+	response := &GetOperationsAfterResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.KeyValuePair
+		var dest []externalRef0.Operation
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest externalRef0.ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest externalRef0.ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
 		var dest externalRef0.ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON410 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetOperationsAfterResponse parses an HTTP response from a GetOperationsAfterWithResponse call
-func ParseGetOperationsAfterResponse(rsp *http.Response) (*GetOperationsAfterResponse, error) {
+// ParseGetOperationResponse parses an HTTP response from a GetOperationWithResponse call
+func ParseGetOperationResponse(rsp *http.Response) (*GetOperationResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetOperationsAfterResponse{
+	response := &GetOperationResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []externalRef0.Operation
+		var dest externalRef0.Operation
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetOperationResponse parses an HTTP response from a GetOperationWithResponse call
-func ParseGetOperationResponse(rsp *http.Response) (*GetOperationResponse, error) {
+// ParseGetSnapshotResponse parses an HTTP response from a GetSnapshotWithResponse call
+func ParseGetSnapshotResponse(rsp *http.Response) (*GetSnapshotResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetOperationResponse{
+	response := &GetSnapshotResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.Operation
+		var dest externalRef0.Snapshot
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1262,95 +3126,333 @@ type ServerInterface interface {
 	// Get current cluster state
 	// (GET /cluster/state)
 	GetClusterState(w http.ResponseWriter, r *http.Request)
+	// Patch node state
+	// (PATCH /nodes/{nodeId}/state)
+	PatchNodeState(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID)
 	// Update node state
 	// (PUT /nodes/{nodeId}/state)
 	UpdateNodeState(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID)
+	// Get the replication status of every partition hosted by this node
+	// (GET /partitions/status)
+	GetPartitionsStatus(w http.ResponseWriter, r *http.Request)
 	// Apply an operation to a replica partition
 	// (POST /partitions/{partitionID}/operations)
 	ApplyOperation(w http.ResponseWriter, r *http.Request, partitionID string)
+	// Apply a batch of consecutive operations to a replica partition
+	// (POST /partitions/{partitionID}/operations/batch)
+	ApplyOperations(w http.ResponseWriter, r *http.Request, partitionID string)
+	// Replicate log entries of the partition's Raft leader, or heartbeat
+	// (POST /partitions/{partitionID}/raft/append)
+	AppendRaftEntries(w http.ResponseWriter, r *http.Request, partitionID string)
+	// Install the snapshot of the partition's Raft leader on a follower missing compacted entries
+	// (POST /partitions/{partitionID}/raft/snapshot)
+	InstallRaftSnapshot(w http.ResponseWriter, r *http.Request, partitionID string)
+	// Ask for the vote of a node of the partition's Raft group
+	// (POST /partitions/{partitionID}/raft/vote)
+	RequestRaftVote(w http.ResponseWriter, r *http.Request, partitionID string)
+	// Import migrated key-value pairs into a master partition
+	// (POST /partitions/{partitionId}/import)
+	ImportKeys(w http.ResponseWriter, r *http.Request, partitionID string)
 	// Delete key from partition
 	// (DELETE /partitions/{partitionId}/keys/{key})
-	DeleteKeyFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string)
+	DeleteKeyFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params DeleteKeyFromPartitionParams)
 	// Get value by key from partition
 	// (GET /partitions/{partitionId}/keys/{key})
-	GetValueFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string)
+	GetValueFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params GetValueFromPartitionParams)
 	// Set key-value pair in partition
 	// (PUT /partitions/{partitionId}/keys/{key})
-	SetValueInPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string)
+	SetValueInPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params SetValueInPartitionParams)
+	// Start copying a hash range of a master partition to another partition
+	// (POST /partitions/{partitionId}/migrations)
+	StartMigration(w http.ResponseWriter, r *http.Request, partitionID string)
+	// Get the progress of a migration started on this node
+	// (GET /partitions/{partitionId}/migrations/{migrationId})
+	GetMigrationStatus(w http.ResponseWriter, r *http.Request, partitionID string, migrationID openapi_types.UUID)
+	// Delete the migrated keys from the source partition
+	// (POST /partitions/{partitionId}/migrations/{migrationId}/commit)
+	CommitMigration(w http.ResponseWriter, r *http.Request, partitionID string, migrationID openapi_types.UUID)
 	// Get all operations after specified ID
 	// (GET /replication/{partitionId}/checkpoint/{lastOperationId})
 	GetOperationsAfter(w http.ResponseWriter, r *http.Request, partitionID string, lastOperationID int64)
 	// Get a specific operation by ID
 	// (GET /replication/{partitionId}/operation/{operationId})
 	GetOperation(w http.ResponseWriter, r *http.Request, partitionID string, operationID int64)
+	// Get a point-in-time snapshot of a partition
+	// (GET /replication/{partitionId}/snapshot)
+	GetSnapshot(w http.ResponseWriter, r *http.Request, partitionID string)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
-// Get current cluster state
-// (GET /cluster/state)
-func (_ Unimplemented) GetClusterState(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// Get current cluster state
+// (GET /cluster/state)
+func (_ Unimplemented) GetClusterState(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Patch node state
+// (PATCH /nodes/{nodeId}/state)
+func (_ Unimplemented) PatchNodeState(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update node state
+// (PUT /nodes/{nodeId}/state)
+func (_ Unimplemented) UpdateNodeState(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the replication status of every partition hosted by this node
+// (GET /partitions/status)
+func (_ Unimplemented) GetPartitionsStatus(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Apply an operation to a replica partition
+// (POST /partitions/{partitionID}/operations)
+func (_ Unimplemented) ApplyOperation(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Apply a batch of consecutive operations to a replica partition
+// (POST /partitions/{partitionID}/operations/batch)
+func (_ Unimplemented) ApplyOperations(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replicate log entries of the partition's Raft leader, or heartbeat
+// (POST /partitions/{partitionID}/raft/append)
+func (_ Unimplemented) AppendRaftEntries(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Install the snapshot of the partition's Raft leader on a follower missing compacted entries
+// (POST /partitions/{partitionID}/raft/snapshot)
+func (_ Unimplemented) InstallRaftSnapshot(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Ask for the vote of a node of the partition's Raft group
+// (POST /partitions/{partitionID}/raft/vote)
+func (_ Unimplemented) RequestRaftVote(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import migrated key-value pairs into a master partition
+// (POST /partitions/{partitionId}/import)
+func (_ Unimplemented) ImportKeys(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete key from partition
+// (DELETE /partitions/{partitionId}/keys/{key})
+func (_ Unimplemented) DeleteKeyFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params DeleteKeyFromPartitionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get value by key from partition
+// (GET /partitions/{partitionId}/keys/{key})
+func (_ Unimplemented) GetValueFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params GetValueFromPartitionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set key-value pair in partition
+// (PUT /partitions/{partitionId}/keys/{key})
+func (_ Unimplemented) SetValueInPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params SetValueInPartitionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start copying a hash range of a master partition to another partition
+// (POST /partitions/{partitionId}/migrations)
+func (_ Unimplemented) StartMigration(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the progress of a migration started on this node
+// (GET /partitions/{partitionId}/migrations/{migrationId})
+func (_ Unimplemented) GetMigrationStatus(w http.ResponseWriter, r *http.Request, partitionID string, migrationID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete the migrated keys from the source partition
+// (POST /partitions/{partitionId}/migrations/{migrationId}/commit)
+func (_ Unimplemented) CommitMigration(w http.ResponseWriter, r *http.Request, partitionID string, migrationID openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all operations after specified ID
+// (GET /replication/{partitionId}/checkpoint/{lastOperationId})
+func (_ Unimplemented) GetOperationsAfter(w http.ResponseWriter, r *http.Request, partitionID string, lastOperationID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a specific operation by ID
+// (GET /replication/{partitionId}/operation/{operationId})
+func (_ Unimplemented) GetOperation(w http.ResponseWriter, r *http.Request, partitionID string, operationID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a point-in-time snapshot of a partition
+// (GET /replication/{partitionId}/snapshot)
+func (_ Unimplemented) GetSnapshot(w http.ResponseWriter, r *http.Request, partitionID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetClusterState operation middleware
+func (siw *ServerInterfaceWrapper) GetClusterState(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClusterState(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchNodeState operation middleware
+func (siw *ServerInterfaceWrapper) PatchNodeState(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "nodeId" -------------
+	var nodeID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "nodeId", chi.URLParam(r, "nodeId"), &nodeID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nodeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchNodeState(w, r, nodeID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateNodeState operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNodeState(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "nodeId" -------------
+	var nodeID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "nodeId", chi.URLParam(r, "nodeId"), &nodeID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nodeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateNodeState(w, r, nodeID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPartitionsStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetPartitionsStatus(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPartitionsStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ApplyOperation operation middleware
+func (siw *ServerInterfaceWrapper) ApplyOperation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "partitionID" -------------
+	var partitionID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionID", chi.URLParam(r, "partitionID"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApplyOperation(w, r, partitionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// Update node state
-// (PUT /nodes/{nodeId}/state)
-func (_ Unimplemented) UpdateNodeState(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// ApplyOperations operation middleware
+func (siw *ServerInterfaceWrapper) ApplyOperations(w http.ResponseWriter, r *http.Request) {
 
-// Apply an operation to a replica partition
-// (POST /partitions/{partitionID}/operations)
-func (_ Unimplemented) ApplyOperation(w http.ResponseWriter, r *http.Request, partitionID string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	var err error
 
-// Delete key from partition
-// (DELETE /partitions/{partitionId}/keys/{key})
-func (_ Unimplemented) DeleteKeyFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "partitionID" -------------
+	var partitionID string
 
-// Get value by key from partition
-// (GET /partitions/{partitionId}/keys/{key})
-func (_ Unimplemented) GetValueFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionID", chi.URLParam(r, "partitionID"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionID", Err: err})
+		return
+	}
 
-// Set key-value pair in partition
-// (PUT /partitions/{partitionId}/keys/{key})
-func (_ Unimplemented) SetValueInPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApplyOperations(w, r, partitionID)
+	}))
 
-// Get all operations after specified ID
-// (GET /replication/{partitionId}/checkpoint/{lastOperationId})
-func (_ Unimplemented) GetOperationsAfter(w http.ResponseWriter, r *http.Request, partitionID string, lastOperationID int64) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Get a specific operation by ID
-// (GET /replication/{partitionId}/operation/{operationId})
-func (_ Unimplemented) GetOperation(w http.ResponseWriter, r *http.Request, partitionID string, operationID int64) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+// AppendRaftEntries operation middleware
+func (siw *ServerInterfaceWrapper) AppendRaftEntries(w http.ResponseWriter, r *http.Request) {
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	var err error
 
-// GetClusterState operation middleware
-func (siw *ServerInterfaceWrapper) GetClusterState(w http.ResponseWriter, r *http.Request) {
+	// ------------- Path parameter "partitionID" -------------
+	var partitionID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionID", chi.URLParam(r, "partitionID"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionID", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetClusterState(w, r)
+		siw.Handler.AppendRaftEntries(w, r, partitionID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1360,22 +3462,22 @@ func (siw *ServerInterfaceWrapper) GetClusterState(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateNodeState operation middleware
-func (siw *ServerInterfaceWrapper) UpdateNodeState(w http.ResponseWriter, r *http.Request) {
+// InstallRaftSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) InstallRaftSnapshot(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "nodeId" -------------
-	var nodeID openapi_types.UUID
+	// ------------- Path parameter "partitionID" -------------
+	var partitionID string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "nodeId", chi.URLParam(r, "nodeId"), &nodeID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionID", chi.URLParam(r, "partitionID"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nodeId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionID", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateNodeState(w, r, nodeID)
+		siw.Handler.InstallRaftSnapshot(w, r, partitionID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1385,8 +3487,8 @@ func (siw *ServerInterfaceWrapper) UpdateNodeState(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// ApplyOperation operation middleware
-func (siw *ServerInterfaceWrapper) ApplyOperation(w http.ResponseWriter, r *http.Request) {
+// RequestRaftVote operation middleware
+func (siw *ServerInterfaceWrapper) RequestRaftVote(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1400,7 +3502,32 @@ func (siw *ServerInterfaceWrapper) ApplyOperation(w http.ResponseWriter, r *http
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ApplyOperation(w, r, partitionID)
+		siw.Handler.RequestRaftVote(w, r, partitionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportKeys operation middleware
+func (siw *ServerInterfaceWrapper) ImportKeys(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "partitionId" -------------
+	var partitionID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionId", chi.URLParam(r, "partitionId"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportKeys(w, r, partitionID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1433,8 +3560,27 @@ func (siw *ServerInterfaceWrapper) DeleteKeyFromPartition(w http.ResponseWriter,
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteKeyFromPartitionParams
+
+	// ------------- Optional query parameter "epoch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "epoch", r.URL.Query(), &params.Epoch)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "epoch", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "w" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "w", r.URL.Query(), &params.W)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "w", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteKeyFromPartition(w, r, partitionID, key)
+		siw.Handler.DeleteKeyFromPartition(w, r, partitionID, key, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1467,8 +3613,19 @@ func (siw *ServerInterfaceWrapper) GetValueFromPartition(w http.ResponseWriter,
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetValueFromPartitionParams
+
+	// ------------- Optional query parameter "minOperationId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minOperationId", r.URL.Query(), &params.MinOperationId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minOperationId", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetValueFromPartition(w, r, partitionID, key)
+		siw.Handler.GetValueFromPartition(w, r, partitionID, key, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1501,8 +3658,120 @@ func (siw *ServerInterfaceWrapper) SetValueInPartition(w http.ResponseWriter, r
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetValueInPartitionParams
+
+	// ------------- Optional query parameter "epoch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "epoch", r.URL.Query(), &params.Epoch)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "epoch", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "w" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "w", r.URL.Query(), &params.W)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "w", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetValueInPartition(w, r, partitionID, key, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartMigration operation middleware
+func (siw *ServerInterfaceWrapper) StartMigration(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "partitionId" -------------
+	var partitionID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionId", chi.URLParam(r, "partitionId"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartMigration(w, r, partitionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMigrationStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetMigrationStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "partitionId" -------------
+	var partitionID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionId", chi.URLParam(r, "partitionId"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "migrationId" -------------
+	var migrationID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "migrationId", chi.URLParam(r, "migrationId"), &migrationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "migrationId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMigrationStatus(w, r, partitionID, migrationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CommitMigration operation middleware
+func (siw *ServerInterfaceWrapper) CommitMigration(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "partitionId" -------------
+	var partitionID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionId", chi.URLParam(r, "partitionId"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "migrationId" -------------
+	var migrationID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "migrationId", chi.URLParam(r, "migrationId"), &migrationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "migrationId", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SetValueInPartition(w, r, partitionID, key)
+		siw.Handler.CommitMigration(w, r, partitionID, migrationID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1580,6 +3849,31 @@ func (siw *ServerInterfaceWrapper) GetOperation(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "partitionId" -------------
+	var partitionID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "partitionId", chi.URLParam(r, "partitionId"), &partitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partitionId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSnapshot(w, r, partitionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -1696,12 +3990,33 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/cluster/state", wrapper.GetClusterState)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/nodes/{nodeId}/state", wrapper.PatchNodeState)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/nodes/{nodeId}/state", wrapper.UpdateNodeState)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/partitions/status", wrapper.GetPartitionsStatus)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/partitions/{partitionID}/operations", wrapper.ApplyOperation)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/partitions/{partitionID}/operations/batch", wrapper.ApplyOperations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/partitions/{partitionID}/raft/append", wrapper.AppendRaftEntries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/partitions/{partitionID}/raft/snapshot", wrapper.InstallRaftSnapshot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/partitions/{partitionID}/raft/vote", wrapper.RequestRaftVote)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/partitions/{partitionId}/import", wrapper.ImportKeys)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/partitions/{partitionId}/keys/{key}", wrapper.DeleteKeyFromPartition)
 	})
@@ -1711,11 +4026,23 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/partitions/{partitionId}/keys/{key}", wrapper.SetValueInPartition)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/partitions/{partitionId}/migrations", wrapper.StartMigration)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/partitions/{partitionId}/migrations/{migrationId}", wrapper.GetMigrationStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/partitions/{partitionId}/migrations/{migrationId}/commit", wrapper.CommitMigration)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/replication/{partitionId}/checkpoint/{lastOperationId}", wrapper.GetOperationsAfter)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/replication/{partitionId}/operation/{operationId}", wrapper.GetOperation)
+		r.Get(options.BaseURL+"/replication/{partitionId}/operation/{operationId}", wrapper.GetOperation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/replication/{partitionId}/snapshot", wrapper.GetSnapshot)
 	})
 
 	return r
@@ -1746,6 +4073,42 @@ func (response GetClusterState500JSONResponse) VisitGetClusterStateResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PatchNodeStateRequestObject struct {
+	NodeID openapi_types.UUID `json:"nodeId"`
+	Body   *PatchNodeStateJSONRequestBody
+}
+
+type PatchNodeStateResponseObject interface {
+	VisitPatchNodeStateResponse(w http.ResponseWriter) error
+}
+
+type PatchNodeState200JSONResponse externalRef0.StateAck
+
+func (response PatchNodeState200JSONResponse) VisitPatchNodeStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchNodeState409JSONResponse externalRef0.ErrorResponse
+
+func (response PatchNodeState409JSONResponse) VisitPatchNodeStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchNodeState500JSONResponse externalRef0.ErrorResponse
+
+func (response PatchNodeState500JSONResponse) VisitPatchNodeStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type UpdateNodeStateRequestObject struct {
 	NodeID openapi_types.UUID `json:"nodeId"`
 	Body   *UpdateNodeStateJSONRequestBody
@@ -1755,12 +4118,13 @@ type UpdateNodeStateResponseObject interface {
 	VisitUpdateNodeStateResponse(w http.ResponseWriter) error
 }
 
-type UpdateNodeState200Response struct {
-}
+type UpdateNodeState200JSONResponse externalRef0.StateAck
 
-func (response UpdateNodeState200Response) VisitUpdateNodeStateResponse(w http.ResponseWriter) error {
+func (response UpdateNodeState200JSONResponse) VisitUpdateNodeStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 type UpdateNodeState400JSONResponse externalRef0.ErrorResponse
@@ -1790,6 +4154,22 @@ func (response UpdateNodeState500JSONResponse) VisitUpdateNodeStateResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetPartitionsStatusRequestObject struct {
+}
+
+type GetPartitionsStatusResponseObject interface {
+	VisitGetPartitionsStatusResponse(w http.ResponseWriter) error
+}
+
+type GetPartitionsStatus200JSONResponse []externalRef0.PartitionStatus
+
+func (response GetPartitionsStatus200JSONResponse) VisitGetPartitionsStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ApplyOperationRequestObject struct {
 	PartitionID string `json:"partitionID"`
 	Body        *ApplyOperationJSONRequestBody
@@ -1816,6 +4196,15 @@ func (response ApplyOperation400JSONResponse) VisitApplyOperationResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ApplyOperation409JSONResponse externalRef0.ErrorResponse
+
+func (response ApplyOperation409JSONResponse) VisitApplyOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ApplyOperationdefaultJSONResponse struct {
 	Body       externalRef0.ErrorResponse
 	StatusCode int
@@ -1828,9 +4217,154 @@ func (response ApplyOperationdefaultJSONResponse) VisitApplyOperationResponse(w
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type ApplyOperationsRequestObject struct {
+	PartitionID string `json:"partitionID"`
+	Body        *ApplyOperationsJSONRequestBody
+}
+
+type ApplyOperationsResponseObject interface {
+	VisitApplyOperationsResponse(w http.ResponseWriter) error
+}
+
+type ApplyOperations200JSONResponse ReplicationAck
+
+func (response ApplyOperations200JSONResponse) VisitApplyOperationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyOperations400JSONResponse externalRef0.ErrorResponse
+
+func (response ApplyOperations400JSONResponse) VisitApplyOperationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyOperations409JSONResponse externalRef0.ErrorResponse
+
+func (response ApplyOperations409JSONResponse) VisitApplyOperationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AppendRaftEntriesRequestObject struct {
+	PartitionID string `json:"partitionID"`
+	Body        *AppendRaftEntriesJSONRequestBody
+}
+
+type AppendRaftEntriesResponseObject interface {
+	VisitAppendRaftEntriesResponse(w http.ResponseWriter) error
+}
+
+type AppendRaftEntries200JSONResponse externalRef0.RaftAppendResponse
+
+func (response AppendRaftEntries200JSONResponse) VisitAppendRaftEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AppendRaftEntries404JSONResponse externalRef0.ErrorResponse
+
+func (response AppendRaftEntries404JSONResponse) VisitAppendRaftEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InstallRaftSnapshotRequestObject struct {
+	PartitionID string `json:"partitionID"`
+	Body        *InstallRaftSnapshotJSONRequestBody
+}
+
+type InstallRaftSnapshotResponseObject interface {
+	VisitInstallRaftSnapshotResponse(w http.ResponseWriter) error
+}
+
+type InstallRaftSnapshot200JSONResponse externalRef0.RaftSnapshotResponse
+
+func (response InstallRaftSnapshot200JSONResponse) VisitInstallRaftSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InstallRaftSnapshot404JSONResponse externalRef0.ErrorResponse
+
+func (response InstallRaftSnapshot404JSONResponse) VisitInstallRaftSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestRaftVoteRequestObject struct {
+	PartitionID string `json:"partitionID"`
+	Body        *RequestRaftVoteJSONRequestBody
+}
+
+type RequestRaftVoteResponseObject interface {
+	VisitRequestRaftVoteResponse(w http.ResponseWriter) error
+}
+
+type RequestRaftVote200JSONResponse externalRef0.RaftVoteResponse
+
+func (response RequestRaftVote200JSONResponse) VisitRequestRaftVoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestRaftVote404JSONResponse externalRef0.ErrorResponse
+
+func (response RequestRaftVote404JSONResponse) VisitRequestRaftVoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportKeysRequestObject struct {
+	PartitionID string `json:"partitionId"`
+	Body        *ImportKeysJSONRequestBody
+}
+
+type ImportKeysResponseObject interface {
+	VisitImportKeysResponse(w http.ResponseWriter) error
+}
+
+type ImportKeys200JSONResponse ImportResponse
+
+func (response ImportKeys200JSONResponse) VisitImportKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportKeys409JSONResponse externalRef0.ErrorResponse
+
+func (response ImportKeys409JSONResponse) VisitImportKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteKeyFromPartitionRequestObject struct {
 	PartitionID string `json:"partitionId"`
 	Key         string `json:"key"`
+	Params      DeleteKeyFromPartitionParams
 }
 
 type DeleteKeyFromPartitionResponseObject interface {
@@ -1855,6 +4389,15 @@ func (response DeleteKeyFromPartition404JSONResponse) VisitDeleteKeyFromPartitio
 	return json.NewEncoder(w).Encode(response)
 }
 
+type DeleteKeyFromPartition409JSONResponse externalRef0.ErrorResponse
+
+func (response DeleteKeyFromPartition409JSONResponse) VisitDeleteKeyFromPartitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteKeyFromPartition500JSONResponse externalRef0.ErrorResponse
 
 func (response DeleteKeyFromPartition500JSONResponse) VisitDeleteKeyFromPartitionResponse(w http.ResponseWriter) error {
@@ -1864,9 +4407,19 @@ func (response DeleteKeyFromPartition500JSONResponse) VisitDeleteKeyFromPartitio
 	return json.NewEncoder(w).Encode(response)
 }
 
+type DeleteKeyFromPartition504JSONResponse externalRef0.ErrorResponse
+
+func (response DeleteKeyFromPartition504JSONResponse) VisitDeleteKeyFromPartitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(504)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetValueFromPartitionRequestObject struct {
 	PartitionID string `json:"partitionId"`
 	Key         string `json:"key"`
+	Params      GetValueFromPartitionParams
 }
 
 type GetValueFromPartitionResponseObject interface {
@@ -1900,9 +4453,19 @@ func (response GetValueFromPartition500JSONResponse) VisitGetValueFromPartitionR
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetValueFromPartition503JSONResponse externalRef0.ErrorResponse
+
+func (response GetValueFromPartition503JSONResponse) VisitGetValueFromPartitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type SetValueInPartitionRequestObject struct {
 	PartitionID string `json:"partitionId"`
 	Key         string `json:"key"`
+	Params      SetValueInPartitionParams
 	Body        *SetValueInPartitionJSONRequestBody
 }
 
@@ -1937,6 +4500,15 @@ func (response SetValueInPartition404JSONResponse) VisitSetValueInPartitionRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type SetValueInPartition409JSONResponse externalRef0.ErrorResponse
+
+func (response SetValueInPartition409JSONResponse) VisitSetValueInPartitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type SetValueInPartition500JSONResponse externalRef0.ErrorResponse
 
 func (response SetValueInPartition500JSONResponse) VisitSetValueInPartitionResponse(w http.ResponseWriter) error {
@@ -1946,6 +4518,97 @@ func (response SetValueInPartition500JSONResponse) VisitSetValueInPartitionRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type SetValueInPartition504JSONResponse externalRef0.ErrorResponse
+
+func (response SetValueInPartition504JSONResponse) VisitSetValueInPartitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(504)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartMigrationRequestObject struct {
+	PartitionID string `json:"partitionId"`
+	Body        *StartMigrationJSONRequestBody
+}
+
+type StartMigrationResponseObject interface {
+	VisitStartMigrationResponse(w http.ResponseWriter) error
+}
+
+type StartMigration202JSONResponse MigrationTaskStatus
+
+func (response StartMigration202JSONResponse) VisitStartMigrationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartMigration409JSONResponse externalRef0.ErrorResponse
+
+func (response StartMigration409JSONResponse) VisitStartMigrationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMigrationStatusRequestObject struct {
+	PartitionID string             `json:"partitionId"`
+	MigrationID openapi_types.UUID `json:"migrationId"`
+}
+
+type GetMigrationStatusResponseObject interface {
+	VisitGetMigrationStatusResponse(w http.ResponseWriter) error
+}
+
+type GetMigrationStatus200JSONResponse MigrationTaskStatus
+
+func (response GetMigrationStatus200JSONResponse) VisitGetMigrationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMigrationStatus404JSONResponse externalRef0.ErrorResponse
+
+func (response GetMigrationStatus404JSONResponse) VisitGetMigrationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CommitMigrationRequestObject struct {
+	PartitionID string             `json:"partitionId"`
+	MigrationID openapi_types.UUID `json:"migrationId"`
+	Body        *CommitMigrationJSONRequestBody
+}
+
+type CommitMigrationResponseObject interface {
+	VisitCommitMigrationResponse(w http.ResponseWriter) error
+}
+
+type CommitMigration200JSONResponse MigrationTaskStatus
+
+func (response CommitMigration200JSONResponse) VisitCommitMigrationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CommitMigration409JSONResponse externalRef0.ErrorResponse
+
+func (response CommitMigration409JSONResponse) VisitCommitMigrationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetOperationsAfterRequestObject struct {
 	PartitionID     string `json:"partitionId"`
 	LastOperationID int64  `json:"lastOperationId"`
@@ -1964,6 +4627,15 @@ func (response GetOperationsAfter200JSONResponse) VisitGetOperationsAfterRespons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetOperationsAfter410JSONResponse externalRef0.ErrorResponse
+
+func (response GetOperationsAfter410JSONResponse) VisitGetOperationsAfterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetOperationRequestObject struct {
 	PartitionID string `json:"partitionId"`
 	OperationID int64  `json:"operationId"`
@@ -1984,7 +4656,33 @@ func (response GetOperation200JSONResponse) VisitGetOperationResponse(w http.Res
 
 type GetOperation404JSONResponse externalRef0.ErrorResponse
 
-func (response GetOperation404JSONResponse) VisitGetOperationResponse(w http.ResponseWriter) error {
+func (response GetOperation404JSONResponse) VisitGetOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSnapshotRequestObject struct {
+	PartitionID string `json:"partitionId"`
+}
+
+type GetSnapshotResponseObject interface {
+	VisitGetSnapshotResponse(w http.ResponseWriter) error
+}
+
+type GetSnapshot200JSONResponse externalRef0.Snapshot
+
+func (response GetSnapshot200JSONResponse) VisitGetSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSnapshot404JSONResponse externalRef0.ErrorResponse
+
+func (response GetSnapshot404JSONResponse) VisitGetSnapshotResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
@@ -1996,12 +4694,33 @@ type StrictServerInterface interface {
 	// Get current cluster state
 	// (GET /cluster/state)
 	GetClusterState(ctx context.Context, request GetClusterStateRequestObject) (GetClusterStateResponseObject, error)
+	// Patch node state
+	// (PATCH /nodes/{nodeId}/state)
+	PatchNodeState(ctx context.Context, request PatchNodeStateRequestObject) (PatchNodeStateResponseObject, error)
 	// Update node state
 	// (PUT /nodes/{nodeId}/state)
 	UpdateNodeState(ctx context.Context, request UpdateNodeStateRequestObject) (UpdateNodeStateResponseObject, error)
+	// Get the replication status of every partition hosted by this node
+	// (GET /partitions/status)
+	GetPartitionsStatus(ctx context.Context, request GetPartitionsStatusRequestObject) (GetPartitionsStatusResponseObject, error)
 	// Apply an operation to a replica partition
 	// (POST /partitions/{partitionID}/operations)
 	ApplyOperation(ctx context.Context, request ApplyOperationRequestObject) (ApplyOperationResponseObject, error)
+	// Apply a batch of consecutive operations to a replica partition
+	// (POST /partitions/{partitionID}/operations/batch)
+	ApplyOperations(ctx context.Context, request ApplyOperationsRequestObject) (ApplyOperationsResponseObject, error)
+	// Replicate log entries of the partition's Raft leader, or heartbeat
+	// (POST /partitions/{partitionID}/raft/append)
+	AppendRaftEntries(ctx context.Context, request AppendRaftEntriesRequestObject) (AppendRaftEntriesResponseObject, error)
+	// Install the snapshot of the partition's Raft leader on a follower missing compacted entries
+	// (POST /partitions/{partitionID}/raft/snapshot)
+	InstallRaftSnapshot(ctx context.Context, request InstallRaftSnapshotRequestObject) (InstallRaftSnapshotResponseObject, error)
+	// Ask for the vote of a node of the partition's Raft group
+	// (POST /partitions/{partitionID}/raft/vote)
+	RequestRaftVote(ctx context.Context, request RequestRaftVoteRequestObject) (RequestRaftVoteResponseObject, error)
+	// Import migrated key-value pairs into a master partition
+	// (POST /partitions/{partitionId}/import)
+	ImportKeys(ctx context.Context, request ImportKeysRequestObject) (ImportKeysResponseObject, error)
 	// Delete key from partition
 	// (DELETE /partitions/{partitionId}/keys/{key})
 	DeleteKeyFromPartition(ctx context.Context, request DeleteKeyFromPartitionRequestObject) (DeleteKeyFromPartitionResponseObject, error)
@@ -2011,12 +4730,24 @@ type StrictServerInterface interface {
 	// Set key-value pair in partition
 	// (PUT /partitions/{partitionId}/keys/{key})
 	SetValueInPartition(ctx context.Context, request SetValueInPartitionRequestObject) (SetValueInPartitionResponseObject, error)
+	// Start copying a hash range of a master partition to another partition
+	// (POST /partitions/{partitionId}/migrations)
+	StartMigration(ctx context.Context, request StartMigrationRequestObject) (StartMigrationResponseObject, error)
+	// Get the progress of a migration started on this node
+	// (GET /partitions/{partitionId}/migrations/{migrationId})
+	GetMigrationStatus(ctx context.Context, request GetMigrationStatusRequestObject) (GetMigrationStatusResponseObject, error)
+	// Delete the migrated keys from the source partition
+	// (POST /partitions/{partitionId}/migrations/{migrationId}/commit)
+	CommitMigration(ctx context.Context, request CommitMigrationRequestObject) (CommitMigrationResponseObject, error)
 	// Get all operations after specified ID
 	// (GET /replication/{partitionId}/checkpoint/{lastOperationId})
 	GetOperationsAfter(ctx context.Context, request GetOperationsAfterRequestObject) (GetOperationsAfterResponseObject, error)
 	// Get a specific operation by ID
 	// (GET /replication/{partitionId}/operation/{operationId})
 	GetOperation(ctx context.Context, request GetOperationRequestObject) (GetOperationResponseObject, error)
+	// Get a point-in-time snapshot of a partition
+	// (GET /replication/{partitionId}/snapshot)
+	GetSnapshot(ctx context.Context, request GetSnapshotRequestObject) (GetSnapshotResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -2072,6 +4803,39 @@ func (sh *strictHandler) GetClusterState(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// PatchNodeState operation middleware
+func (sh *strictHandler) PatchNodeState(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
+	var request PatchNodeStateRequestObject
+
+	request.NodeID = nodeID
+
+	var body PatchNodeStateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchNodeState(ctx, request.(PatchNodeStateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchNodeState")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchNodeStateResponseObject); ok {
+		if err := validResponse.VisitPatchNodeStateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // UpdateNodeState operation middleware
 func (sh *strictHandler) UpdateNodeState(w http.ResponseWriter, r *http.Request, nodeID openapi_types.UUID) {
 	var request UpdateNodeStateRequestObject
@@ -2105,6 +4869,30 @@ func (sh *strictHandler) UpdateNodeState(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// GetPartitionsStatus operation middleware
+func (sh *strictHandler) GetPartitionsStatus(w http.ResponseWriter, r *http.Request) {
+	var request GetPartitionsStatusRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPartitionsStatus(ctx, request.(GetPartitionsStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPartitionsStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPartitionsStatusResponseObject); ok {
+		if err := validResponse.VisitGetPartitionsStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ApplyOperation operation middleware
 func (sh *strictHandler) ApplyOperation(w http.ResponseWriter, r *http.Request, partitionID string) {
 	var request ApplyOperationRequestObject
@@ -2138,12 +4926,178 @@ func (sh *strictHandler) ApplyOperation(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// ApplyOperations operation middleware
+func (sh *strictHandler) ApplyOperations(w http.ResponseWriter, r *http.Request, partitionID string) {
+	var request ApplyOperationsRequestObject
+
+	request.PartitionID = partitionID
+
+	var body ApplyOperationsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApplyOperations(ctx, request.(ApplyOperationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApplyOperations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApplyOperationsResponseObject); ok {
+		if err := validResponse.VisitApplyOperationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AppendRaftEntries operation middleware
+func (sh *strictHandler) AppendRaftEntries(w http.ResponseWriter, r *http.Request, partitionID string) {
+	var request AppendRaftEntriesRequestObject
+
+	request.PartitionID = partitionID
+
+	var body AppendRaftEntriesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AppendRaftEntries(ctx, request.(AppendRaftEntriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AppendRaftEntries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AppendRaftEntriesResponseObject); ok {
+		if err := validResponse.VisitAppendRaftEntriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// InstallRaftSnapshot operation middleware
+func (sh *strictHandler) InstallRaftSnapshot(w http.ResponseWriter, r *http.Request, partitionID string) {
+	var request InstallRaftSnapshotRequestObject
+
+	request.PartitionID = partitionID
+
+	var body InstallRaftSnapshotJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.InstallRaftSnapshot(ctx, request.(InstallRaftSnapshotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "InstallRaftSnapshot")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(InstallRaftSnapshotResponseObject); ok {
+		if err := validResponse.VisitInstallRaftSnapshotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RequestRaftVote operation middleware
+func (sh *strictHandler) RequestRaftVote(w http.ResponseWriter, r *http.Request, partitionID string) {
+	var request RequestRaftVoteRequestObject
+
+	request.PartitionID = partitionID
+
+	var body RequestRaftVoteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestRaftVote(ctx, request.(RequestRaftVoteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestRaftVote")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RequestRaftVoteResponseObject); ok {
+		if err := validResponse.VisitRequestRaftVoteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportKeys operation middleware
+func (sh *strictHandler) ImportKeys(w http.ResponseWriter, r *http.Request, partitionID string) {
+	var request ImportKeysRequestObject
+
+	request.PartitionID = partitionID
+
+	var body ImportKeysJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportKeys(ctx, request.(ImportKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportKeysResponseObject); ok {
+		if err := validResponse.VisitImportKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteKeyFromPartition operation middleware
-func (sh *strictHandler) DeleteKeyFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string) {
+func (sh *strictHandler) DeleteKeyFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params DeleteKeyFromPartitionParams) {
 	var request DeleteKeyFromPartitionRequestObject
 
 	request.PartitionID = partitionID
 	request.Key = key
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteKeyFromPartition(ctx, request.(DeleteKeyFromPartitionRequestObject))
@@ -2166,11 +5120,12 @@ func (sh *strictHandler) DeleteKeyFromPartition(w http.ResponseWriter, r *http.R
 }
 
 // GetValueFromPartition operation middleware
-func (sh *strictHandler) GetValueFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string) {
+func (sh *strictHandler) GetValueFromPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params GetValueFromPartitionParams) {
 	var request GetValueFromPartitionRequestObject
 
 	request.PartitionID = partitionID
 	request.Key = key
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetValueFromPartition(ctx, request.(GetValueFromPartitionRequestObject))
@@ -2193,11 +5148,12 @@ func (sh *strictHandler) GetValueFromPartition(w http.ResponseWriter, r *http.Re
 }
 
 // SetValueInPartition operation middleware
-func (sh *strictHandler) SetValueInPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string) {
+func (sh *strictHandler) SetValueInPartition(w http.ResponseWriter, r *http.Request, partitionID string, key string, params SetValueInPartitionParams) {
 	var request SetValueInPartitionRequestObject
 
 	request.PartitionID = partitionID
 	request.Key = key
+	request.Params = params
 
 	var body SetValueInPartitionJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -2226,6 +5182,100 @@ func (sh *strictHandler) SetValueInPartition(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// StartMigration operation middleware
+func (sh *strictHandler) StartMigration(w http.ResponseWriter, r *http.Request, partitionID string) {
+	var request StartMigrationRequestObject
+
+	request.PartitionID = partitionID
+
+	var body StartMigrationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StartMigration(ctx, request.(StartMigrationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StartMigration")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StartMigrationResponseObject); ok {
+		if err := validResponse.VisitStartMigrationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMigrationStatus operation middleware
+func (sh *strictHandler) GetMigrationStatus(w http.ResponseWriter, r *http.Request, partitionID string, migrationID openapi_types.UUID) {
+	var request GetMigrationStatusRequestObject
+
+	request.PartitionID = partitionID
+	request.MigrationID = migrationID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMigrationStatus(ctx, request.(GetMigrationStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMigrationStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMigrationStatusResponseObject); ok {
+		if err := validResponse.VisitGetMigrationStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CommitMigration operation middleware
+func (sh *strictHandler) CommitMigration(w http.ResponseWriter, r *http.Request, partitionID string, migrationID openapi_types.UUID) {
+	var request CommitMigrationRequestObject
+
+	request.PartitionID = partitionID
+	request.MigrationID = migrationID
+
+	var body CommitMigrationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CommitMigration(ctx, request.(CommitMigrationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CommitMigration")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CommitMigrationResponseObject); ok {
+		if err := validResponse.VisitCommitMigrationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetOperationsAfter operation middleware
 func (sh *strictHandler) GetOperationsAfter(w http.ResponseWriter, r *http.Request, partitionID string, lastOperationID int64) {
 	var request GetOperationsAfterRequestObject
@@ -2279,3 +5329,29 @@ func (sh *strictHandler) GetOperation(w http.ResponseWriter, r *http.Request, pa
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// GetSnapshot operation middleware
+func (sh *strictHandler) GetSnapshot(w http.ResponseWriter, r *http.Request, partitionID string) {
+	var request GetSnapshotRequestObject
+
+	request.PartitionID = partitionID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSnapshot(ctx, request.(GetSnapshotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSnapshot")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSnapshotResponseObject); ok {
+		if err := validResponse.VisitGetSnapshotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
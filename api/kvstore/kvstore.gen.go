@@ -24,6 +24,27 @@ type Pong struct {
 	Ping string `json:"ping"`
 }
 
+// DeleteKeyParams defines parameters for DeleteKey.
+type DeleteKeyParams struct {
+	// W Write concern of the request, defaults to the load balancer configuration
+	W *externalRef0.WriteConcern `form:"w,omitempty" json:"w,omitempty"`
+}
+
+// GetValueParams defines parameters for GetValue.
+type GetValueParams struct {
+	// Consistency Read consistency of the request, defaults to the load balancer configuration
+	Consistency *externalRef0.ReadConsistency `form:"consistency,omitempty" json:"consistency,omitempty"`
+
+	// Session Session token returned by a previous set or delete, the read observes that write when the key is in the same partition
+	Session *string `form:"session,omitempty" json:"session,omitempty"`
+}
+
+// SetValueParams defines parameters for SetValue.
+type SetValueParams struct {
+	// W Write concern of the request, defaults to the load balancer configuration
+	W *externalRef0.WriteConcern `form:"w,omitempty" json:"w,omitempty"`
+}
+
 // SetValueJSONRequestBody defines body for SetValue for application/json ContentType.
 type SetValueJSONRequestBody = externalRef0.SetValueRequest
 
@@ -101,22 +122,22 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 // The interface specification for the client above.
 type ClientInterface interface {
 	// DeleteKey request
-	DeleteKey(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	DeleteKey(ctx context.Context, key string, params *DeleteKeyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetValue request
-	GetValue(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetValue(ctx context.Context, key string, params *GetValueParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SetValueWithBody request with any body
-	SetValueWithBody(ctx context.Context, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	SetValueWithBody(ctx context.Context, key string, params *SetValueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	SetValue(ctx context.Context, key string, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	SetValue(ctx context.Context, key string, params *SetValueParams, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PingServer request
 	PingServer(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) DeleteKey(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteKeyRequest(c.Server, key)
+func (c *Client) DeleteKey(ctx context.Context, key string, params *DeleteKeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteKeyRequest(c.Server, key, params)
 	if err != nil {
 		return nil, err
 	}
@@ -127,8 +148,8 @@ func (c *Client) DeleteKey(ctx context.Context, key string, reqEditors ...Reques
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetValue(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetValueRequest(c.Server, key)
+func (c *Client) GetValue(ctx context.Context, key string, params *GetValueParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetValueRequest(c.Server, key, params)
 	if err != nil {
 		return nil, err
 	}
@@ -139,8 +160,8 @@ func (c *Client) GetValue(ctx context.Context, key string, reqEditors ...Request
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetValueWithBody(ctx context.Context, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetValueRequestWithBody(c.Server, key, contentType, body)
+func (c *Client) SetValueWithBody(ctx context.Context, key string, params *SetValueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetValueRequestWithBody(c.Server, key, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -151,8 +172,8 @@ func (c *Client) SetValueWithBody(ctx context.Context, key string, contentType s
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetValue(ctx context.Context, key string, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetValueRequest(c.Server, key, body)
+func (c *Client) SetValue(ctx context.Context, key string, params *SetValueParams, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetValueRequest(c.Server, key, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +197,7 @@ func (c *Client) PingServer(ctx context.Context, reqEditors ...RequestEditorFn)
 }
 
 // NewDeleteKeyRequest generates requests for DeleteKey
-func NewDeleteKeyRequest(server string, key string) (*http.Request, error) {
+func NewDeleteKeyRequest(server string, key string, params *DeleteKeyParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -201,6 +222,28 @@ func NewDeleteKeyRequest(server string, key string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.W != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "w", runtime.ParamLocationQuery, *params.W); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -210,7 +253,7 @@ func NewDeleteKeyRequest(server string, key string) (*http.Request, error) {
 }
 
 // NewGetValueRequest generates requests for GetValue
-func NewGetValueRequest(server string, key string) (*http.Request, error) {
+func NewGetValueRequest(server string, key string, params *GetValueParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -235,6 +278,44 @@ func NewGetValueRequest(server string, key string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Consistency != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consistency", runtime.ParamLocationQuery, *params.Consistency); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Session != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "session", runtime.ParamLocationQuery, *params.Session); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -244,18 +325,18 @@ func NewGetValueRequest(server string, key string) (*http.Request, error) {
 }
 
 // NewSetValueRequest calls the generic SetValue builder with application/json body
-func NewSetValueRequest(server string, key string, body SetValueJSONRequestBody) (*http.Request, error) {
+func NewSetValueRequest(server string, key string, params *SetValueParams, body SetValueJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewSetValueRequestWithBody(server, key, "application/json", bodyReader)
+	return NewSetValueRequestWithBody(server, key, params, "application/json", bodyReader)
 }
 
 // NewSetValueRequestWithBody generates requests for SetValue with any type of body
-func NewSetValueRequestWithBody(server string, key string, contentType string, body io.Reader) (*http.Request, error) {
+func NewSetValueRequestWithBody(server string, key string, params *SetValueParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -280,6 +361,28 @@ func NewSetValueRequestWithBody(server string, key string, contentType string, b
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.W != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "w", runtime.ParamLocationQuery, *params.W); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
@@ -361,15 +464,15 @@ func WithBaseURL(baseURL string) ClientOption {
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// DeleteKeyWithResponse request
-	DeleteKeyWithResponse(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*DeleteKeyResponse, error)
+	DeleteKeyWithResponse(ctx context.Context, key string, params *DeleteKeyParams, reqEditors ...RequestEditorFn) (*DeleteKeyResponse, error)
 
 	// GetValueWithResponse request
-	GetValueWithResponse(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*GetValueResponse, error)
+	GetValueWithResponse(ctx context.Context, key string, params *GetValueParams, reqEditors ...RequestEditorFn) (*GetValueResponse, error)
 
 	// SetValueWithBodyWithResponse request with any body
-	SetValueWithBodyWithResponse(ctx context.Context, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueResponse, error)
+	SetValueWithBodyWithResponse(ctx context.Context, key string, params *SetValueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueResponse, error)
 
-	SetValueWithResponse(ctx context.Context, key string, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueResponse, error)
+	SetValueWithResponse(ctx context.Context, key string, params *SetValueParams, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueResponse, error)
 
 	// PingServerWithResponse request
 	PingServerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PingServerResponse, error)
@@ -471,8 +574,8 @@ func (r PingServerResponse) StatusCode() int {
 }
 
 // DeleteKeyWithResponse request returning *DeleteKeyResponse
-func (c *ClientWithResponses) DeleteKeyWithResponse(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*DeleteKeyResponse, error) {
-	rsp, err := c.DeleteKey(ctx, key, reqEditors...)
+func (c *ClientWithResponses) DeleteKeyWithResponse(ctx context.Context, key string, params *DeleteKeyParams, reqEditors ...RequestEditorFn) (*DeleteKeyResponse, error) {
+	rsp, err := c.DeleteKey(ctx, key, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -480,8 +583,8 @@ func (c *ClientWithResponses) DeleteKeyWithResponse(ctx context.Context, key str
 }
 
 // GetValueWithResponse request returning *GetValueResponse
-func (c *ClientWithResponses) GetValueWithResponse(ctx context.Context, key string, reqEditors ...RequestEditorFn) (*GetValueResponse, error) {
-	rsp, err := c.GetValue(ctx, key, reqEditors...)
+func (c *ClientWithResponses) GetValueWithResponse(ctx context.Context, key string, params *GetValueParams, reqEditors ...RequestEditorFn) (*GetValueResponse, error) {
+	rsp, err := c.GetValue(ctx, key, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -489,16 +592,16 @@ func (c *ClientWithResponses) GetValueWithResponse(ctx context.Context, key stri
 }
 
 // SetValueWithBodyWithResponse request with arbitrary body returning *SetValueResponse
-func (c *ClientWithResponses) SetValueWithBodyWithResponse(ctx context.Context, key string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueResponse, error) {
-	rsp, err := c.SetValueWithBody(ctx, key, contentType, body, reqEditors...)
+func (c *ClientWithResponses) SetValueWithBodyWithResponse(ctx context.Context, key string, params *SetValueParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetValueResponse, error) {
+	rsp, err := c.SetValueWithBody(ctx, key, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseSetValueResponse(rsp)
 }
 
-func (c *ClientWithResponses) SetValueWithResponse(ctx context.Context, key string, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueResponse, error) {
-	rsp, err := c.SetValue(ctx, key, body, reqEditors...)
+func (c *ClientWithResponses) SetValueWithResponse(ctx context.Context, key string, params *SetValueParams, body SetValueJSONRequestBody, reqEditors ...RequestEditorFn) (*SetValueResponse, error) {
+	rsp, err := c.SetValue(ctx, key, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -671,13 +774,13 @@ func ParsePingServerResponse(rsp *http.Response) (*PingServerResponse, error) {
 type ServerInterface interface {
 	// Delete a key-value pair
 	// (DELETE /kv/{key})
-	DeleteKey(w http.ResponseWriter, r *http.Request, key string)
+	DeleteKey(w http.ResponseWriter, r *http.Request, key string, params DeleteKeyParams)
 	// Get a value by key
 	// (GET /kv/{key})
-	GetValue(w http.ResponseWriter, r *http.Request, key string)
+	GetValue(w http.ResponseWriter, r *http.Request, key string, params GetValueParams)
 	// Set a key-value pair
 	// (PUT /kv/{key})
-	SetValue(w http.ResponseWriter, r *http.Request, key string)
+	SetValue(w http.ResponseWriter, r *http.Request, key string, params SetValueParams)
 	// Health check endpoint
 	// (GET /ping)
 	PingServer(w http.ResponseWriter, r *http.Request)
@@ -689,19 +792,19 @@ type Unimplemented struct{}
 
 // Delete a key-value pair
 // (DELETE /kv/{key})
-func (_ Unimplemented) DeleteKey(w http.ResponseWriter, r *http.Request, key string) {
+func (_ Unimplemented) DeleteKey(w http.ResponseWriter, r *http.Request, key string, params DeleteKeyParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Get a value by key
 // (GET /kv/{key})
-func (_ Unimplemented) GetValue(w http.ResponseWriter, r *http.Request, key string) {
+func (_ Unimplemented) GetValue(w http.ResponseWriter, r *http.Request, key string, params GetValueParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Set a key-value pair
 // (PUT /kv/{key})
-func (_ Unimplemented) SetValue(w http.ResponseWriter, r *http.Request, key string) {
+func (_ Unimplemented) SetValue(w http.ResponseWriter, r *http.Request, key string, params SetValueParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -734,8 +837,19 @@ func (siw *ServerInterfaceWrapper) DeleteKey(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteKeyParams
+
+	// ------------- Optional query parameter "w" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "w", r.URL.Query(), &params.W)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "w", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteKey(w, r, key)
+		siw.Handler.DeleteKey(w, r, key, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -759,8 +873,27 @@ func (siw *ServerInterfaceWrapper) GetValue(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetValueParams
+
+	// ------------- Optional query parameter "consistency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "consistency", r.URL.Query(), &params.Consistency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "consistency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "session" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "session", r.URL.Query(), &params.Session)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetValue(w, r, key)
+		siw.Handler.GetValue(w, r, key, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -784,8 +917,19 @@ func (siw *ServerInterfaceWrapper) SetValue(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetValueParams
+
+	// ------------- Optional query parameter "w" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "w", r.URL.Query(), &params.W)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "w", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SetValue(w, r, key)
+		siw.Handler.SetValue(w, r, key, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -939,7 +1083,8 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 }
 
 type DeleteKeyRequestObject struct {
-	Key string `json:"key"`
+	Key    string `json:"key"`
+	Params DeleteKeyParams
 }
 
 type DeleteKeyResponseObject interface {
@@ -977,7 +1122,8 @@ func (response DeleteKeydefaultJSONResponse) VisitDeleteKeyResponse(w http.Respo
 }
 
 type GetValueRequestObject struct {
-	Key string `json:"key"`
+	Key    string `json:"key"`
+	Params GetValueParams
 }
 
 type GetValueResponseObject interface {
@@ -1015,8 +1161,9 @@ func (response GetValuedefaultJSONResponse) VisitGetValueResponse(w http.Respons
 }
 
 type SetValueRequestObject struct {
-	Key  string `json:"key"`
-	Body *SetValueJSONRequestBody
+	Key    string `json:"key"`
+	Params SetValueParams
+	Body   *SetValueJSONRequestBody
 }
 
 type SetValueResponseObject interface {
@@ -1127,10 +1274,11 @@ type strictHandler struct {
 }
 
 // DeleteKey operation middleware
-func (sh *strictHandler) DeleteKey(w http.ResponseWriter, r *http.Request, key string) {
+func (sh *strictHandler) DeleteKey(w http.ResponseWriter, r *http.Request, key string, params DeleteKeyParams) {
 	var request DeleteKeyRequestObject
 
 	request.Key = key
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteKey(ctx, request.(DeleteKeyRequestObject))
@@ -1153,10 +1301,11 @@ func (sh *strictHandler) DeleteKey(w http.ResponseWriter, r *http.Request, key s
 }
 
 // GetValue operation middleware
-func (sh *strictHandler) GetValue(w http.ResponseWriter, r *http.Request, key string) {
+func (sh *strictHandler) GetValue(w http.ResponseWriter, r *http.Request, key string, params GetValueParams) {
 	var request GetValueRequestObject
 
 	request.Key = key
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetValue(ctx, request.(GetValueRequestObject))
@@ -1179,10 +1328,11 @@ func (sh *strictHandler) GetValue(w http.ResponseWriter, r *http.Request, key st
 }
 
 // SetValue operation middleware
-func (sh *strictHandler) SetValue(w http.ResponseWriter, r *http.Request, key string) {
+func (sh *strictHandler) SetValue(w http.ResponseWriter, r *http.Request, key string, params SetValueParams) {
 	var request SetValueRequestObject
 
 	request.Key = key
+	request.Params = params
 
 	var body SetValueJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -18,11 +18,8 @@ import (
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
-// StateResponse defines model for StateResponse.
-type StateResponse struct {
-	// Message Optional message providing additional information
-	Message *string `json:"message,omitempty"`
-}
+// PatchStateJSONRequestBody defines body for PatchState for application/json ContentType.
+type PatchStateJSONRequestBody = externalRef0.StatePatch
 
 // SetStateJSONRequestBody defines body for SetState for application/json ContentType.
 type SetStateJSONRequestBody = externalRef0.State
@@ -100,12 +97,41 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// PatchStateWithBody request with any body
+	PatchStateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchState(ctx context.Context, body PatchStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// SetStateWithBody request with any body
 	SetStateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	SetState(ctx context.Context, body SetStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) PatchStateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchStateRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchState(ctx context.Context, body PatchStateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchStateRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) SetStateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSetStateRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -130,6 +156,46 @@ func (c *Client) SetState(ctx context.Context, body SetStateJSONRequestBody, req
 	return c.Client.Do(req)
 }
 
+// NewPatchStateRequest calls the generic PatchState builder with application/json body
+func NewPatchStateRequest(server string, body PatchStateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchStateRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPatchStateRequestWithBody generates requests for PatchState with any type of body
+func NewPatchStateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/state")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewSetStateRequest calls the generic SetState builder with application/json body
 func NewSetStateRequest(server string, body SetStateJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -213,16 +279,44 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// PatchStateWithBodyWithResponse request with any body
+	PatchStateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchStateResponse, error)
+
+	PatchStateWithResponse(ctx context.Context, body PatchStateJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchStateResponse, error)
+
 	// SetStateWithBodyWithResponse request with any body
 	SetStateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetStateResponse, error)
 
 	SetStateWithResponse(ctx context.Context, body SetStateJSONRequestBody, reqEditors ...RequestEditorFn) (*SetStateResponse, error)
 }
 
+type PatchStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.StateAck
+	JSON409      *externalRef0.ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SetStateResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *StateResponse
+	JSON200      *externalRef0.StateAck
 }
 
 // Status returns HTTPResponse.Status
@@ -241,6 +335,23 @@ func (r SetStateResponse) StatusCode() int {
 	return 0
 }
 
+// PatchStateWithBodyWithResponse request with arbitrary body returning *PatchStateResponse
+func (c *ClientWithResponses) PatchStateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchStateResponse, error) {
+	rsp, err := c.PatchStateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchStateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchStateWithResponse(ctx context.Context, body PatchStateJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchStateResponse, error) {
+	rsp, err := c.PatchState(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchStateResponse(rsp)
+}
+
 // SetStateWithBodyWithResponse request with arbitrary body returning *SetStateResponse
 func (c *ClientWithResponses) SetStateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetStateResponse, error) {
 	rsp, err := c.SetStateWithBody(ctx, contentType, body, reqEditors...)
@@ -258,6 +369,39 @@ func (c *ClientWithResponses) SetStateWithResponse(ctx context.Context, body Set
 	return ParseSetStateResponse(rsp)
 }
 
+// ParsePatchStateResponse parses an HTTP response from a PatchStateWithResponse call
+func ParsePatchStateResponse(rsp *http.Response) (*PatchStateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchStateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.StateAck
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest externalRef0.ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSetStateResponse parses an HTTP response from a SetStateWithResponse call
 func ParseSetStateResponse(rsp *http.Response) (*SetStateResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -273,7 +417,7 @@ func ParseSetStateResponse(rsp *http.Response) (*SetStateResponse, error) {
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest StateResponse
+		var dest externalRef0.StateAck
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -286,6 +430,9 @@ func ParseSetStateResponse(rsp *http.Response) (*SetStateResponse, error) {
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Applies the changes of the state since the version the loadbalancer acknowledged last
+	// (PATCH /state)
+	PatchState(w http.ResponseWriter, r *http.Request)
 	// Sets the loadbalancer state
 	// (PUT /state)
 	SetState(w http.ResponseWriter, r *http.Request)
@@ -295,6 +442,12 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// Applies the changes of the state since the version the loadbalancer acknowledged last
+// (PATCH /state)
+func (_ Unimplemented) PatchState(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Sets the loadbalancer state
 // (PUT /state)
 func (_ Unimplemented) SetState(w http.ResponseWriter, r *http.Request) {
@@ -310,6 +463,20 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// PatchState operation middleware
+func (siw *ServerInterfaceWrapper) PatchState(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchState(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // SetState operation middleware
 func (siw *ServerInterfaceWrapper) SetState(w http.ResponseWriter, r *http.Request) {
 
@@ -437,6 +604,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/state", wrapper.PatchState)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/state", wrapper.SetState)
 	})
@@ -444,6 +614,32 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	return r
 }
 
+type PatchStateRequestObject struct {
+	Body *PatchStateJSONRequestBody
+}
+
+type PatchStateResponseObject interface {
+	VisitPatchStateResponse(w http.ResponseWriter) error
+}
+
+type PatchState200JSONResponse externalRef0.StateAck
+
+func (response PatchState200JSONResponse) VisitPatchStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchState409JSONResponse externalRef0.ErrorResponse
+
+func (response PatchState409JSONResponse) VisitPatchStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type SetStateRequestObject struct {
 	Body *SetStateJSONRequestBody
 }
@@ -452,7 +648,7 @@ type SetStateResponseObject interface {
 	VisitSetStateResponse(w http.ResponseWriter) error
 }
 
-type SetState200JSONResponse StateResponse
+type SetState200JSONResponse externalRef0.StateAck
 
 func (response SetState200JSONResponse) VisitSetStateResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -463,6 +659,9 @@ func (response SetState200JSONResponse) VisitSetStateResponse(w http.ResponseWri
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Applies the changes of the state since the version the loadbalancer acknowledged last
+	// (PATCH /state)
+	PatchState(ctx context.Context, request PatchStateRequestObject) (PatchStateResponseObject, error)
 	// Sets the loadbalancer state
 	// (PUT /state)
 	SetState(ctx context.Context, request SetStateRequestObject) (SetStateResponseObject, error)
@@ -497,6 +696,37 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// PatchState operation middleware
+func (sh *strictHandler) PatchState(w http.ResponseWriter, r *http.Request) {
+	var request PatchStateRequestObject
+
+	var body PatchStateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchState(ctx, request.(PatchStateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchState")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchStateResponseObject); ok {
+		if err := validResponse.VisitPatchStateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // SetState operation middleware
 func (sh *strictHandler) SetState(w http.ResponseWriter, r *http.Request) {
 	var request SetStateRequestObject
@@ -3,13 +3,18 @@ package client
 import (
 	"fmt"
 
-	"github.com/computer-technology-team/distributed-kvstore/config"
 	"github.com/spf13/cobra"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/kvstore"
+	"github.com/computer-technology-team/distributed-kvstore/config"
 )
 
 // NewDeleteCmd creates a new delete command
 func NewDeleteCmd() *cobra.Command {
-	return &cobra.Command{
+	var writeConcern string
+
+	cmd := &cobra.Command{
 		Use:   "delete [key]",
 		Short: "Delete a key-value pair",
 		Args:  cobra.ExactArgs(1),
@@ -28,7 +33,16 @@ func NewDeleteCmd() *cobra.Command {
 				return err
 			}
 
-			resp, err := client.DeleteKeyWithResponse(ctx, key)
+			params := &kvstore.DeleteKeyParams{}
+			if writeConcern != "" {
+				w, err := common.ParseWriteConcern(writeConcern)
+				if err != nil {
+					return err
+				}
+				params.W = &w
+			}
+
+			resp, err := client.DeleteKeyWithResponse(ctx, key, params)
 
 			if err != nil {
 				return fmt.Errorf("failed to delete key: %w", err)
@@ -40,11 +54,25 @@ func NewDeleteCmd() *cobra.Command {
 			}
 
 			if resp.StatusCode() != 200 {
+				if resp.JSONDefault != nil {
+					if resp.JSONDefault.Message != "" {
+						return fmt.Errorf("error deleting key: %s: %s", resp.JSONDefault.Error, resp.JSONDefault.Message)
+					}
+					return fmt.Errorf("error deleting key: %s", resp.JSONDefault.Error)
+				}
 				return fmt.Errorf("unexpected status code: %d", resp.StatusCode())
 			}
 
 			fmt.Printf("Key '%s' deleted\n", key)
+			if resp.JSON200 != nil && resp.JSON200.SessionToken != nil {
+				fmt.Printf("Session token: %s\n", *resp.JSON200.SessionToken)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVarP(&writeConcern, "write-concern", "w", "",
+		"Write concern of the request (1, majority, all), defaults to the load balancer's")
+
+	return cmd
 }
@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 
+	"github.com/computer-technology-team/distributed-kvstore/api/kvstore"
 	"github.com/computer-technology-team/distributed-kvstore/config"
 	"github.com/spf13/cobra"
 )
@@ -27,7 +28,7 @@ func NewExistsCmd() *cobra.Command {
 				return err
 			}
 
-			resp, err := client.GetValueWithResponse(ctx, key)
+			resp, err := client.GetValueWithResponse(ctx, key, &kvstore.GetValueParams{})
 
 			if err != nil {
 				return fmt.Errorf("failed to check key: %w", err)
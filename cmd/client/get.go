@@ -3,13 +3,17 @@ package client
 import (
 	"fmt"
 
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/kvstore"
 	"github.com/computer-technology-team/distributed-kvstore/config"
 	"github.com/spf13/cobra"
 )
 
 // NewGetCmd creates a new get command
 func NewGetCmd() *cobra.Command {
-	return &cobra.Command{
+	var consistency, session string
+
+	cmd := &cobra.Command{
 		Use:   "get [key]",
 		Short: "Get the value of a key",
 		Args:  cobra.ExactArgs(1),
@@ -27,7 +31,19 @@ func NewGetCmd() *cobra.Command {
 				return err
 			}
 
-			resp, err := client.GetValueWithResponse(ctx, key)
+			params := &kvstore.GetValueParams{}
+			if consistency != "" {
+				c, err := common.ParseReadConsistency(consistency)
+				if err != nil {
+					return err
+				}
+				params.Consistency = &c
+			}
+			if session != "" {
+				params.Session = &session
+			}
+
+			resp, err := client.GetValueWithResponse(ctx, key, params)
 
 			if err != nil {
 				return fmt.Errorf("failed to get key: %w", err)
@@ -49,4 +65,11 @@ func NewGetCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVarP(&consistency, "consistency", "c", "",
+		"Read consistency of the request (strong, bounded, any), defaults to the load balancer's")
+	cmd.Flags().StringVarP(&session, "session", "s", "",
+		"Session token of a previous set or delete the read must observe")
+
+	return cmd
 }
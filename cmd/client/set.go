@@ -5,13 +5,16 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/computer-technology-team/distributed-kvstore/api/kvstore"
 	"github.com/computer-technology-team/distributed-kvstore/config"
 )
 
 // NewSetCmd creates a new set command
 func NewSetCmd() *cobra.Command {
-	return &cobra.Command{
+	var writeConcern string
+
+	cmd := &cobra.Command{
 		Use:   "set [key] [value]",
 		Short: "Set a key-value pair",
 		Args:  cobra.ExactArgs(2),
@@ -29,7 +32,16 @@ func NewSetCmd() *cobra.Command {
 				return err
 			}
 
-			resp, err := client.SetValueWithResponse(ctx, key, kvstore.SetValueJSONRequestBody{
+			params := &kvstore.SetValueParams{}
+			if writeConcern != "" {
+				w, err := common.ParseWriteConcern(writeConcern)
+				if err != nil {
+					return err
+				}
+				params.W = &w
+			}
+
+			resp, err := client.SetValueWithResponse(ctx, key, params, kvstore.SetValueJSONRequestBody{
 				Value: value,
 			})
 
@@ -42,13 +54,24 @@ func NewSetCmd() *cobra.Command {
 					return fmt.Errorf("error setting key: %s", resp.JSON400.Error)
 				}
 				if resp.JSONDefault != nil {
+					if resp.JSONDefault.Message != "" {
+						return fmt.Errorf("error setting key: %s: %s", resp.JSONDefault.Error, resp.JSONDefault.Message)
+					}
 					return fmt.Errorf("error setting key: %s", resp.JSONDefault.Error)
 				}
 				return fmt.Errorf("unexpected status code: %d", resp.StatusCode())
 			}
 
 			fmt.Printf("Key '%s' set to value '%s'\n", key, value)
+			if resp.JSON200 != nil && resp.JSON200.SessionToken != nil {
+				fmt.Printf("Session token: %s\n", *resp.JSON200.SessionToken)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVarP(&writeConcern, "write-concern", "w", "",
+		"Write concern of the request (1, majority, all), defaults to the load balancer's")
+
+	return cmd
 }
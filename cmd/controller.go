@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -19,8 +20,8 @@ import (
 	"github.com/computer-technology-team/distributed-kvstore/internal/controller"
 	"github.com/computer-technology-team/distributed-kvstore/internal/health"
 
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	controllerAPI "github.com/computer-technology-team/distributed-kvstore/api/controller"
-	"github.com/computer-technology-team/distributed-kvstore/api/loadbalancer"
 )
 
 func NewControllerCmd() *cobra.Command {
@@ -33,13 +34,47 @@ func NewControllerCmd() *cobra.Command {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
-			balancerClient, err := loadbalancer.NewClientWithResponses(cfg.Controller.LoadBalancerURL)
+			replicationMode, err := common.ParseReplicationMode(cfg.Controller.ReplicationMode)
 			if err != nil {
-				return fmt.Errorf("failed to create balancer client: %w", err)
+				return fmt.Errorf("invalid controller config: %w", err)
+			}
+
+			placementPolicy, err := controller.ParsePlacementPolicy(cfg.Controller.PlacementPolicy)
+			if err != nil {
+				return fmt.Errorf("invalid controller config: %w", err)
+			}
+
+			failureDetector, err := controller.NewFailureDetector(cfg.Controller.FailureDetector,
+				cfg.Controller.FailureThreshold, cfg.Controller.PhiThreshold, cfg.Controller.HealthCheckDuration)
+			if err != nil {
+				return fmt.Errorf("invalid controller config: %w", err)
+			}
+
+			var stateStore controller.StateStore
+			if cfg.Controller.StateFile != "" {
+				stateStore = controller.NewFileStateStore(cfg.Controller.StateFile)
 			}
 
 			ctrl := controller.NewController(cfg.Controller.VirtualNodeCount, cfg.Controller.HealthCheckDuration,
-				cfg.Controller.HealthCheckTimeout, balancerClient)
+				cfg.Controller.HealthCheckTimeout, cfg.Controller.DeadNodeTimeout, placementPolicy, replicationMode, failureDetector, stateStore)
+
+			if err := ctrl.RecoverState(); err != nil {
+				return fmt.Errorf("failed to recover controller state: %w", err)
+			}
+
+			if peers := splitAddresses(cfg.Controller.Peers); len(peers) > 0 {
+				err := ctrl.StartHA(controller.HAOptions{
+					Address:           cfg.Controller.AdvertiseAddress,
+					Peers:             peers,
+					Dir:               cfg.Controller.RaftDir,
+					HeartbeatInterval: cfg.Controller.RaftHeartbeatInterval,
+					ElectionTimeout:   cfg.Controller.RaftElectionTimeout,
+					SnapshotThreshold: cfg.Controller.RaftSnapshotThreshold,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to start controller raft node: %w", err)
+				}
+			}
 
 			controllerAddr := fmt.Sprintf("%s:%d", cfg.Controller.Host, cfg.Controller.Port)
 			controllerListener, err := net.Listen("tcp", controllerAddr)
@@ -130,3 +165,15 @@ func NewControllerCmd() *cobra.Command {
 		},
 	}
 }
+
+// splitAddresses parses a comma separated list of addresses, ignoring empty entries
+func splitAddresses(list string) []string {
+	var addresses []string
+	for _, address := range strings.Split(list, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
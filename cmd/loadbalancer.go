@@ -14,6 +14,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/computer-technology-team/distributed-kvstore/api/controller"
 	apiKVStore "github.com/computer-technology-team/distributed-kvstore/api/kvstore"
 	apiLoadBalancer "github.com/computer-technology-team/distributed-kvstore/api/loadbalancer"
@@ -36,12 +37,29 @@ func NewServeLoadBalancerCmd() *cobra.Command {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
+			writeConcern, err := common.ParseWriteConcern(cfg.LoadBalancer.WriteConcern)
+			if err != nil {
+				return fmt.Errorf("invalid load balancer config: %w", err)
+			}
+
+			readConsistency, err := common.ParseReadConsistency(cfg.LoadBalancer.ReadConsistency)
+			if err != nil {
+				return fmt.Errorf("invalid load balancer config: %w", err)
+			}
+
 			client, err := controller.NewClientWithResponses(cfg.LoadBalancer.ControllerURL)
 			if err != nil {
 				return fmt.Errorf("failed to create controller client: %w", err)
 			}
 
-			server, err := loadbalancer.NewServer(ctx, client)
+			server, err := loadbalancer.NewServer(ctx, client, loadbalancer.Options{
+				WriteConcern:       writeConcern,
+				ReadConsistency:    readConsistency,
+				MaxStalenessOps:    cfg.LoadBalancer.MaxStalenessOps,
+				MaxStaleness:       cfg.LoadBalancer.MaxStaleness,
+				StatusPollInterval: cfg.LoadBalancer.StatusPollInterval,
+				StateWatchTimeout:  cfg.LoadBalancer.StateWatchTimeout,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to create server: %w", err)
 			}
@@ -64,6 +82,24 @@ func NewServeLoadBalancerCmd() *cobra.Command {
 				return fmt.Errorf("failed to create private listener: %w", err)
 			}
 
+			advertiseAddr := cfg.LoadBalancer.AdvertiseAddress
+			if advertiseAddr == "" {
+				advertiseAddr = privateAddr
+			}
+
+			resp, err := client.PostBalancersRegisterWithResponse(ctx, controller.BalancerRegistration{
+				Address: advertiseAddr,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to register load balancer: %w", err)
+			}
+
+			if resp.JSON201 == nil {
+				return fmt.Errorf("failed to register load balancer: unexpected status code %d", resp.StatusCode())
+			}
+
+			slog.Info("registered with controller", "id", resp.JSON201.Id.String(), "address", advertiseAddr)
+
 			// Create mux for public server to handle both API and health check endpoints
 			publicMux := http.NewServeMux()
 			publicMux.Handle("/", apiKVStore.Handler(apiKVStore.NewStrictHandler(server, nil)))
@@ -1,18 +1,30 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/computer-technology-team/distributed-kvstore/api/controller"
 	"github.com/computer-technology-team/distributed-kvstore/api/database"
 	"github.com/computer-technology-team/distributed-kvstore/config"
 	"github.com/computer-technology-team/distributed-kvstore/internal/health"
+	"github.com/computer-technology-team/distributed-kvstore/internal/heartbeat"
+	"github.com/computer-technology-team/distributed-kvstore/internal/kvstore"
 	"github.com/computer-technology-team/distributed-kvstore/internal/node"
+	"github.com/computer-technology-team/distributed-kvstore/internal/statewatch"
 )
 
 func NewServeNodeCmd() *cobra.Command {
@@ -28,6 +40,15 @@ func NewServeNodeCmd() *cobra.Command {
 			}
 			addr := fmt.Sprintf("%s:%d", cfg.Node.Host, cfg.Node.Port)
 
+			syncPolicy, err := kvstore.ParseSyncPolicy(cfg.Node.WALSyncPolicy)
+			if err != nil {
+				return fmt.Errorf("invalid node config: %w", err)
+			}
+
+			if cfg.Node.Weight <= 0 {
+				return fmt.Errorf("invalid node config: weight must be greater than 0")
+			}
+
 			listener, err := net.Listen("tcp", addr)
 			if err != nil {
 				return err
@@ -38,14 +59,50 @@ func NewServeNodeCmd() *cobra.Command {
 				return fmt.Errorf("fail to create controller client: %w", err)
 			}
 
-			resp, err := client.PostNodesRegisterWithResponse(ctx, controller.NodeRegistration{Address: addr})
+			labels := common.NodeLabels{
+				Zone: lo.EmptyableToPtr(cfg.Node.Labels.Zone),
+				Rack: lo.EmptyableToPtr(cfg.Node.Labels.Rack),
+				Host: lo.EmptyableToPtr(cfg.Node.Labels.Host),
+			}
+			if labels.Host == nil {
+				if hostname, err := os.Hostname(); err == nil {
+					labels.Host = &hostname
+				}
+			}
+
+			resp, err := client.PostNodesRegisterWithResponse(ctx, controller.NodeRegistration{
+				Address: addr,
+				Labels:  &labels,
+				Weight:  &cfg.Node.Weight,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to regsiter node: %w", err)
 			}
 
+			if resp.JSON201 == nil {
+				return fmt.Errorf("failed to register node: unexpected status code %d", resp.StatusCode())
+			}
+
 			id := resp.JSON201.Id
 
-			server := node.NewServer(id)
+			server, err := node.NewServer(id, kvstore.StorageOptions{
+				DataDir:      cfg.Node.DataDir,
+				SyncPolicy:   syncPolicy,
+				SyncInterval: cfg.Node.WALSyncInterval,
+
+				SnapshotInterval:  cfg.Node.SnapshotInterval,
+				SnapshotThreshold: cfg.Node.SnapshotThreshold,
+				LogRetention:      cfg.Node.LogRetention,
+			}, kvstore.ReplicationOptions{
+				WriteConcernTimeout: cfg.Node.WriteConcernTimeout,
+				QueueSize:           cfg.Node.ReplicationQueueSize,
+
+				RaftHeartbeatInterval: cfg.Node.RaftHeartbeatInterval,
+				RaftElectionTimeout:   cfg.Node.RaftElectionTimeout,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create node server: %w", err)
+			}
 
 			// Create a mux to handle both API and health check endpoints
 			mux := http.NewServeMux()
@@ -56,10 +113,66 @@ func NewServeNodeCmd() *cobra.Command {
 			// Add health check endpoint
 			health.AddHealthCheckEndpoint(mux)
 
-			slog.Info("server started", "address", addr,
-				"id", id.String())
+			httpServer := &http.Server{
+				Handler: mux,
+			}
+
+			var wg sync.WaitGroup
+
+			watchCtx, stopWatch := context.WithCancel(ctx)
+			defer stopWatch()
+
+			if cfg.Node.StateWatchTimeout > 0 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					statewatch.Watch(watchCtx, client, server, cfg.Node.StateWatchTimeout)
+				}()
+			}
+
+			if cfg.Node.HeartbeatInterval > 0 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					heartbeat.Send(watchCtx, client, id, server, cfg.Node.HeartbeatInterval)
+				}()
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				slog.Info("server started", "address", addr,
+					"id", id.String())
+				if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error("node server error", "error", err)
+				}
+			}()
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+			<-stop
+			slog.Info("Shutting down node server...")
+
+			// Graceful shutdown
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			stopWatch()
+			if err := httpServer.Shutdown(shutdownCtx); err != nil {
+				slog.Error("Node server shutdown error", "error", err)
+			}
+
+			wg.Wait()
+
+			if err := server.Close(); err != nil {
+				slog.Error("could not close node storage", "error", err)
+			}
+
+			slog.Info("Node server gracefully stopped")
 
-			return http.Serve(listener, mux)
+			return nil
 		},
 	}
 }
@@ -41,9 +41,35 @@ type FlagConfig struct {
 
 // NodeConfig represents the configuration for a node server
 type NodeConfig struct {
-	Host          string `mapstructure:"host"`
-	Port          int    `mapstructure:"port"`
-	ControllerURL string `mapstructure:"controller_url"`
+	Host            string        `mapstructure:"host"`
+	Port            int           `mapstructure:"port"`
+	ControllerURL   string        `mapstructure:"controller_url"`
+	DataDir         string        `mapstructure:"data_dir"`
+	WALSyncPolicy   string        `mapstructure:"wal_sync_policy"`
+	WALSyncInterval time.Duration `mapstructure:"wal_sync_interval"`
+
+	SnapshotInterval  time.Duration `mapstructure:"snapshot_interval"`
+	SnapshotThreshold int64         `mapstructure:"snapshot_threshold"`
+	LogRetention      int           `mapstructure:"log_retention"`
+
+	WriteConcernTimeout  time.Duration `mapstructure:"write_concern_timeout"`
+	ReplicationQueueSize int           `mapstructure:"replication_queue_size"`
+
+	RaftHeartbeatInterval time.Duration `mapstructure:"raft_heartbeat_interval"`
+	RaftElectionTimeout   time.Duration `mapstructure:"raft_election_timeout"`
+
+	// Labels are the failure domains of the node, the host defaults to the machine's hostname
+	Labels struct {
+		Zone string `mapstructure:"zone"`
+		Rack string `mapstructure:"rack"`
+		Host string `mapstructure:"host"`
+	} `mapstructure:"labels"`
+	// Weight is the capacity of the node relative to the other nodes
+	Weight float64 `mapstructure:"weight"`
+	// StateWatchTimeout is how long a watch of the controller's state waits for a change
+	StateWatchTimeout time.Duration `mapstructure:"state_watch_timeout"`
+	// HeartbeatInterval is how often the node sends a heartbeat to the controller
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
 }
 
 // ClientConfig represents the configuration for a client
@@ -61,7 +87,17 @@ type LoadBalancerConfig struct {
 		Host string `mapstructure:"host"`
 		Port int    `mapstructure:"port"`
 	} `mapstructure:"private_server"`
-	ControllerURL string `mapstructure:"controller_url"`
+	// AdvertiseAddress is the address of the private server the controller reaches the load
+	// balancer at, the private server's host and port by default
+	AdvertiseAddress string `mapstructure:"advertise_address"`
+	ControllerURL    string `mapstructure:"controller_url"`
+	WriteConcern     string `mapstructure:"write_concern"`
+
+	ReadConsistency    string        `mapstructure:"read_consistency"`
+	MaxStalenessOps    int64         `mapstructure:"max_staleness_ops"`
+	MaxStaleness       time.Duration `mapstructure:"max_staleness"`
+	StatusPollInterval time.Duration `mapstructure:"status_poll_interval"`
+	StateWatchTimeout  time.Duration `mapstructure:"state_watch_timeout"`
 }
 
 // ControllerConfig represents the configuration for the controller
@@ -73,10 +109,23 @@ type ControllerConfig struct {
 		Host    string `mapstructure:"host"`
 		Port    int    `mapstructure:"port"`
 	} `mapstructure:"admin_ui"`
-	LoadBalancerURL     string        `mapstructure:"load_balancer_url"`
 	HealthCheckDuration time.Duration `mapstructure:"health_check_duration"`
 	HealthCheckTimeout  time.Duration `mapstructure:"health_check_timeout"`
+	DeadNodeTimeout     time.Duration `mapstructure:"dead_node_timeout"`
+	FailureDetector     string        `mapstructure:"failure_detector"`
+	FailureThreshold    int           `mapstructure:"failure_threshold"`
+	PhiThreshold        float64       `mapstructure:"phi_threshold"`
+	PlacementPolicy     string        `mapstructure:"placement_policy"`
 	VirtualNodeCount    int           `mapstructure:"virtual_node_count"`
+	ReplicationMode     string        `mapstructure:"replication_mode"`
+	StateFile           string        `mapstructure:"state_file"`
+	// AdvertiseAddress is the address the other controller instances reach this one at
+	AdvertiseAddress      string        `mapstructure:"advertise_address"`
+	Peers                 string        `mapstructure:"peers"`
+	RaftDir               string        `mapstructure:"raft_dir"`
+	RaftHeartbeatInterval time.Duration `mapstructure:"raft_heartbeat_interval"`
+	RaftElectionTimeout   time.Duration `mapstructure:"raft_election_timeout"`
+	RaftSnapshotThreshold int64         `mapstructure:"raft_snapshot_threshold"`
 }
 
 type LogLevel struct {
@@ -97,20 +146,55 @@ var ConfigFlags = []FlagConfig{
 	{"log-level", "log_level", slog.LevelInfo, "Log level (debug, info, warn, error)"},
 	{"node.host", "node.host", "localhost", "Node server host"},
 	{"node.port", "node.port", 8080, "Node server port"},
+	{"node.data_dir", "node.data_dir", "", "Directory for partition write-ahead logs (empty keeps data in memory only)"},
+	{"node.wal_sync_policy", "node.wal_sync_policy", "always", "When to fsync the write-ahead log (always, interval, never)"},
+	{"node.wal_sync_interval", "node.wal_sync_interval", time.Second, "Fsync interval when the WAL sync policy is interval"},
+	{"node.snapshot_interval", "node.snapshot_interval", time.Minute, "How often partitions are checked for snapshotting (0 disables snapshots)"},
+	{"node.snapshot_threshold", "node.snapshot_threshold", int64(10000), "Operations since the last snapshot that trigger a new one"},
+	{"node.log_retention", "node.log_retention", 1000, "Operations kept in the log behind a snapshot for lagging replicas"},
+	{"node.write_concern_timeout", "node.write_concern_timeout", 5 * time.Second, "How long a write waits for the replica acknowledgements its write concern requires"},
+	{"node.replication_queue_size", "node.replication_queue_size", 10000, "Operations queued per replica before it is caught up from the log instead"},
+	{"node.raft_heartbeat_interval", "node.raft_heartbeat_interval", 100 * time.Millisecond, "How often Raft leaders contact their followers"},
+	{"node.raft_election_timeout", "node.raft_election_timeout", time.Second, "Minimum time without a Raft leader before a follower starts an election"},
+	{"node.labels.zone", "node.labels.zone", "", "Zone the node runs in"},
+	{"node.labels.rack", "node.labels.rack", "", "Rack the node runs in"},
+	{"node.labels.host", "node.labels.host", "", "Host the node runs on (empty uses the hostname)"},
+	{"node.weight", "node.weight", 1.0, "Capacity of the node relative to the other nodes, a node of weight 2 gets twice the load"},
+	{"node.heartbeat_interval", "node.heartbeat_interval", time.Second, "How often the node sends a heartbeat with its partition statuses to the controller (0 only relies on health checks)"},
+	{"node.state_watch_timeout", "node.state_watch_timeout", 30 * time.Second, "How long a watch of the controller's state waits for a change (0 only relies on pushes)"},
 	{"client.server-url", "client.server_url", "", "KVStore server URL for client commands"},
 	{"controller.host", "controller.host", "localhost", "Controller host"},
 	{"controller.port", "controller.port", 9090, "Controller port"},
 	{"controller.admin-ui.enabled", "controller.admin_ui.enabled", true, "Enable admin UI"},
 	{"controller.admin-ui.host", "controller.admin_ui.host", "localhost", "Admin UI host"},
 	{"controller.admin-ui.port", "controller.admin_ui.port", 9091, "Admin UI port"},
-	{"controller.load_balancer_url", "controller.load_balancer_url", "http://localhost:8001", "Load Balancer URL"},
 	{"controller.health_check_duration", "controller.health_check_duration", time.Second * 5, "Health Check Duration"},
 	{"controller.health_check_timeout", "controller.health_check_timeout", time.Second * 2, "Health Check Timeout"},
+	{"controller.dead_node_timeout", "controller.dead_node_timeout", time.Minute, "Time a node stays unhealthy before its replicas are replaced"},
+	{"controller.failure_detector", "controller.failure_detector", "consecutive", "How failed health checks mark a node unhealthy (consecutive, phi)"},
+	{"controller.failure_threshold", "controller.failure_threshold", 3, "Failed health checks in a row before the consecutive failure detector marks a node unhealthy"},
+	{"controller.phi_threshold", "controller.phi_threshold", 8.0, "Suspicion level above which the phi accrual failure detector marks a node unhealthy"},
+	{"controller.placement_policy", "controller.placement_policy", "none", "Failure domain the replicas of a partition are spread over (none, host, rack, zone)"},
 	{"controller.virtual_node_count", "controller.virtual_node_count", 3, "Number of Virtual nodes for each partition"},
+	{"controller.replication_mode", "controller.replication_mode", "primary", "How partitions replicate writes (primary, raft)"},
+	{"controller.state_file", "controller.state_file", "controller-state.json", "File the cluster state is persisted to (empty keeps it in memory only)"},
+	{"controller.advertise_address", "controller.advertise_address", "localhost:9090", "Address the other controller instances reach this one at"},
+	{"controller.peers", "controller.peers", "", "Comma separated addresses of the other controller instances (empty runs a single instance)"},
+	{"controller.raft_dir", "controller.raft_dir", "controller-raft", "Directory the Raft log of the controller instances is kept in (empty keeps it in memory only)"},
+	{"controller.raft_heartbeat_interval", "controller.raft_heartbeat_interval", 100 * time.Millisecond, "Interval of the heartbeats of the leading controller instance"},
+	{"controller.raft_election_timeout", "controller.raft_election_timeout", time.Second, "Time without heartbeats before a controller instance starts an election"},
+	{"controller.raft_snapshot_threshold", "controller.raft_snapshot_threshold", int64(100), "States committed since the last Raft snapshot of the controller instances that trigger a new one"},
 	{"load-balancer.public-server.host", "load_balancer.public_server.host", "localhost", "Load balancer public server host"},
 	{"load-balancer.public-server.port", "load_balancer.public_server.port", 8000, "Load balancer public server port"},
 	{"load-balancer.private-server.host", "load_balancer.private_server.host", "localhost", "Load balancer private server host"},
 	{"load-balancer.private-server.port", "load_balancer.private_server.port", 8001, "Load balancer private server port"},
+	{"load-balancer.advertise_address", "load_balancer.advertise_address", "", "Address the controller reaches the private server at (empty uses the private server's host and port)"},
+	{"load-balancer.write_concern", "load_balancer.write_concern", "1", "Default write concern of set and delete requests (1, majority, all)"},
+	{"load-balancer.read_consistency", "load_balancer.read_consistency", "any", "Default read consistency of get requests (strong, bounded, any)"},
+	{"load-balancer.max_staleness_ops", "load_balancer.max_staleness_ops", int64(100), "Operations a replica may be behind the master to serve bounded reads"},
+	{"load-balancer.max_staleness", "load_balancer.max_staleness", time.Second, "Time a replica may be behind the master to serve bounded reads"},
+	{"load-balancer.status_poll_interval", "load_balancer.status_poll_interval", time.Second, "How often the replication status of the nodes is refreshed (0 disables bounded reads from replicas)"},
+	{"load-balancer.state_watch_timeout", "load_balancer.state_watch_timeout", 30 * time.Second, "How long a watch of the controller's state waits for a change (0 only relies on pushes)"},
 }
 
 // initViper initializes a new Viper instance with default settings
@@ -162,6 +246,8 @@ func AddFlags(cmd *cobra.Command) {
 			cmd.PersistentFlags().String(fc.FlagName, v.String(), fc.Usage)
 		case int64:
 			cmd.PersistentFlags().Int64(fc.FlagName, v, fc.Usage)
+		case time.Duration:
+			cmd.PersistentFlags().Duration(fc.FlagName, v, fc.Usage)
 		default:
 			slog.Warn("invalid value type", "value", v)
 		}
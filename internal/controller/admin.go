@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"io/fs"
 	"log/slog"
 	"net/http"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/computer-technology-team/distributed-kvstore/web"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 type adminServer struct {
@@ -46,6 +49,9 @@ type AdminServer interface {
 	// RemoveNode handles the removal of a node
 	RemoveNode(w http.ResponseWriter, r *http.Request)
 
+	// RemoveBalancer handles the removal of a load balancer
+	RemoveBalancer(w http.ResponseWriter, r *http.Request)
+
 	// SystemStats renders system statistics page
 	SystemStats(w http.ResponseWriter, r *http.Request)
 
@@ -107,6 +113,9 @@ func (a *adminServer) SetupRoutes() {
 			r.Post("/remove", a.RemoveNode)
 		})
 
+		// Load balancers management
+		r.Post("/balancers/remove", a.RemoveBalancer)
+
 		// System statistics
 		r.Get("/stats", a.SystemStats)
 
@@ -124,6 +133,12 @@ func (a *adminServer) Dashboard(w http.ResponseWriter, r *http.Request) {
 		"VirtualNodes": state.VirtualNodes,
 		"Nodes":        state.Nodes,
 		"ReplicaCount": state.ReplicaCount,
+		"StateVersion": state.Version,
+		"Instance":     a.controller.Address(),
+		"Instances":    a.controller.Instances(),
+		"Leader":       a.controller.Leader(),
+		"Replication":  a.controller.ReplicationProgress(),
+		"Distribution": a.controller.DistributionProgress(),
 	}
 
 	a.renderTemplate(w, "dashboard.html", data)
@@ -156,6 +171,7 @@ func (a *adminServer) PartitionDetail(w http.ResponseWriter, r *http.Request) {
 	data := map[string]any{
 		"Title":     "Partition Details",
 		"Partition": partition,
+		"Replicas":  a.controller.ReplicationLag(partitionID),
 	}
 
 	a.renderTemplate(w, "partition_detail.html", data)
@@ -169,6 +185,10 @@ func (a *adminServer) NodesList(w http.ResponseWriter, r *http.Request) {
 		"Title":             "Nodes Management",
 		"Nodes":             state.Nodes,
 		"UnRegisteredNodes": state.UnRegisteredNodes,
+		"Loads":             a.controller.NodeLoads(),
+		"Balancers":         lo.FromPtr(state.LoadBalancers),
+		"StateVersions":     a.controller.AcknowledgedVersions(),
+		"StateVersion":      state.Version,
 	}
 
 	a.renderTemplate(w, "nodes.html", data)
@@ -231,6 +251,27 @@ func (a *adminServer) RemoveNode(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/nodes", http.StatusSeeOther)
 }
 
+// RemoveBalancer handles the removal of a load balancer
+func (a *adminServer) RemoveBalancer(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form data", http.StatusBadRequest)
+		return
+	}
+
+	balancerID, err := uuid.Parse(r.FormValue("balancer_id"))
+	if err != nil {
+		http.Error(w, "Invalid load balancer ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.controller.RemoveBalancer(balancerID); err != nil {
+		http.Error(w, "Failed to remove load balancer: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/nodes", http.StatusSeeOther)
+}
+
 // SystemStats renders system statistics page
 func (a *adminServer) SystemStats(w http.ResponseWriter, r *http.Request) {
 	stats := map[string]any{
@@ -273,6 +314,10 @@ func (a *adminServer) SetPartitionSize(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = a.controller.SetPartitionCount(partitionCountInt)
+	if errors.Is(err, ErrNotLeader) {
+		http.Error(w, "This controller instance does not lead the cluster", http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		slog.Error("could not set partition", "count", partitionCountInt,
 			"error", err)
@@ -310,6 +355,10 @@ func (a *adminServer) SetReplicaCount(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = a.controller.SetReplicaCount(replicaCountInt)
+	if errors.Is(err, ErrNotLeader) {
+		http.Error(w, "This controller instance does not lead the cluster", http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		http.Error(w, "could not set replica count", http.StatusBadRequest)
 		return
@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/controller"
+	"github.com/google/uuid"
+)
+
+// errorResponse is the error answer of a cluster change, it converts to the default response of every change
+type errorResponse struct {
+	Body       common.ErrorResponse
+	StatusCode int
+}
+
+// changeError maps an error of a cluster change to its status and error code
+func changeError(err error) errorResponse {
+	statusCode, code := http.StatusInternalServerError, common.InternalErrorCode
+	switch {
+	case errors.Is(err, ErrNotLeader), errors.Is(err, ErrNoLeader):
+		statusCode, code = http.StatusServiceUnavailable, common.NoLeaderErrorCode
+	case errors.Is(err, ErrInvalidRequest):
+		statusCode, code = http.StatusBadRequest, common.InvalidRequestErrorCode
+	case errors.Is(err, ErrNodeNotFound):
+		statusCode, code = http.StatusNotFound, common.NodeNotFoundErrorCode
+	case errors.Is(err, ErrBalancerNotFound):
+		statusCode, code = http.StatusNotFound, common.BalancerNotFoundErrorCode
+	case errors.Is(err, ErrNodeExists):
+		statusCode, code = http.StatusConflict, common.NodeExistsErrorCode
+	case errors.Is(err, ErrClusterBusy):
+		statusCode, code = http.StatusConflict, common.ClusterBusyErrorCode
+	case errors.Is(err, ErrNoCapacity):
+		statusCode, code = http.StatusConflict, common.NoCapacityErrorCode
+	default:
+		slog.Error("could not change cluster", "error", err)
+	}
+
+	return errorResponse{
+		Body:       common.ErrorResponse{Error: code, Message: err.Error()},
+		StatusCode: statusCode,
+	}
+}
+
+// noLeaderError is the answer to a change no controller instance can serve
+func noLeaderError(err error) errorResponse {
+	return errorResponse{Body: noLeaderResponse(err), StatusCode: http.StatusServiceUnavailable}
+}
+
+// leaderError relays the error the leading instance answered a forwarded change with
+func leaderError(statusCode int, body *common.ErrorResponse) errorResponse {
+	if body == nil {
+		return noLeaderError(fmt.Errorf("leader returned status %d", statusCode))
+	}
+
+	return errorResponse{Body: *body, StatusCode: statusCode}
+}
+
+// PutPartitionCount implements controller.StrictServerInterface.
+func (s *server) PutPartitionCount(ctx context.Context, request controller.PutPartitionCountRequestObject) (controller.PutPartitionCountResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing partition count in request body")
+	}
+
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.PutPartitionCountdefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.PutPartitionCountWithResponse(ctx, *request.Body)
+		if err != nil {
+			slog.Error("could not forward partition count to leader", "error", err)
+			return controller.PutPartitionCountdefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.JSON202 != nil {
+			return controller.PutPartitionCount202JSONResponse(*resp.JSON202), nil
+		}
+		return controller.PutPartitionCountdefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	if err := s.controller.SetPartitionCount(request.Body.PartitionCount); err != nil {
+		return controller.PutPartitionCountdefaultJSONResponse(changeError(err)), nil
+	}
+
+	return controller.PutPartitionCount202JSONResponse(s.controller.GetState()), nil
+}
+
+// PutReplicaCount implements controller.StrictServerInterface.
+func (s *server) PutReplicaCount(ctx context.Context, request controller.PutReplicaCountRequestObject) (controller.PutReplicaCountResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing replica count in request body")
+	}
+
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.PutReplicaCountdefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.PutReplicaCountWithResponse(ctx, *request.Body)
+		if err != nil {
+			slog.Error("could not forward replica count to leader", "error", err)
+			return controller.PutReplicaCountdefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.JSON200 != nil {
+			return controller.PutReplicaCount200JSONResponse(*resp.JSON200), nil
+		}
+		return controller.PutReplicaCountdefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	if err := s.controller.SetReplicaCount(request.Body.ReplicaCount); err != nil {
+		return controller.PutReplicaCountdefaultJSONResponse(changeError(err)), nil
+	}
+
+	return controller.PutReplicaCount200JSONResponse(s.controller.GetState()), nil
+}
+
+// PostNodes implements controller.StrictServerInterface.
+func (s *server) PostNodes(ctx context.Context, request controller.PostNodesRequestObject) (controller.PostNodesResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing node in request body")
+	}
+
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.PostNodesdefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.PostNodesWithResponse(ctx, *request.Body)
+		if err != nil {
+			slog.Error("could not forward node addition to leader", "error", err)
+			return controller.PostNodesdefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.JSON201 != nil {
+			return controller.PostNodes201JSONResponse(*resp.JSON201), nil
+		}
+		return controller.PostNodesdefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	nodeID := uuid.New()
+	if err := s.controller.AddNode(nodeID, request.Body.Address); err != nil {
+		return controller.PostNodesdefaultJSONResponse(changeError(err)), nil
+	}
+
+	node, found := s.controller.GetNode(nodeID)
+	if !found {
+		return controller.PostNodesdefaultJSONResponse(changeError(ErrNodeNotFound)), nil
+	}
+
+	return controller.PostNodes201JSONResponse(node), nil
+}
+
+// DeleteNodesNodeId implements controller.StrictServerInterface.
+func (s *server) DeleteNodesNodeId(ctx context.Context, request controller.DeleteNodesNodeIdRequestObject) (controller.DeleteNodesNodeIdResponseObject, error) {
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.DeleteNodesNodeIddefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.DeleteNodesNodeIdWithResponse(ctx, request.NodeID)
+		if err != nil {
+			slog.Error("could not forward node removal to leader", "error", err)
+			return controller.DeleteNodesNodeIddefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.StatusCode() == http.StatusAccepted {
+			return controller.DeleteNodesNodeId202Response{}, nil
+		}
+		return controller.DeleteNodesNodeIddefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	if err := s.controller.RemoveNode(request.NodeID.String()); err != nil {
+		return controller.DeleteNodesNodeIddefaultJSONResponse(changeError(err)), nil
+	}
+
+	return controller.DeleteNodesNodeId202Response{}, nil
+}
+
+// PostNodesNodeIdAdmit implements controller.StrictServerInterface.
+func (s *server) PostNodesNodeIdAdmit(ctx context.Context, request controller.PostNodesNodeIdAdmitRequestObject) (controller.PostNodesNodeIdAdmitResponseObject, error) {
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.PostNodesNodeIdAdmitdefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.PostNodesNodeIdAdmitWithResponse(ctx, request.NodeID)
+		if err != nil {
+			slog.Error("could not forward node admission to leader", "error", err)
+			return controller.PostNodesNodeIdAdmitdefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.JSON200 != nil {
+			return controller.PostNodesNodeIdAdmit200JSONResponse(*resp.JSON200), nil
+		}
+		return controller.PostNodesNodeIdAdmitdefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	nodeID, err := s.controller.RegisterNode(request.NodeID.String())
+	if err != nil {
+		return controller.PostNodesNodeIdAdmitdefaultJSONResponse(changeError(err)), nil
+	}
+
+	node, found := s.controller.GetNode(nodeID)
+	if !found {
+		return controller.PostNodesNodeIdAdmitdefaultJSONResponse(changeError(ErrNodeNotFound)), nil
+	}
+
+	return controller.PostNodesNodeIdAdmit200JSONResponse(node), nil
+}
+
+// PostBalancersRegister implements controller.StrictServerInterface.
+func (s *server) PostBalancersRegister(ctx context.Context, request controller.PostBalancersRegisterRequestObject) (controller.PostBalancersRegisterResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing load balancer in request body")
+	}
+
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.PostBalancersRegisterdefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.PostBalancersRegisterWithResponse(ctx, *request.Body)
+		if err != nil {
+			slog.Error("could not forward load balancer registration to leader", "error", err)
+			return controller.PostBalancersRegisterdefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.JSON201 != nil {
+			return controller.PostBalancersRegister201JSONResponse(*resp.JSON201), nil
+		}
+		return controller.PostBalancersRegisterdefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	balancer, err := s.controller.RegisterBalancer(request.Body.Address)
+	if err != nil {
+		return controller.PostBalancersRegisterdefaultJSONResponse(changeError(err)), nil
+	}
+
+	return controller.PostBalancersRegister201JSONResponse(balancer), nil
+}
+
+// GetBalancers implements controller.StrictServerInterface.
+func (s *server) GetBalancers(ctx context.Context, request controller.GetBalancersRequestObject) (controller.GetBalancersResponseObject, error) {
+	return controller.GetBalancers200JSONResponse(s.controller.GetBalancers()), nil
+}
+
+// DeleteBalancersBalancerId implements controller.StrictServerInterface.
+func (s *server) DeleteBalancersBalancerId(ctx context.Context, request controller.DeleteBalancersBalancerIdRequestObject) (controller.DeleteBalancersBalancerIdResponseObject, error) {
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.DeleteBalancersBalancerIddefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.DeleteBalancersBalancerIdWithResponse(ctx, request.BalancerID)
+		if err != nil {
+			slog.Error("could not forward load balancer removal to leader", "error", err)
+			return controller.DeleteBalancersBalancerIddefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.StatusCode() == http.StatusNoContent {
+			return controller.DeleteBalancersBalancerId204Response{}, nil
+		}
+		return controller.DeleteBalancersBalancerIddefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	if err := s.controller.RemoveBalancer(request.BalancerID); err != nil {
+		return controller.DeleteBalancersBalancerIddefaultJSONResponse(changeError(err)), nil
+	}
+
+	return controller.DeleteBalancersBalancerId204Response{}, nil
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+)
+
+func TestChangeError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatusCode int
+		wantCode       string
+	}{
+		{name: "not leader", err: ErrNotLeader, wantStatusCode: http.StatusServiceUnavailable, wantCode: common.NoLeaderErrorCode},
+		{name: "no leader", err: ErrNoLeader, wantStatusCode: http.StatusServiceUnavailable, wantCode: common.NoLeaderErrorCode},
+		{name: "dropped change", err: fmt.Errorf("%w: state version 7 was not committed", ErrNotLeader),
+			wantStatusCode: http.StatusServiceUnavailable, wantCode: common.NoLeaderErrorCode},
+		{name: "invalid request", err: fmt.Errorf("%w: replica count must not be negative", ErrInvalidRequest),
+			wantStatusCode: http.StatusBadRequest, wantCode: common.InvalidRequestErrorCode},
+		{name: "node not found", err: ErrNodeNotFound, wantStatusCode: http.StatusNotFound, wantCode: common.NodeNotFoundErrorCode},
+		{name: "balancer not found", err: fmt.Errorf("%w: id", ErrBalancerNotFound),
+			wantStatusCode: http.StatusNotFound, wantCode: common.BalancerNotFoundErrorCode},
+		{name: "node exists", err: ErrNodeExists, wantStatusCode: http.StatusConflict, wantCode: common.NodeExistsErrorCode},
+		{name: "cluster busy", err: fmt.Errorf("%w: resharding is in progress", ErrClusterBusy),
+			wantStatusCode: http.StatusConflict, wantCode: common.ClusterBusyErrorCode},
+		{name: "no capacity", err: fmt.Errorf("%w: no healthy nodes", ErrNoCapacity),
+			wantStatusCode: http.StatusConflict, wantCode: common.NoCapacityErrorCode},
+		{name: "unexpected", err: errors.New("could not create database client"),
+			wantStatusCode: http.StatusInternalServerError, wantCode: common.InternalErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := changeError(tt.err)
+
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("got status code %d, want %d", resp.StatusCode, tt.wantStatusCode)
+			}
+			if resp.Body.Error != tt.wantCode {
+				t.Errorf("got error code %q, want %q", resp.Body.Error, tt.wantCode)
+			}
+			if resp.Body.Message != tt.err.Error() {
+				t.Errorf("got message %q, want %q", resp.Body.Message, tt.err.Error())
+			}
+		})
+	}
+}
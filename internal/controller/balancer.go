@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/loadbalancer"
+	"github.com/google/uuid"
+	"github.com/mohae/deepcopy"
+	"github.com/samber/lo"
+)
+
+// ErrBalancerNotFound is returned for changes of a load balancer the cluster does not know
+var ErrBalancerNotFound = errors.New("load balancer not found")
+
+// RegisterBalancer registers a load balancer by the address of its private API, a load
+// balancer registering again on a known address keeps its ID
+func (c *Controller) RegisterBalancer(address string) (common.LoadBalancer, error) {
+	balancer, version, err := c.registerBalancer(address)
+	if err != nil {
+		return common.LoadBalancer{}, err
+	}
+
+	return balancer, c.awaitChange(version)
+}
+
+// registerBalancer registers a load balancer, returning the version of the state to wait for
+func (c *Controller) registerBalancer(address string) (common.LoadBalancer, int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.leadingLocked() {
+		return common.LoadBalancer{}, 0, ErrNotLeader
+	}
+
+	if address == "" {
+		return common.LoadBalancer{}, 0, fmt.Errorf("%w: load balancer address is required", ErrInvalidRequest)
+	}
+
+	balancers := lo.FromPtr(c.state.LoadBalancers)
+	idx := slices.IndexFunc(balancers, func(b common.LoadBalancer) bool {
+		return b.Address == address
+	})
+
+	if idx >= 0 {
+		slog.Info("load balancer re-registered", "balancer_id", balancers[idx].Id, "balancer_address", address)
+		// A restarted load balancer only holds the state it fetched on startup
+		c.distribution.forget(balancers[idx].Id)
+
+		if balancers[idx].Status != common.Healthy {
+			balancers[idx].Status = common.Healthy
+			delete(c.unhealthySince, balancers[idx].Id)
+			c.commitStateLocked()
+		}
+	} else {
+		client, err := loadbalancer.NewClientWithResponses("http://" + address)
+		if err != nil {
+			return common.LoadBalancer{}, 0, fmt.Errorf("could not create load balancer client: %w", err)
+		}
+
+		balancer := common.LoadBalancer{Id: uuid.New(), Address: address, Status: common.Healthy}
+		slog.Info("load balancer registered", "balancer_id", balancer.Id, "balancer_address", address)
+
+		c.balancerClients[balancer.Id] = client
+		balancers = append(balancers, balancer)
+		c.state.LoadBalancers = &balancers
+		idx = len(balancers) - 1
+		c.commitStateLocked()
+	}
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	go c.dispatchState(stateCopy)
+
+	return balancers[idx], c.state.Version, nil
+}
+
+// RemoveBalancer removes a load balancer, it no longer receives the state
+func (c *Controller) RemoveBalancer(balancerID uuid.UUID) error {
+	version, err := c.removeBalancer(balancerID)
+	if err != nil {
+		return err
+	}
+
+	return c.awaitChange(version)
+}
+
+// removeBalancer removes a load balancer, returning the version of the state to wait for
+func (c *Controller) removeBalancer(balancerID uuid.UUID) (int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.leadingLocked() {
+		return 0, ErrNotLeader
+	}
+
+	balancers := lo.FromPtr(c.state.LoadBalancers)
+	idx := slices.IndexFunc(balancers, func(b common.LoadBalancer) bool {
+		return b.Id == balancerID
+	})
+	if idx < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrBalancerNotFound, balancerID)
+	}
+
+	c.removeBalancerLocked(balancers[idx].Id)
+	c.commitStateLocked()
+
+	return c.state.Version, nil
+}
+
+// removeBalancerLocked drops a load balancer from the state, the caller must hold the lock
+func (c *Controller) removeBalancerLocked(balancerID uuid.UUID) {
+	slog.Info("load balancer removed", "balancer_id", balancerID)
+
+	balancers := lo.Reject(lo.FromPtr(c.state.LoadBalancers), func(b common.LoadBalancer, _ int) bool {
+		return b.Id == balancerID
+	})
+	c.state.LoadBalancers = &balancers
+
+	delete(c.balancerClients, balancerID)
+	delete(c.unhealthySince, balancerID)
+	c.distribution.forget(balancerID)
+}
+
+// GetBalancers returns the registered load balancers
+func (c *Controller) GetBalancers() []common.LoadBalancer {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return slices.Clone(lo.FromPtr(c.state.LoadBalancers))
+}
+
+// checkBalancers probes the health endpoint of every load balancer. Load balancers unhealthy
+// for longer than the dead node timeout are removed, they register again when they come back.
+func (c *Controller) checkBalancers() {
+	c.lock.RLock()
+	balancers := slices.Clone(lo.FromPtr(c.state.LoadBalancers))
+	c.lock.RUnlock()
+
+	if len(balancers) == 0 {
+		return
+	}
+
+	statuses := make([]common.Status, len(balancers))
+	var wg sync.WaitGroup
+	for i, balancer := range balancers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = c.probeBalancer(balancer)
+		}()
+	}
+	wg.Wait()
+
+	c.lock.Lock()
+
+	var recoveredIDs []uuid.UUID
+	changed := false
+	for i, probed := range balancers {
+		current := lo.FromPtr(c.state.LoadBalancers)
+		idx := slices.IndexFunc(current, func(b common.LoadBalancer) bool {
+			return b.Id == probed.Id
+		})
+		// Removed or re-registered while it was probed
+		if idx < 0 {
+			continue
+		}
+		balancer := &current[idx]
+
+		switch statuses[i] {
+		case common.Unhealthy:
+			since, found := c.unhealthySince[balancer.Id]
+			if !found {
+				c.unhealthySince[balancer.Id] = time.Now()
+			} else if time.Since(since) >= c.deadNodeTimeout {
+				c.removeBalancerLocked(balancer.Id)
+				changed = true
+				continue
+			}
+		case common.Healthy:
+			delete(c.unhealthySince, balancer.Id)
+			if balancer.Status == common.Unhealthy {
+				slog.Info("load balancer recovered", "balancer_id", balancer.Id)
+				// The load balancer may have restarted and lost the state it acknowledged
+				c.distribution.forget(balancer.Id)
+				recoveredIDs = append(recoveredIDs, balancer.Id)
+			}
+		}
+
+		if balancer.Status != statuses[i] {
+			slog.Info("load balancer status changed", "balancer_id", balancer.Id, "status", statuses[i])
+			balancer.Status = statuses[i]
+			changed = true
+		}
+	}
+
+	if !changed {
+		c.lock.Unlock()
+		return
+	}
+
+	c.commitStateLocked()
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	c.lock.Unlock()
+
+	if len(recoveredIDs) > 0 {
+		c.dispatchState(stateCopy)
+	}
+}
+
+// probeBalancer returns the health of a load balancer
+func (c *Controller) probeBalancer(balancer common.LoadBalancer) common.Status {
+	ctx, cancel := context.WithTimeout(context.Background(), c.healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+balancer.Address+"/health", nil)
+	if err != nil {
+		slog.Error("could not create load balancer health request", "balancer_id", balancer.Id, "error", err)
+		return common.Unhealthy
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		slog.Error("could not check load balancer health", "balancer_address", balancer.Address, "error", err)
+		return common.Unhealthy
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		slog.Error("load balancer health response non 200", "balancer_address", balancer.Address,
+			"status_code", resp.StatusCode)
+		return common.Unhealthy
+	}
+
+	return common.Healthy
+}
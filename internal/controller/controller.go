@@ -6,15 +6,17 @@ import (
 	"fmt"
 	"hash/fnv"
 	"log/slog"
-	"math"
+	"maps"
+	"reflect"
 	"slices"
-	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/computer-technology-team/distributed-kvstore/api/database"
 	"github.com/computer-technology-team/distributed-kvstore/api/loadbalancer"
+	"github.com/computer-technology-team/distributed-kvstore/internal/raft"
 	"github.com/google/uuid"
 	"github.com/mohae/deepcopy"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -23,8 +25,20 @@ import (
 
 var startWorkerOnce sync.Once
 
+var (
+	// ErrInvalidRequest is returned for changes of the cluster with invalid arguments
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrNodeNotFound is returned for changes of a node the cluster does not know
+	ErrNodeNotFound = errors.New("node not found")
+	// ErrNodeExists is returned when adding a node whose ID or address is already known
+	ErrNodeExists = errors.New("node already exists")
+	// ErrClusterBusy is returned for changes conflicting with a resharding or with partitions moving
+	ErrClusterBusy = errors.New("cluster is busy")
+	// ErrNoCapacity is returned when too few nodes are available for a change
+	ErrNoCapacity = errors.New("not enough nodes available")
+)
+
 type Controller struct {
-	balancerClient      loadbalancer.ClientWithResponsesInterface
 	state               common.State
 	lock                sync.RWMutex
 	startTime           time.Time
@@ -33,27 +47,86 @@ type Controller struct {
 	ticker              *time.Ticker
 	stopWorker          chan int
 	nodeClients         map[uuid.UUID]database.ClientWithResponsesInterface
+	balancerClients     map[uuid.UUID]loadbalancer.ClientWithResponsesInterface
 	virtualNodeCount    int
+	// deadNodeTimeout is the time a node stays unhealthy before its replicas are replaced, and
+	// a load balancer before it is removed
+	deadNodeTimeout time.Duration
+	// unhealthySince is when a node or a load balancer started failing its health checks
+	unhealthySince map[uuid.UUID]time.Time
+	// failureDetector decides from the health probes of the nodes which ones are unhealthy
+	failureDetector FailureDetector
+	placementPolicy PlacementPolicy
+	// reportedLoads are the last partition statuses every node reported, keyed by node and partition
+	reportedLoads map[uuid.UUID]map[string]common.PartitionStatus
+	// heartbeats is when every node sent its last heartbeat
+	heartbeats map[uuid.UUID]time.Time
+	// fences is the state version that fenced the leaving master of a partition, keyed by partition
+	fences map[string]int64
+	// stateStore persists every change of the state, nil keeps it in memory only
+	stateStore StateStore
+	// raft replicates the state among the controller instances, nil for a single instance
+	raft *raft.Node
+	ha   HAOptions
+	// leading reports whether this instance took over the cluster, in Raft term leaderTerm
+	leading    bool
+	leaderTerm int64
+	// replicated is the last state committed by the instances, at Raft index replicatedIndex
+	replicated      *common.State
+	replicatedIndex int64
+	pendingState    atomic.Pointer[common.State]
+	replicateCh     chan struct{}
+	// distribution tracks the state versions the nodes and the load balancers acknowledged
+	distribution *stateDistribution
+	// stateChanged is closed and replaced whenever the state changes, waking up watchers
+	stateChanged chan struct{}
 }
 
+// SetPartitionCount reshards the cluster to a partition count once the change was committed
 func (c *Controller) SetPartitionCount(partitionCount int) error {
+	version, err := c.setPartitionCount(partitionCount)
+	if err != nil {
+		return err
+	}
+
+	return c.awaitChange(version)
+}
+
+// setPartitionCount changes the partition count, returning the version of the state to wait for
+func (c *Controller) setPartitionCount(partitionCount int) (int64, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	if !c.leadingLocked() {
+		return 0, ErrNotLeader
+	}
+
 	// Validate inputs
 	if partitionCount <= 0 {
-		return errors.New("partition count must be greater than 0")
+		return 0, fmt.Errorf("%w: partition count must be greater than 0", ErrInvalidRequest)
 	}
 
 	if len(c.state.Nodes) == 0 {
-		return errors.New("no available nodes")
+		return 0, fmt.Errorf("%w: no registered nodes", ErrNoCapacity)
+	}
+
+	if c.state.IsResharding {
+		return 0, fmt.Errorf("%w: resharding is already in progress", ErrClusterBusy)
+	}
+
+	if c.movingLocked() {
+		return 0, fmt.Errorf("%w: partitions are being moved between nodes", ErrClusterBusy)
+	}
+
+	if len(lo.FromPtr(c.state.PendingMigrationCommits)) > 0 {
+		return 0, fmt.Errorf("%w: migrated keys are still being deleted from their sources", ErrClusterBusy)
 	}
 
 	currentPartitionCount := len(c.state.Partitions)
 
 	// No change needed
 	if currentPartitionCount == partitionCount {
-		return nil
+		return c.state.Version, nil
 	}
 
 	// Initialize partitions map if needed
@@ -61,16 +134,8 @@ func (c *Controller) SetPartitionCount(partitionCount int) error {
 		c.state.Partitions = make(map[string]common.Partition)
 	}
 
-	// Initialize migration ranges if needed
-	if c.state.MigrationRanges == nil {
-		c.state.MigrationRanges = &[]common.MigrationRange{}
-	}
-
-	// Set resharding flag - only set to true if we're not creating the first partition
-	c.state.IsResharding = currentPartitionCount != 0
-
-	// Create a deep copy of the state for later use
-	stateCopy := deepcopy.Copy(c.state).(common.State)
+	// Keep the current ring to find the hash ranges changing owner
+	oldVirtualNodes := slices.Clone(c.state.VirtualNodes)
 	nodeIDSet := make(map[openapi_types.UUID]struct{})
 
 	if currentPartitionCount < partitionCount {
@@ -79,15 +144,9 @@ func (c *Controller) SetPartitionCount(partitionCount int) error {
 			partitionID := uuid.NewString()
 
 			// Determine which nodes will host the partition
-			var nodeIDs []openapi_types.UUID
-			var partitionNodes []common.Node
-
-			if len(c.state.Partitions) == 0 {
-				// First partition: assign to all nodes
-				nodeIDs, partitionNodes = c.getAllNodesForPartition()
-			} else {
-				// Subsequent partitions: distribute based on load
-				nodeIDs, partitionNodes = c.selectNodesForPartition()
+			nodeIDs, partitionNodes := c.selectNodesForPartition(partitionID)
+			if len(nodeIDs) == 0 {
+				return 0, fmt.Errorf("%w: no healthy nodes", ErrNoCapacity)
 			}
 
 			// Create the partition and assign it to nodes
@@ -97,103 +156,90 @@ func (c *Controller) SetPartitionCount(partitionCount int) error {
 			// Generate virtual nodes for the partition
 			if err := c.generateVirtualNodesForPartition(partitionID, c.virtualNodeCount); err != nil {
 				slog.Error("failed to generate virtual nodes for partition", "error", err)
-				return err
+				return 0, err
 			}
 
 			// Add node IDs to the set to ensure uniqueness
 			for _, nodeID := range nodeIDs {
 				nodeIDSet[nodeID] = struct{}{}
 			}
-
-			// Create migration ranges for the new partition if this isn't the first partition
-			if c.state.IsResharding {
-				c.createMigrationRangesForNewPartition(partitionID)
-			}
 		}
 	} else if currentPartitionCount > partitionCount {
 		// Need to remove partitions
 		// Determine which partitions to remove
 		partitionsToRemove := c.selectPartitionsToRemove(currentPartitionCount - partitionCount)
 
-		// For each partition to remove
+		// Removed partitions only leave the ring, they keep serving their keys
+		// until the migrations are committed
 		for _, partitionID := range partitionsToRemove {
-			// Get the nodes that host this partition
-			partition := c.state.Partitions[partitionID]
-
-			// Add node IDs to the set for state updates
-			for _, nodeID := range partition.NodeIds {
-				nodeIDSet[nodeID] = struct{}{}
-			}
-
-			// Create migration ranges for redistributing data from this partition
-			c.createMigrationRangesForRemovedPartition(partitionID)
-
-			// Remove virtual nodes for this partition
 			c.removeVirtualNodesForPartition(partitionID)
-
-			// Remove the partition from nodes
-			c.removePartitionFromNodes(partitionID)
-
-			// Delete the partition from state
-			delete(c.state.Partitions, partitionID)
 		}
 	}
 
-	// Mark partitions as migrating if we're resharding
-	if c.state.IsResharding {
-		for partitionID := range c.state.Partitions {
+	// Create migration ranges for every hash range that changed owner
+	migrationRanges := computeMigrationRanges(oldVirtualNodes, c.state.VirtualNodes)
+	c.state.MigrationRanges = &migrationRanges
+	c.state.IsResharding = len(migrationRanges) > 0
+
+	// Mark the partitions involved in a migration
+	for _, migrationRange := range migrationRanges {
+		for _, partitionID := range []string{migrationRange.SourcePartitionId, migrationRange.TargetPartitionId} {
 			partition := c.state.Partitions[partitionID]
-			isMigrating := true
-			partition.IsMigrating = &isMigrating
+			partition.IsMigrating = lo.ToPtr(true)
 			c.state.Partitions[partitionID] = partition
 		}
 	}
 
-	// Convert nodeIDSet to a slice for state updates
-	var allNodeIDs []openapi_types.UUID
-	for nodeID := range nodeIDSet {
-		allNodeIDs = append(allNodeIDs, nodeID)
-	}
+	slog.Info("partition count changed", "partition_count", partitionCount,
+		"migration_ranges", len(migrationRanges))
+
+	c.commitStateLocked()
+
+	// Create a deep copy of the state to dispatch outside the lock
+	stateCopy := deepcopy.Copy(c.state).(common.State)
 
 	// Create node state updates
-	nodeStateUpdates := lo.Map(lo.Uniq(allNodeIDs), func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+	nodeStateUpdates := lo.Map(lo.Keys(nodeIDSet), func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
 		return lo.T2(nodeID, stateCopy)
 	})
 
 	// Dispatch state updates in a goroutine
 	go func() {
 		c.dispatchNodeState(nodeStateUpdates)
-		c.dispatchState()
+		c.dispatchState(stateCopy)
 	}()
 
-	return nil
+	return c.state.Version, nil
 }
 
-// getAllNodesForPartition returns all nodes for the first partition
-func (c *Controller) getAllNodesForPartition() ([]openapi_types.UUID, []common.Node) {
-	nodeIDs := make([]openapi_types.UUID, len(c.state.Nodes))
-	for i, node := range c.state.Nodes {
-		nodeIDs[i] = node.Id
+// selectNodesForPartition selects the nodes of a new partition, its master first. The nodes
+// are spread over failure domains and the nodes hosting the fewest partitions are preferred.
+func (c *Controller) selectNodesForPartition(partitionID string) ([]openapi_types.UUID, []common.Node) {
+	var nodeIDs []openapi_types.UUID
+	spread := true
+	for range c.state.ReplicaCount + 1 {
+		idx, nodeSpread, found := c.pickNodeLocked(nodeIDs, nil)
+		if !found {
+			break
+		}
+		spread = spread && nodeSpread
+		nodeIDs = append(nodeIDs, c.state.Nodes[idx].Id)
 	}
-	return nodeIDs[:c.state.ReplicaCount+1], c.state.Nodes[:c.state.ReplicaCount+1]
-}
 
-// selectNodesForPartition selects nodes for a new partition based on load balancing
-func (c *Controller) selectNodesForPartition() ([]openapi_types.UUID, []common.Node) {
-	currentPartitionCount := len(c.state.Partitions)
-	currentNodeCount := len(c.state.Nodes)
-	maxNodePartitions := int(math.Round(float64(currentPartitionCount+1) / float64(currentNodeCount)))
+	if !spread {
+		slog.Warn("placement policy cannot be met", "partition_id", partitionID,
+			"policy", c.placementPolicy)
+	}
 
-	candidateNodes := lo.Filter(c.state.Nodes, func(n common.Node, _ int) bool {
-		return len(n.Partitions) <= maxNodePartitions
+	partitionNodes := lo.Filter(c.state.Nodes, func(n common.Node, _ int) bool {
+		return lo.Contains(nodeIDs, n.Id)
 	})
-
-	partitionNodes := lo.Samples(candidateNodes, c.state.ReplicaCount+1)
-	partitionNodesIDs := lo.Map(partitionNodes, func(n common.Node, _ int) openapi_types.UUID {
-		return n.Id
+	// The master comes first
+	slices.SortFunc(partitionNodes, func(a, b common.Node) int {
+		return slices.Index(nodeIDs, a.Id) - slices.Index(nodeIDs, b.Id)
 	})
 
-	return partitionNodesIDs, partitionNodes
+	return nodeIDs, partitionNodes
 }
 
 // createPartition creates a new partition in the state
@@ -215,8 +261,10 @@ func (c *Controller) assignPartitionToNodes(partitionID string, nodes []common.N
 
 		// Create partition role
 		role := common.PartitionRole{
-			IsMaster:  i == 0,
-			IsSyncing: len(c.state.Partitions) > 1, // Only set syncing for non-first partitions
+			IsMaster: i == 0,
+			// Replicas pull the master's state and report when they caught up
+			IsSyncing: i != 0,
+			Epoch:     c.state.Partitions[partitionID].Epoch,
 		}
 
 		// Assign role to nodes[i]
@@ -224,28 +272,49 @@ func (c *Controller) assignPartitionToNodes(partitionID string, nodes []common.N
 	}
 }
 
-func (c *Controller) RemoveNode(nodeID string) error {
-	panic("unimplemented")
+// AddNode adds a node to the cluster, the watcher moves partitions onto it once it passed a health check
+func (c *Controller) AddNode(nodeID uuid.UUID, nodeAddress string) error {
+	version, err := c.addNode(nodeID, nodeAddress)
+	if err != nil {
+		return err
+	}
+
+	return c.awaitChange(version)
 }
 
-func (c *Controller) AddNode(nodeID uuid.UUID, nodeAddress string) error {
+// addNode adds a node, returning the version of the state to wait for
+func (c *Controller) addNode(nodeID uuid.UUID, nodeAddress string) (int64, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if len(c.state.Partitions) == 0 {
-		// Initialize empty partitions map
-		partitions := make(map[string]common.PartitionRole)
+	if !c.leadingLocked() {
+		return 0, ErrNotLeader
+	}
 
-		c.state.Nodes = append(c.state.Nodes, common.Node{
-			Address:    nodeAddress,
-			Id:         nodeID,
-			Partitions: partitions,
-		})
+	if nodeAddress == "" {
+		return 0, fmt.Errorf("%w: node address is required", ErrInvalidRequest)
+	}
+
+	if lo.ContainsBy(slices.Concat(c.state.Nodes, c.state.UnRegisteredNodes), func(n common.Node) bool {
+		return n.Id == nodeID || n.Address == nodeAddress
+	}) {
+		return 0, ErrNodeExists
+	}
 
-		return nil
+	client, err := database.NewClientWithResponses("http://" + nodeAddress)
+	if err != nil {
+		return 0, fmt.Errorf("could not create database client: %w", err)
 	}
+	c.nodeClients[nodeID] = client
 
-	return errors.New("unimplemented")
+	c.state.Nodes = append(c.state.Nodes, common.Node{
+		Address:    nodeAddress,
+		Id:         nodeID,
+		Partitions: make(map[string]common.PartitionRole),
+	})
+	c.commitStateLocked()
+
+	return c.state.Version, nil
 }
 
 func (c *Controller) GetState() common.State {
@@ -255,27 +324,91 @@ func (c *Controller) GetState() common.State {
 	return c.state
 }
 
+// CommittedState returns the last state committed by the controller instances, the zero
+// state when none was committed yet
+func (c *Controller) CommittedState() common.State {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	state, _ := c.committedStateLocked()
+	return deepcopy.Copy(state).(common.State)
+}
+
+// WatchState returns the committed state once its version is newer than sinceVersion,
+// reporting false when ctx is done first
+func (c *Controller) WatchState(ctx context.Context, sinceVersion int64) (common.State, bool) {
+	for {
+		c.lock.RLock()
+		if committed, found := c.committedStateLocked(); found && committed.Version > sinceVersion {
+			state := deepcopy.Copy(committed).(common.State)
+			c.lock.RUnlock()
+			return state, true
+		}
+		changed := c.stateChanged
+		c.lock.RUnlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return common.State{}, false
+		}
+	}
+}
+
+// notifyStateChangedLocked wakes up the watchers of the state, the caller must hold the lock
+func (c *Controller) notifyStateChangedLocked() {
+	close(c.stateChanged)
+	c.stateChanged = make(chan struct{})
+}
+
+// GetNode returns a registered or unregistered node by its ID
+func (c *Controller) GetNode(nodeID uuid.UUID) (common.Node, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	node, found := lo.Find(slices.Concat(c.state.Nodes, c.state.UnRegisteredNodes), func(n common.Node) bool {
+		return n.Id == nodeID
+	})
+
+	return deepcopy.Copy(node).(common.Node), found
+}
+
 func (c *Controller) GetUptime() time.Duration {
 	return time.Since(c.startTime)
 }
 
+// RegisterNode admits a node that registered itself, the watcher moves partitions onto it once it passed a health check
 func (c *Controller) RegisterNode(nodeID string) (uuid.UUID, error) {
+	id, version, err := c.registerNode(nodeID)
+	if err != nil {
+		return uuid.Max, err
+	}
+
+	return id, c.awaitChange(version)
+}
+
+// registerNode admits a node, returning the version of the state to wait for
+func (c *Controller) registerNode(nodeID string) (uuid.UUID, int64, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	if !c.leadingLocked() {
+		return uuid.Max, 0, ErrNotLeader
+	}
+
 	unregisteredNode, idx, found := lo.FindIndexOf(c.state.UnRegisteredNodes, func(n common.Node) bool {
 		return n.Id.String() == nodeID
 	})
 
 	if !found {
-		return uuid.Max, errors.New("unregistered node not found")
+		return uuid.Max, 0, fmt.Errorf("%w: no unregistered node has this id", ErrNodeNotFound)
 	}
 
 	_, found = lo.Find(c.state.Nodes, func(n common.Node) bool {
 		return n.Address == unregisteredNode.Address
 	})
 	if found {
-		return uuid.Max, errors.New("registered node with this address already exists")
+		return uuid.Max, 0, fmt.Errorf("%w: a registered node has the same address", ErrNodeExists)
 	}
 
 	// Initialize empty partitions map
@@ -284,14 +417,17 @@ func (c *Controller) RegisterNode(nodeID string) (uuid.UUID, error) {
 	registeredNode := common.Node{
 		Address:    unregisteredNode.Address,
 		Id:         unregisteredNode.Id,
+		Labels:     unregisteredNode.Labels,
+		Weight:     unregisteredNode.Weight,
 		Partitions: partitions,
 	}
 
 	c.state.Nodes = append(c.state.Nodes, registeredNode)
 
 	c.state.UnRegisteredNodes = slices.Delete(c.state.UnRegisteredNodes, idx, idx+1)
+	c.commitStateLocked()
 
-	return uuid.UUID(registeredNode.Id), nil
+	return uuid.UUID(registeredNode.Id), c.state.Version, nil
 }
 
 func (c *Controller) StartWatcher() {
@@ -303,92 +439,306 @@ func (c *Controller) startWorker() {
 	for {
 		select {
 		case <-c.ticker.C:
+			leading, elected := c.updateLeadership()
+			if !leading {
+				continue
+			}
+
+			if elected {
+				c.redispatchState()
+			}
+
 			c.checkNodes()
+			c.checkBalancers()
+			c.failoverPartitions()
+			c.advanceMigrations()
+			c.advancePartitionMoves()
+			c.reconcileReplicas()
+			c.rebalancePartitions()
+			c.distributeState()
 		case <-c.stopWorker:
 			return
 		}
 	}
 }
 
+// checkNodes probes every registered node in parallel outside the lock, the failure detector
+// decides from the outcomes which nodes are unhealthy. Nodes that sent a heartbeat within the
+// health check interval are not probed.
 func (c *Controller) checkNodes() {
+	now := time.Now()
+
+	c.lock.RLock()
+	probes := lo.FilterMap(c.state.Nodes, func(node common.Node, _ int) (nodeProbe, bool) {
+		return nodeProbe{nodeID: node.Id, address: node.Address, client: c.nodeClients[node.Id]},
+			!c.heartbeatFreshLocked(node.Id, now)
+	})
+	c.lock.RUnlock()
+
+	var wg sync.WaitGroup
+	for i := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probes[i].run(c.healthCheckTimeout)
+		}()
+	}
+	wg.Wait()
+
+	now = time.Now()
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	for i := range c.state.Nodes {
-		c.checkNode(&c.state.Nodes[i])
+	var recoveredNodeIDs []openapi_types.UUID
+	changed := false
+	for _, probe := range probes {
+		idx := slices.IndexFunc(c.state.Nodes, func(n common.Node) bool { return n.Id == probe.nodeID })
+		// Removed while it was probed
+		if idx < 0 {
+			continue
+		}
+		node := &c.state.Nodes[idx]
+
+		if _, found := c.nodeClients[node.Id]; !found && probe.client != nil {
+			c.nodeClients[node.Id] = probe.client
+		}
+
+		recovered, nodeChanged := c.applyNodeReportLocked(node, probe.ok, probe.statuses, now)
+		if recovered {
+			recoveredNodeIDs = append(recoveredNodeIDs, node.Id)
+		}
+		changed = changed || nodeChanged
+	}
+
+	if !changed {
+		return
 	}
+
+	c.commitStateLocked()
+
+	// Recovered nodes may have missed role changes while they were unreachable,
+	// e.g. a former master has to learn it was demoted. Replicas that caught up
+	// can serve reads from now on.
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	go func() {
+		c.dispatchNodeState(lo.Map(recoveredNodeIDs, func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+			return lo.T2(nodeID, stateCopy)
+		}))
+		c.dispatchState(stateCopy)
+	}()
 }
 
-// updateNodePartitionsStatus updates the status of all partitions for a node
-func (c *Controller) updateNodePartitionsStatus(node *common.Node, status common.Status) {
+// applyNodeReportLocked feeds the outcome of a probe or a heartbeat of a node to the failure
+// detector and applies the partition statuses the node reported. It returns whether the node
+// recovered and whether its status or its roles changed. The caller must hold the lock.
+func (c *Controller) applyNodeReportLocked(node *common.Node, ok bool, statuses []common.PartitionStatus,
+	now time.Time) (recovered, changed bool) {
+	c.failureDetector.Report(node.Id, ok, now)
+	status := common.Healthy
+	if c.failureDetector.Suspect(node.Id, now) {
+		status = common.Unhealthy
+	}
+
+	previousStatus := node.Status
+	previousRoles := maps.Clone(node.Partitions)
+	c.updateNodePartitionsStatus(node, status, statuses)
+	c.markNodeHealthLocked(*node)
+
+	if previousStatus == common.Unhealthy && node.Status == common.Healthy {
+		slog.Info("node recovered", "node_id", node.Id)
+		// The node may have restarted and lost the state it acknowledged
+		c.distribution.forget(node.Id)
+		recovered = true
+	}
+
+	return recovered, previousStatus != node.Status || !maps.Equal(previousRoles, node.Partitions)
+}
+
+// updateNodePartitionsStatus updates the health of a node and, when the node reported
+// the status of its partitions, whether its replicas are still syncing with their masters.
+// With Raft replication the partition masters follow the leaders the nodes report.
+func (c *Controller) updateNodePartitionsStatus(node *common.Node, status common.Status,
+	partitionStatuses []common.PartitionStatus) {
 	if node.Partitions == nil {
 		return
 	}
 
 	node.Status = status
+	if partitionStatuses != nil {
+		c.recordLoadLocked(node.Id, partitionStatuses)
+	}
+
+	for _, partitionStatus := range partitionStatuses {
+		role, exists := node.Partitions[partitionStatus.PartitionId]
+		if !exists {
+			continue
+		}
+
+		if c.state.UsesRaft() {
+			c.updateRaftLeader(node, partitionStatus)
+			continue
+		}
 
-	for partitionID := range node.Partitions {
-		node.Partitions[partitionID] = common.PartitionRole{
-			IsMaster: node.Partitions[partitionID].IsMaster,
+		if role.IsMaster {
+			continue
+		}
+
+		// Sent before the node learned its current role, e.g. by a replica that just
+		// started to sync again after a move or a failover
+		if partitionStatus.Epoch < role.Epoch {
+			continue
 		}
+
+		if role.IsSyncing != partitionStatus.IsSyncing {
+			slog.Info("replica syncing status changed", "node_id", node.Id,
+				"partition_id", partitionStatus.PartitionId, "is_syncing", partitionStatus.IsSyncing)
+		}
+
+		role.IsSyncing = partitionStatus.IsSyncing
+		node.Partitions[partitionStatus.PartitionId] = role
 	}
 }
 
-func (c *Controller) checkNode(node *common.Node) {
-	client, err := database.NewClientWithResponses("http://" + node.Address)
-	if err != nil {
-		// Update status for all partitions this node is responsible for
-		c.updateNodePartitionsStatus(node, common.Unhealthy)
-		slog.Error("could not initalize database client", "error", err,
-			"node_address", node.Address)
+// updateRaftLeader records whether node leads the Raft group of a partition, the caller must hold the lock
+func (c *Controller) updateRaftLeader(node *common.Node, partitionStatus common.PartitionStatus) {
+	role := node.Partitions[partitionStatus.PartitionId]
+	role.IsMaster = partitionStatus.IsMaster
+	role.IsSyncing = partitionStatus.IsSyncing
+	node.Partitions[partitionStatus.PartitionId] = role
+
+	partition, exists := c.state.Partitions[partitionStatus.PartitionId]
+	if !exists || !partitionStatus.IsMaster || partition.MasterNodeId == node.Id {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), c.healthCheckTimeout)
+	slog.Info("raft leader changed", "partition_id", partition.Id,
+		"previous_master_node_id", partition.MasterNodeId, "master_node_id", node.Id)
+
+	partition.MasterNodeId = node.Id
+	c.state.Partitions[partition.Id] = partition
+
+	// The former leader's role is reset now rather than on its next health check
+	for i := range c.state.Nodes {
+		other := &c.state.Nodes[i]
+		if other.Id == node.Id {
+			continue
+		}
+
+		if otherRole, hosted := other.Partitions[partition.Id]; hosted && otherRole.IsMaster {
+			otherRole.IsMaster = false
+			other.Partitions[partition.Id] = otherRole
+		}
+	}
+}
+
+// nodeProbe is a health probe of a node, run outside the lock
+type nodeProbe struct {
+	nodeID  openapi_types.UUID
+	address string
+	client  database.ClientWithResponsesInterface
+	ok      bool
+	// statuses are the partition statuses the node reported
+	statuses []common.PartitionStatus
+}
+
+// run asks the node for the status of its partitions
+func (p *nodeProbe) run(timeout time.Duration) {
+	if p.client == nil {
+		client, err := database.NewClientWithResponses("http://" + p.address)
+		if err != nil {
+			slog.Error("could not initalize database client", "error", err,
+				"node_address", p.address)
+			return
+		}
+		p.client = client
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	resp, err := client.GetClusterStateWithResponse(ctx)
+	resp, err := p.client.GetPartitionsStatusWithResponse(ctx)
 	if err != nil {
-		// Update status for all partitions this node is responsible for
-		c.updateNodePartitionsStatus(node, common.Unhealthy)
-		slog.Error("could not get state", "node_address", node.Address, "error", err)
+		slog.Error("could not get partitions status", "node_address", p.address, "error", err)
 		return
 	}
 
-	if resp.StatusCode() != 200 {
-		// Update status for all partitions this node is responsible for
-		c.updateNodePartitionsStatus(node, common.Unhealthy)
-		slog.Error("state response non 200",
-			"node_address", node.Address, "status_code", resp.StatusCode())
+	if resp.JSON200 == nil {
+		slog.Error("partitions status response non 200",
+			"node_address", p.address, "status_code", resp.StatusCode())
 		return
 	}
 
-	// Update status for all partitions this node is responsible for
-	c.updateNodePartitionsStatus(node, common.Healthy)
+	p.ok, p.statuses = true, *resp.JSON200
 }
 
 func (c *Controller) StopWatcher() {
 	c.ticker.Stop()
 	close(c.stopWorker)
+
+	if c.raft != nil {
+		if err := c.raft.Stop(); err != nil {
+			slog.Error("could not stop controller raft node", "error", err)
+		}
+	}
+}
+
+// RegisterNodeByAddress registers a new node by its address, the labels and the weight of a known node are updated
+func (c *Controller) RegisterNodeByAddress(address string, labels *common.NodeLabels, weight *float64) (uuid.UUID, error) {
+	id, version, err := c.registerNodeByAddress(address, labels, weight)
+	if err != nil {
+		return uuid.Max, err
+	}
+
+	return id, c.awaitChange(version)
 }
 
-// RegisterNodeByAddress registers a new node by its address
-func (c *Controller) RegisterNodeByAddress(address string) (uuid.UUID, error) {
+// registerNodeByAddress registers a node, returning the version of the state to wait for
+func (c *Controller) registerNodeByAddress(address string, labels *common.NodeLabels, weight *float64) (uuid.UUID, int64, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	_, found := lo.Find(c.state.Nodes, func(n common.Node) bool {
+	if !c.leadingLocked() {
+		return uuid.Max, 0, ErrNotLeader
+	}
+
+	// A node restarting on a known address keeps its identity, so that the
+	// partitions it recovers from disk stay assigned to it
+	if idx := slices.IndexFunc(c.state.Nodes, func(n common.Node) bool {
 		return n.Address == address
-	})
-	if found {
-		return uuid.Max, errors.New("node already exists")
+	}); idx >= 0 {
+		node := &c.state.Nodes[idx]
+		slog.Info("node re-registered", "node_id", node.Id, "node_address", address)
+		// A restarted node holds no state, patches of the version it acknowledged would fail
+		c.distribution.forget(node.Id)
+
+		if updateRegistration(node, labels, weight) {
+			c.commitStateLocked()
+		}
+
+		stateCopy := deepcopy.Copy(c.state).(common.State)
+		go c.dispatchNodeState([]lo.Tuple2[openapi_types.UUID, database.NodeState]{
+			lo.T2(node.Id, stateCopy),
+		})
+
+		return node.Id, c.state.Version, nil
+	}
+
+	if idx := slices.IndexFunc(c.state.UnRegisteredNodes, func(n common.Node) bool {
+		return n.Address == address
+	}); idx >= 0 {
+		if updateRegistration(&c.state.UnRegisteredNodes[idx], labels, weight) {
+			c.commitStateLocked()
+		}
+
+		return c.state.UnRegisteredNodes[idx].Id, c.state.Version, nil
 	}
 
 	id := uuid.New()
 	client, err := database.NewClientWithResponses("http://" + address)
 	if err != nil {
 		slog.Error("could not create database client", "node_address", address)
-		return uuid.Max, fmt.Errorf("could not create database client: %w", err)
+		return uuid.Max, 0, fmt.Errorf("could not create database client: %w", err)
 	}
 
 	c.nodeClients[id] = client
@@ -398,9 +748,22 @@ func (c *Controller) RegisterNodeByAddress(address string) (uuid.UUID, error) {
 		common.Node{
 			Address: address,
 			Id:      id,
+			Labels:  labels,
+			Weight:  weight,
 		})
+	c.commitStateLocked()
 
-	return id, nil
+	return id, c.state.Version, nil
+}
+
+// updateRegistration records the labels and the weight a node registered with, reporting whether they changed
+func updateRegistration(node *common.Node, labels *common.NodeLabels, weight *float64) bool {
+	if reflect.DeepEqual(node.Labels, labels) && reflect.DeepEqual(node.Weight, weight) {
+		return false
+	}
+
+	node.Labels, node.Weight = labels, weight
+	return true
 }
 
 func (c *Controller) generateVirtualNodesForPartition(partitionId string, count int) error {
@@ -422,52 +785,47 @@ func (c *Controller) generateVirtualNodesForPartition(partitionId string, count
 			PartitionId: partitionId,
 		})
 
-		slices.SortFunc(c.state.VirtualNodes, func(a common.VirtualNode, b common.VirtualNode) int {
-			return int(a.Hash - b.Hash)
-		})
+		sortVirtualNodes(c.state.VirtualNodes)
 	}
 
 	return nil
 }
 
-func (c *Controller) dispatchNodeState(nodeStateUpdates []lo.Tuple2[openapi_types.UUID, database.NodeState]) {
-	for _, update := range nodeStateUpdates {
-		nodeID, state := update.Unpack()
-		dbClient := c.nodeClients[nodeID]
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-		defer cancel()
-		resp, err := dbClient.UpdateNodeStateWithResponse(ctx, nodeID, database.UpdateNodeStateJSONRequestBody(state))
-		if err != nil || resp.StatusCode() != 200 {
-			slog.Error("could not update node status", "node_id", nodeID, "response_status_code", resp.StatusCode())
-		}
-	}
-}
-
-func (c *Controller) dispatchState() {
-	ctx := context.Background()
-	_, err := c.balancerClient.SetStateWithResponse(ctx, loadbalancer.SetStateJSONRequestBody(c.state))
+// SetReplicaNumber sets the replica number with proper locking
+func (c *Controller) SetReplicaCount(replicaNum int) error {
+	version, err := c.setReplicaCount(replicaNum)
 	if err != nil {
-		slog.Error("could not set state in load balancer", "error", err)
-		return
+		return err
 	}
+
+	return c.awaitChange(version)
 }
 
-// SetReplicaNumber sets the replica number with proper locking
-func (c *Controller) SetReplicaCount(replicaNum int) error {
+// setReplicaCount changes the replica count, returning the version of the state to wait for
+func (c *Controller) setReplicaCount(replicaNum int) (int64, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
+
+	if !c.leadingLocked() {
+		return 0, ErrNotLeader
+	}
+
+	if replicaNum < 0 {
+		return 0, fmt.Errorf("%w: replica count must not be negative", ErrInvalidRequest)
+	}
+
 	if replicaNum >= len(c.state.Nodes) {
-		return errors.New("replica count can not be equal or more than node count")
+		return 0, fmt.Errorf("%w: replica count can not be equal or more than node count", ErrNoCapacity)
+	}
 
+	if c.state.UsesRaft() && replicaNum != c.state.ReplicaCount {
+		return 0, fmt.Errorf("%w: raft groups can not change their members", ErrInvalidRequest)
 	}
 
 	c.state.ReplicaCount = replicaNum
-	return nil
-}
+	c.commitStateLocked()
 
-// selectPartitionsToRemove selects partitions to remove based on load balancing
-func (c *Controller) selectPartitionsToRemove(count int) []string {
-	return lo.Samples(lo.Keys(c.state.Partitions), count)
+	return c.state.Version, nil
 }
 
 // removeVirtualNodesForPartition removes all virtual nodes for a given partition
@@ -497,142 +855,27 @@ func (c *Controller) removePartitionFromNodes(partitionID string) {
 	}
 }
 
-// createMigrationRangesForNewPartition creates migration ranges when a new partition is added
-func (c *Controller) createMigrationRangesForNewPartition(newPartitionID string) {
-	// Sort virtual nodes by hash to ensure consistent ranges
-	slices.SortFunc(c.state.VirtualNodes, func(a common.VirtualNode, b common.VirtualNode) int {
-		return int(a.Hash - b.Hash)
-	})
-
-	// Find all virtual nodes for the new partition
-	newVNodes := lo.Filter(c.state.VirtualNodes, func(vn common.VirtualNode, _ int) bool {
-		return vn.PartitionId == newPartitionID
-	})
-
-	// For each virtual node in the new partition, create a migration range
-	for _, newVNode := range newVNodes {
-		// Find the previous virtual node in the ring
-		prevIdx := c.findPreviousVirtualNodeIndex(newVNode.Hash)
-		if prevIdx == -1 {
-			continue // Skip if no previous node found (shouldn't happen in a properly initialized ring)
-		}
-
-		prevVNode := c.state.VirtualNodes[prevIdx]
-
-		// Skip if the previous node is also from the new partition
-		if prevVNode.PartitionId == newPartitionID {
-			continue
-		}
-
-		// Create a migration range from the previous partition to the new one
-		migrationRange := common.MigrationRange{
-			Id:                openapi_types.UUID(uuid.New()),
-			RangeStart:        prevVNode.Hash,
-			RangeEnd:          newVNode.Hash,
-			SourcePartitionId: prevVNode.PartitionId,
-			TargetPartitionId: newPartitionID,
-			Status:            common.NotStarted,
-		}
-
-		// Add the migration range to the state
-		*c.state.MigrationRanges = append(*c.state.MigrationRanges, migrationRange)
-	}
-}
-
-// createMigrationRangesForRemovedPartition creates migration ranges when a partition is removed
-func (c *Controller) createMigrationRangesForRemovedPartition(removedPartitionID string) {
-	// Get all virtual nodes for the removed partition
-	removedVNodes := lo.Filter(c.state.VirtualNodes, func(vn common.VirtualNode, _ int) bool {
-		return vn.PartitionId == removedPartitionID
-	})
-
-	// Sort virtual nodes by hash
-	slices.SortFunc(c.state.VirtualNodes, func(a common.VirtualNode, b common.VirtualNode) int {
-		return int(a.Hash - b.Hash)
-	})
-
-	// For each virtual node in the removed partition, create a migration range
-	for _, removedVNode := range removedVNodes {
-		// Find the next virtual node in the ring that's not from the removed partition
-		nextVNode := c.findNextNonRemovedVirtualNode(removedVNode.Hash, removedPartitionID)
-		if nextVNode == nil {
-			continue // Skip if no suitable next node found
-		}
-
-		// Create a migration range from the removed partition to the next one
-		migrationRange := common.MigrationRange{
-			Id:                openapi_types.UUID(uuid.New()),
-			RangeStart:        removedVNode.Hash,
-			RangeEnd:          nextVNode.Hash,
-			SourcePartitionId: removedPartitionID,
-			TargetPartitionId: nextVNode.PartitionId,
-			Status:            common.NotStarted,
-		}
-
-		// Add the migration range to the state
-		*c.state.MigrationRanges = append(*c.state.MigrationRanges, migrationRange)
-	}
-}
-
-// findPreviousVirtualNodeIndex finds the index of the virtual node that comes before the given hash
-func (c *Controller) findPreviousVirtualNodeIndex(hash int64) int {
-	if len(c.state.VirtualNodes) <= 1 {
-		return -1
-	}
-
-	// Find the index of the first node with hash >= the given hash
-	idx := sort.Search(len(c.state.VirtualNodes), func(i int) bool {
-		return c.state.VirtualNodes[i].Hash >= hash
-	})
-
-	// If we found the exact node or we're at the beginning, the previous node is the last one
-	if idx == 0 || (idx < len(c.state.VirtualNodes) && c.state.VirtualNodes[idx].Hash == hash) {
-		return len(c.state.VirtualNodes) - 1
-	}
-
-	// Otherwise, the previous node is the one before the found index
-	return idx - 1
-}
-
-// findNextNonRemovedVirtualNode finds the next virtual node that's not in the removed partition
-func (c *Controller) findNextNonRemovedVirtualNode(hash int64, removedPartitionID string) *common.VirtualNode {
-	if len(c.state.VirtualNodes) <= 1 {
-		return nil
-	}
-
-	// Find the index of the first node with hash > the given hash
-	idx := sort.Search(len(c.state.VirtualNodes), func(i int) bool {
-		return c.state.VirtualNodes[i].Hash > hash
-	})
-
-	// If we're at the end, wrap around to the beginning
-	if idx == len(c.state.VirtualNodes) {
-		idx = 0
-	}
-
-	// Find the first node that's not in the removed partition
-	startIdx := idx
-	for {
-		if c.state.VirtualNodes[idx].PartitionId != removedPartitionID {
-			return &c.state.VirtualNodes[idx]
-		}
-
-		idx = (idx + 1) % len(c.state.VirtualNodes)
-		if idx == startIdx {
-			// We've gone all the way around and found no suitable node
-			return nil
-		}
-	}
-}
-
-func NewController(virtualNodeCount int, healthCheckInterval time.Duration, healthCheckTimeout time.Duration, balancerClient loadbalancer.ClientWithResponsesInterface) *Controller {
+func NewController(virtualNodeCount int, healthCheckInterval time.Duration, healthCheckTimeout time.Duration,
+	deadNodeTimeout time.Duration, placementPolicy PlacementPolicy, replicationMode common.ReplicationMode,
+	failureDetector FailureDetector, stateStore StateStore) *Controller {
 	return &Controller{
-		balancerClient:      balancerClient,
+		stateStore:          stateStore,
+		state:               common.State{ReplicationMode: &replicationMode},
 		startTime:           time.Now(),
 		healthCheckInterval: healthCheckInterval,
 		healthCheckTimeout:  healthCheckTimeout,
 		stopWorker:          make(chan int),
 		nodeClients:         make(map[uuid.UUID]database.ClientWithResponsesInterface),
+		balancerClients:     make(map[uuid.UUID]loadbalancer.ClientWithResponsesInterface),
 		virtualNodeCount:    virtualNodeCount,
+		deadNodeTimeout:     deadNodeTimeout,
+		unhealthySince:      make(map[uuid.UUID]time.Time),
+		failureDetector:     failureDetector,
+		placementPolicy:     placementPolicy,
+		reportedLoads:       make(map[uuid.UUID]map[string]common.PartitionStatus),
+		heartbeats:          make(map[uuid.UUID]time.Time),
+		fences:              make(map[string]int64),
+		distribution:        newStateDistribution(),
+		stateChanged:        make(chan struct{}),
 	}
 }
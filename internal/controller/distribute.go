@@ -0,0 +1,402 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/computer-technology-team/distributed-kvstore/api/loadbalancer"
+	"github.com/google/uuid"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+const (
+	// stateHistorySize is the number of dispatched state versions kept as bases of patches
+	stateHistorySize = 16
+	// dispatchTimeout bounds a single push of the state to a node or to a load balancer
+	dispatchTimeout = 5 * time.Second
+)
+
+// acknowledgement is the state version a receiver reported holding
+type acknowledgement struct {
+	version int64
+	// patchable reports whether the receiver holds the state this instance dispatched for the
+	// version, rather than one of a former leader or of a previous run with the same version
+	patchable bool
+}
+
+// stateDistribution tracks the state versions the nodes and the load balancers acknowledged
+type stateDistribution struct {
+	mu   sync.Mutex
+	acks map[uuid.UUID]acknowledgement
+	// sent are the last dispatched states keyed by version
+	sent map[int64]common.State
+}
+
+func newStateDistribution() *stateDistribution {
+	return &stateDistribution{
+		acks: make(map[uuid.UUID]acknowledgement),
+		sent: make(map[int64]common.State),
+	}
+}
+
+// record keeps a dispatched state as the base of later patches
+func (d *stateDistribution) record(state common.State) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sent[state.Version] = state
+	for version := range d.sent {
+		if version <= state.Version-stateHistorySize {
+			delete(d.sent, version)
+		}
+	}
+}
+
+// patchBase returns the state a receiver acknowledged when a patch of it can bring the
+// receiver to version
+func (d *stateDistribution) patchBase(receiverID uuid.UUID, version int64) (common.State, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ack, found := d.acks[receiverID]
+	if !found || !ack.patchable || ack.version <= 0 || ack.version >= version {
+		return common.State{}, false
+	}
+
+	base, found := d.sent[ack.version]
+	return base, found
+}
+
+// acknowledge records the version a receiver holds after it was sent version, versions only grow
+func (d *stateDistribution) acknowledge(receiverID uuid.UUID, sent, acked int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ack := acknowledgement{version: acked, patchable: acked == sent}
+	if current, found := d.acks[receiverID]; found && (current.version > ack.version ||
+		current.version == ack.version && current.patchable) {
+		return
+	}
+
+	d.acks[receiverID] = ack
+}
+
+// acknowledged returns the version a receiver acknowledged, 0 when it acknowledged none
+func (d *stateDistribution) acknowledged(receiverID uuid.UUID) int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.acks[receiverID].version
+}
+
+// versions returns the version every receiver acknowledged
+func (d *stateDistribution) versions() map[uuid.UUID]int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return lo.MapValues(d.acks, func(ack acknowledgement, _ uuid.UUID) int64 {
+		return ack.version
+	})
+}
+
+// reset drops every acknowledgement and dispatched state, receivers may have been sent other
+// states of the same versions by another instance meanwhile
+func (d *stateDistribution) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	clear(d.acks)
+	clear(d.sent)
+}
+
+// forget drops the acknowledgement of a receiver, which then gets the whole state again
+func (d *stateDistribution) forget(receiverID uuid.UUID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.acks, receiverID)
+}
+
+// retain drops the acknowledgements of the receivers not in receiverIDs
+func (d *stateDistribution) retain(receiverIDs []uuid.UUID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for receiverID := range d.acks {
+		if !lo.Contains(receiverIDs, receiverID) {
+			delete(d.acks, receiverID)
+		}
+	}
+}
+
+// highestAcknowledged returns the highest version any receiver acknowledged
+func (d *stateDistribution) highestAcknowledged() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return lo.Max(lo.Map(lo.Values(d.acks), func(ack acknowledgement, _ int) int64 {
+		return ack.version
+	}))
+}
+
+// DistributionProgress is how far the nodes and the load balancers are in applying the state
+type DistributionProgress struct {
+	Version int64
+	// Receivers counts the reachable nodes and load balancers, Acknowledged those holding Version
+	Receivers    int
+	Acknowledged int
+	// Lagging are the IDs of the nodes and load balancers that did not acknowledge Version
+	Lagging []openapi_types.UUID
+}
+
+// Converged reports whether every receiver holds the current version of the state
+func (p DistributionProgress) Converged() bool {
+	return p.Acknowledged == p.Receivers
+}
+
+// DistributionProgress returns how far the nodes and the load balancers are in applying the state
+func (c *Controller) DistributionProgress() DistributionProgress {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	progress := DistributionProgress{Version: c.state.Version}
+	receivers := slices.Concat(
+		lo.Map(c.state.Nodes, func(n common.Node, _ int) lo.Tuple2[uuid.UUID, common.Status] {
+			return lo.T2(n.Id, n.Status)
+		}),
+		lo.Map(lo.FromPtr(c.state.LoadBalancers), func(b common.LoadBalancer, _ int) lo.Tuple2[uuid.UUID, common.Status] {
+			return lo.T2(b.Id, b.Status)
+		}),
+	)
+
+	for _, receiver := range receivers {
+		receiverID, status := receiver.Unpack()
+		if status == common.Unhealthy {
+			continue
+		}
+
+		progress.Receivers++
+		if c.distribution.acknowledged(receiverID) >= c.state.Version {
+			progress.Acknowledged++
+		} else {
+			progress.Lagging = append(progress.Lagging, receiverID)
+		}
+	}
+
+	return progress
+}
+
+// AcknowledgedVersions returns the state version every node and load balancer acknowledged
+func (c *Controller) AcknowledgedVersions() map[uuid.UUID]int64 {
+	return c.distribution.versions()
+}
+
+// dispatchNodeState pushes states to nodes in parallel once they were committed, returning
+// once every push completed
+func (c *Controller) dispatchNodeState(nodeStateUpdates []lo.Tuple2[openapi_types.UUID, database.NodeState]) {
+	if len(nodeStateUpdates) == 0 {
+		return
+	}
+
+	version := lo.Max(lo.Map(nodeStateUpdates, func(update lo.Tuple2[openapi_types.UUID, database.NodeState], _ int) int64 {
+		return update.B.Version
+	}))
+	if !c.awaitCommitted(version) {
+		return
+	}
+
+	c.lock.RLock()
+	clients := make(map[uuid.UUID]database.ClientWithResponsesInterface, len(nodeStateUpdates))
+	for _, update := range nodeStateUpdates {
+		if client, found := c.nodeClients[update.A]; found {
+			clients[update.A] = client
+		}
+	}
+	c.lock.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, update := range nodeStateUpdates {
+		nodeID, state := update.Unpack()
+		client, found := clients[nodeID]
+		if !found {
+			slog.Error("no client to update node state", "node_id", nodeID)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.pushNodeState(nodeID, client, state)
+		}()
+	}
+	wg.Wait()
+}
+
+// pushNodeState sends a state to a node, as a patch of the version the node acknowledged
+// when this instance still has it and as the whole state otherwise
+func (c *Controller) pushNodeState(nodeID uuid.UUID, client database.ClientWithResponsesInterface, state common.State) {
+	c.distribution.record(state)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dispatchTimeout)
+	defer cancel()
+
+	if base, found := c.distribution.patchBase(nodeID, state.Version); found {
+		patch, err := common.DiffStates(base, state)
+		if err != nil {
+			slog.Error("could not diff states", "base_version", base.Version, "version", state.Version, "error", err)
+		} else {
+			resp, err := client.PatchNodeStateWithResponse(ctx, nodeID, patch)
+			if err == nil && resp.JSON200 != nil {
+				c.distribution.acknowledge(nodeID, state.Version, resp.JSON200.Version)
+				return
+			}
+			if err == nil {
+				err = fmt.Errorf("unexpected status code %d", resp.StatusCode())
+			}
+
+			// The node lost or never applied the base, e.g. it restarted in between
+			slog.Warn("could not patch node state, sending the whole state", "node_id", nodeID,
+				"base_version", patch.BaseVersion, "version", patch.Version, "error", err)
+		}
+	}
+
+	resp, err := client.UpdateNodeStateWithResponse(ctx, nodeID, database.UpdateNodeStateJSONRequestBody(state))
+	if err != nil {
+		slog.Error("could not update node state", "node_id", nodeID, "version", state.Version, "error", err)
+		return
+	}
+
+	if resp.JSON200 == nil {
+		slog.Error("could not update node state", "node_id", nodeID, "version", state.Version,
+			"response_status_code", resp.StatusCode())
+		return
+	}
+
+	c.distribution.acknowledge(nodeID, state.Version, resp.JSON200.Version)
+}
+
+// dispatchState pushes a state to the reachable load balancers
+func (c *Controller) dispatchState(state common.State) {
+	c.dispatchBalancerState(state, lo.FilterMap(lo.FromPtr(state.LoadBalancers), func(b common.LoadBalancer, _ int) (uuid.UUID, bool) {
+		return b.Id, b.Status != common.Unhealthy
+	}))
+}
+
+// dispatchBalancerState pushes a state to load balancers in parallel once it was committed,
+// returning once every push completed
+func (c *Controller) dispatchBalancerState(state common.State, balancerIDs []uuid.UUID) {
+	if len(balancerIDs) == 0 || !c.awaitCommitted(state.Version) {
+		return
+	}
+
+	c.lock.RLock()
+	clients := lo.PickByKeys(c.balancerClients, balancerIDs)
+	c.lock.RUnlock()
+
+	var wg sync.WaitGroup
+	for balancerID, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.pushBalancerState(balancerID, client, state)
+		}()
+	}
+	wg.Wait()
+}
+
+// pushBalancerState sends a state to a load balancer, as a patch when possible like pushNodeState
+func (c *Controller) pushBalancerState(balancerID uuid.UUID, client loadbalancer.ClientWithResponsesInterface,
+	state common.State) {
+	c.distribution.record(state)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dispatchTimeout)
+	defer cancel()
+
+	if base, found := c.distribution.patchBase(balancerID, state.Version); found {
+		patch, err := common.DiffStates(base, state)
+		if err != nil {
+			slog.Error("could not diff states", "base_version", base.Version, "version", state.Version, "error", err)
+		} else {
+			resp, err := client.PatchStateWithResponse(ctx, patch)
+			if err == nil && resp.JSON200 != nil {
+				c.distribution.acknowledge(balancerID, state.Version, resp.JSON200.Version)
+				return
+			}
+			if err == nil {
+				err = fmt.Errorf("unexpected status code %d", resp.StatusCode())
+			}
+
+			slog.Warn("could not patch load balancer state, sending the whole state", "balancer_id", balancerID,
+				"base_version", patch.BaseVersion, "version", patch.Version, "error", err)
+		}
+	}
+
+	resp, err := client.SetStateWithResponse(ctx, loadbalancer.SetStateJSONRequestBody(state))
+	if err != nil {
+		slog.Error("could not set state in load balancer", "balancer_id", balancerID,
+			"version", state.Version, "error", err)
+		return
+	}
+
+	if resp.JSON200 == nil {
+		slog.Error("could not set state in load balancer", "balancer_id", balancerID,
+			"version", state.Version, "response_status_code", resp.StatusCode())
+		return
+	}
+
+	c.distribution.acknowledge(balancerID, state.Version, resp.JSON200.Version)
+}
+
+// distributeState resends the state to the reachable nodes and load balancers until they
+// acknowledge its version, e.g. after a push was lost while a node was briefly unreachable
+func (c *Controller) distributeState() {
+	c.lock.Lock()
+
+	c.distribution.retain(slices.Concat(
+		lo.Map(c.state.Nodes, func(n common.Node, _ int) uuid.UUID {
+			return n.Id
+		}),
+		lo.Map(lo.FromPtr(c.state.LoadBalancers), func(b common.LoadBalancer, _ int) uuid.UUID {
+			return b.Id
+		}),
+	))
+
+	// Receivers ignore states older than the one they hold, which they got from a former leader
+	// or from a previous run whose last changes were not persisted. The state has to outrank it.
+	if acked := c.distribution.highestAcknowledged(); acked > c.state.Version && c.leadingLocked() {
+		slog.Warn("receivers hold a newer state version, outranking it",
+			"version", c.state.Version, "acknowledged_version", acked)
+		c.state.Version = acked
+		c.commitStateLocked()
+	}
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	c.lock.Unlock()
+
+	laggingNodes := lo.Filter(stateCopy.Nodes, func(n common.Node, _ int) bool {
+		return n.Status != common.Unhealthy && c.distribution.acknowledged(n.Id) < stateCopy.Version
+	})
+	laggingBalancers := lo.FilterMap(lo.FromPtr(stateCopy.LoadBalancers), func(b common.LoadBalancer, _ int) (uuid.UUID, bool) {
+		return b.Id, b.Status != common.Unhealthy && c.distribution.acknowledged(b.Id) < stateCopy.Version
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.dispatchBalancerState(stateCopy, laggingBalancers)
+	}()
+
+	c.dispatchNodeState(lo.Map(laggingNodes, func(n common.Node, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+		return lo.T2(n.Id, stateCopy)
+	}))
+	wg.Wait()
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/google/uuid"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// RemoveNode starts draining a registered node: every partition it hosts gets a replacement
+// replica, and the node only leaves the cluster once the replacements caught up. Unregistered
+// nodes are dropped right away.
+func (c *Controller) RemoveNode(nodeID string) error {
+	version, err := c.removeNode(nodeID)
+	if err != nil {
+		return err
+	}
+
+	return c.awaitChange(version)
+}
+
+// removeNode starts draining a node, returning the version of the state to wait for
+func (c *Controller) removeNode(nodeID string) (int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.leadingLocked() {
+		return 0, ErrNotLeader
+	}
+
+	id, err := uuid.Parse(nodeID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid node id: %w", ErrInvalidRequest, err)
+	}
+
+	if idx := slices.IndexFunc(c.state.UnRegisteredNodes, func(n common.Node) bool { return n.Id == id }); idx >= 0 {
+		c.state.UnRegisteredNodes = slices.Delete(c.state.UnRegisteredNodes, idx, idx+1)
+		delete(c.nodeClients, id)
+		c.commitStateLocked()
+		return c.state.Version, nil
+	}
+
+	idx := slices.IndexFunc(c.state.Nodes, func(n common.Node) bool { return n.Id == id })
+	if idx < 0 {
+		return 0, ErrNodeNotFound
+	}
+
+	node := &c.state.Nodes[idx]
+	if isDraining(*node) {
+		return c.state.Version, nil
+	}
+
+	if c.state.IsResharding {
+		return 0, fmt.Errorf("%w: resharding is in progress", ErrClusterBusy)
+	}
+
+	// Raft groups apply membership changes directly, replacing a member is not safe
+	if c.state.UsesRaft() && len(node.Partitions) > 0 {
+		return 0, fmt.Errorf("%w: members of raft groups can not be replaced", ErrInvalidRequest)
+	}
+
+	// Every partition needs a replacement before anything changes
+	replacements := make(map[string]int, len(node.Partitions))
+	for partitionID := range node.Partitions {
+		// Moving off the partition already
+		if lo.Contains(leavingNodeIDs(c.state.Partitions[partitionID]), id) {
+			continue
+		}
+
+		replacementIdx, found := c.pickReplacementLocked(partitionID, id)
+		if !found {
+			return 0, fmt.Errorf("%w: no node can take over partition %s", ErrNoCapacity, partitionID)
+		}
+		replacements[partitionID] = replacementIdx
+	}
+
+	node.IsDraining = lo.ToPtr(true)
+
+	nodeIDs := make(map[openapi_types.UUID]struct{})
+	for partitionID, replacementIdx := range replacements {
+		c.moveReplicaLocked(partitionID, id, replacementIdx)
+
+		for _, partitionNodeID := range c.state.Partitions[partitionID].NodeIds {
+			nodeIDs[partitionNodeID] = struct{}{}
+		}
+	}
+
+	slog.Info("draining node", "node_id", id, "node_address", node.Address,
+		"partitions", len(replacements))
+
+	c.commitStateLocked()
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	go func() {
+		c.dispatchNodeState(lo.Map(lo.Keys(nodeIDs), func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+			return lo.T2(nodeID, stateCopy)
+		}))
+		c.dispatchState(stateCopy)
+	}()
+
+	return c.state.Version, nil
+}
+
+// isDraining reports whether a node is being removed
+func isDraining(node common.Node) bool {
+	return node.IsDraining != nil && *node.IsDraining
+}
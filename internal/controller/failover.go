@@ -0,0 +1,230 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// failoverJob is a partition whose master is unhealthy together with the replicas that may replace it
+type failoverJob struct {
+	partitionID string
+	epoch       int64
+	candidates  []common.Node
+}
+
+// failoverPartitions promotes a replica of every partition whose master node is unhealthy.
+// The healthy replica that applied the most operations is promoted so that the fewest
+// acknowledged writes are lost. Replicas still syncing miss operations they never received
+// and are not promoted, a partition without a caught-up replica is left without a master.
+func (c *Controller) failoverPartitions() {
+	jobs, statuses := c.pendingFailovers()
+	if len(jobs) == 0 {
+		return
+	}
+
+	promotions := make(map[string]openapi_types.UUID)
+	for _, job := range jobs {
+		var best *common.PartitionStatus
+		var bestNodeID openapi_types.UUID
+
+		for _, candidate := range job.candidates {
+			nodeStatuses, fetched := statuses[candidate.Id]
+			if !fetched {
+				var err error
+				nodeStatuses, err = c.fetchPartitionsStatus(candidate)
+				if err != nil {
+					slog.Error("could not get partitions status", "node_id", candidate.Id,
+						"node_address", candidate.Address, "error", err)
+				}
+				statuses[candidate.Id] = nodeStatuses
+			}
+
+			status, found := nodeStatuses[job.partitionID]
+			if !found || status.IsSyncing {
+				continue
+			}
+
+			if best == nil || status.LastAppliedOperationId > best.LastAppliedOperationId {
+				best = &status
+				bestNodeID = candidate.Id
+			}
+		}
+
+		if best == nil {
+			slog.Error("no caught-up replica available to promote, partition left without a master",
+				"partition_id", job.partitionID, "candidates", len(job.candidates))
+			continue
+		}
+
+		promotions[job.partitionID] = bestNodeID
+	}
+
+	if len(promotions) == 0 {
+		return
+	}
+
+	c.lock.Lock()
+
+	promoted := 0
+	nodeIDs := make(map[openapi_types.UUID]struct{})
+	for _, job := range jobs {
+		newMasterID, found := promotions[job.partitionID]
+		if !found {
+			continue
+		}
+
+		partition, found := c.state.Partitions[job.partitionID]
+		// The partition changed since the candidates were collected
+		if !found || partition.Epoch != job.epoch || c.isNodeHealthy(partition.MasterNodeId) {
+			continue
+		}
+
+		c.promoteMaster(job.partitionID, newMasterID)
+		promoted++
+
+		for _, nodeID := range c.state.Partitions[job.partitionID].NodeIds {
+			if c.isNodeHealthy(nodeID) {
+				nodeIDs[nodeID] = struct{}{}
+			}
+		}
+	}
+
+	if promoted > 0 {
+		c.commitStateLocked()
+	}
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	c.lock.Unlock()
+
+	if len(nodeIDs) == 0 {
+		return
+	}
+
+	c.dispatchNodeState(lo.Map(lo.Keys(nodeIDs), func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+		return lo.T2(nodeID, stateCopy)
+	}))
+	c.dispatchState(stateCopy)
+}
+
+// pendingFailovers returns the partitions whose master node is unhealthy together with their
+// healthy replicas that are not syncing, Raft groups elect a new leader on their own and
+// never fail over. It also returns the partition statuses
+// of the candidates that sent a heartbeat within the health check interval, the other
+// candidates are asked once for the status of all of their partitions.
+func (c *Controller) pendingFailovers() ([]failoverJob, map[openapi_types.UUID]map[string]common.PartitionStatus) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.state.UsesRaft() {
+		return nil, nil
+	}
+
+	now := time.Now()
+	statuses := make(map[openapi_types.UUID]map[string]common.PartitionStatus)
+
+	var jobs []failoverJob
+	for partitionID, partition := range c.state.Partitions {
+		if c.isNodeHealthy(partition.MasterNodeId) {
+			continue
+		}
+
+		candidates := lo.Filter(c.state.Nodes, func(n common.Node, _ int) bool {
+			return n.Id != partition.MasterNodeId && n.Status == common.Healthy &&
+				lo.Contains(partition.NodeIds, n.Id) && !n.Partitions[partitionID].IsSyncing
+		})
+
+		for _, candidate := range candidates {
+			if c.heartbeatFreshLocked(candidate.Id, now) {
+				statuses[candidate.Id] = maps.Clone(c.reportedLoads[candidate.Id])
+			}
+		}
+
+		jobs = append(jobs, failoverJob{
+			partitionID: partitionID,
+			epoch:       partition.Epoch,
+			candidates:  candidates,
+		})
+	}
+
+	return jobs, statuses
+}
+
+// isNodeHealthy reports whether a registered node passed its last health check, the caller must hold the lock
+func (c *Controller) isNodeHealthy(nodeID openapi_types.UUID) bool {
+	return lo.ContainsBy(c.state.Nodes, func(n common.Node) bool {
+		return n.Id == nodeID && n.Status == common.Healthy
+	})
+}
+
+// promoteMaster makes nodeID the master of a partition and bumps its epoch in the partition
+// and all of its roles, the previous master is demoted to a syncing replica. The caller must hold the lock.
+func (c *Controller) promoteMaster(partitionID string, nodeID openapi_types.UUID) {
+	partition := c.state.Partitions[partitionID]
+	oldMasterID := partition.MasterNodeId
+
+	partition.MasterNodeId = nodeID
+	partition.Epoch++
+	c.state.Partitions[partitionID] = partition
+	delete(c.fences, partitionID)
+
+	for i := range c.state.Nodes {
+		node := &c.state.Nodes[i]
+		if _, hosts := node.Partitions[partitionID]; !hosts {
+			continue
+		}
+
+		// Every role carries the new epoch so that replicas reject operations
+		// still sent by the previous master
+		role := node.Partitions[partitionID]
+		role.Epoch = partition.Epoch
+
+		switch node.Id {
+		case nodeID:
+			role.IsMaster = true
+			role.IsSyncing = false
+		case oldMasterID:
+			role.IsMaster = false
+			role.IsSyncing = true
+		}
+
+		node.Partitions[partitionID] = role
+	}
+
+	slog.Info("promoted new partition master", "partition_id", partitionID,
+		"old_master_node_id", oldMasterID, "new_master_node_id", nodeID, "epoch", partition.Epoch)
+}
+
+// fetchPartitionsStatus returns the status of every partition hosted by node keyed by partition ID
+func (c *Controller) fetchPartitionsStatus(node common.Node) (map[string]common.PartitionStatus, error) {
+	c.lock.RLock()
+	client, found := c.nodeClients[node.Id]
+	c.lock.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("no database client for node %s", node.Id)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.healthCheckTimeout)
+	defer cancel()
+
+	resp, err := client.GetPartitionsStatusWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get partitions status: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("partitions status returned status %d", resp.StatusCode())
+	}
+
+	return lo.SliceToMap(*resp.JSON200, func(status common.PartitionStatus) (string, common.PartitionStatus) {
+		return status.PartitionId, status
+	}), nil
+}
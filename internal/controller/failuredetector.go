@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+const (
+	// FailureDetectorConsecutive suspects a node after a number of failed probes in a row
+	FailureDetectorConsecutive = "consecutive"
+	// FailureDetectorPhiAccrual suspects a node once the time since its last successful probe
+	// is unlikely given the intervals between its previous successful probes
+	FailureDetectorPhiAccrual = "phi"
+
+	// phiWindowSize is the number of intervals between successful probes the phi accrual
+	// detector estimates their distribution from
+	phiWindowSize = 100
+)
+
+// FailureDetector decides from the outcomes of the health probes of a node whether it failed.
+// Its methods are called with the controller lock held.
+type FailureDetector interface {
+	// Report records the outcome of a probe of a node
+	Report(nodeID uuid.UUID, ok bool, at time.Time)
+	// Suspect reports whether a node is considered failed
+	Suspect(nodeID uuid.UUID, now time.Time) bool
+	// Forget drops the probe history of a node
+	Forget(nodeID uuid.UUID)
+}
+
+// NewFailureDetector returns the failure detector of the controller configuration. The
+// consecutive detector uses failureThreshold, the phi accrual detector phiThreshold and the
+// interval the nodes are probed at.
+func NewFailureDetector(kind string, failureThreshold int, phiThreshold float64,
+	probeInterval time.Duration) (FailureDetector, error) {
+	switch kind {
+	case FailureDetectorConsecutive:
+		if failureThreshold < 1 {
+			return nil, fmt.Errorf("failure threshold must be at least 1, got %d", failureThreshold)
+		}
+		return &consecutiveFailureDetector{
+			threshold: failureThreshold,
+			failures:  make(map[uuid.UUID]int),
+		}, nil
+	case FailureDetectorPhiAccrual:
+		if phiThreshold <= 0 {
+			return nil, fmt.Errorf("phi threshold must be greater than 0, got %v", phiThreshold)
+		}
+		return &phiAccrualFailureDetector{
+			threshold: phiThreshold,
+			// Probes run on a fixed interval, a floor on the deviation keeps a single late
+			// probe from looking like a failure
+			minStdDev: max(probeInterval.Seconds()/4, 0.1),
+			histories: make(map[uuid.UUID]*probeHistory),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown failure detector %q", kind)
+	}
+}
+
+// consecutiveFailureDetector suspects a node after threshold failed probes in a row
+type consecutiveFailureDetector struct {
+	threshold int
+	failures  map[uuid.UUID]int
+}
+
+// Report implements FailureDetector.
+func (d *consecutiveFailureDetector) Report(nodeID uuid.UUID, ok bool, _ time.Time) {
+	if ok {
+		delete(d.failures, nodeID)
+		return
+	}
+
+	d.failures[nodeID]++
+}
+
+// Suspect implements FailureDetector.
+func (d *consecutiveFailureDetector) Suspect(nodeID uuid.UUID, _ time.Time) bool {
+	return d.failures[nodeID] >= d.threshold
+}
+
+// Forget implements FailureDetector.
+func (d *consecutiveFailureDetector) Forget(nodeID uuid.UUID) {
+	delete(d.failures, nodeID)
+}
+
+// probeHistory is the successful probes of a node the phi accrual detector learned from
+type probeHistory struct {
+	lastSuccess time.Time
+	// lastFailed reports whether the last probe failed, a node never probed successfully
+	// is suspected from it
+	lastFailed bool
+	// intervals are the last seconds elapsed between successful probes
+	intervals []float64
+}
+
+// phiAccrualFailureDetector implements the phi accrual failure detector of Hayashibara et
+// al.: phi grows with the time since the last successful probe, scaled by the distribution
+// of the intervals between successful probes, and a node is suspected once it exceeds the threshold
+type phiAccrualFailureDetector struct {
+	threshold float64
+	minStdDev float64
+	histories map[uuid.UUID]*probeHistory
+}
+
+// Report implements FailureDetector.
+func (d *phiAccrualFailureDetector) Report(nodeID uuid.UUID, ok bool, at time.Time) {
+	history, found := d.histories[nodeID]
+	if !found {
+		history = &probeHistory{}
+		d.histories[nodeID] = history
+	}
+
+	history.lastFailed = !ok
+	if !ok {
+		return
+	}
+
+	if !history.lastSuccess.IsZero() {
+		history.intervals = append(history.intervals, at.Sub(history.lastSuccess).Seconds())
+		if len(history.intervals) > phiWindowSize {
+			history.intervals = history.intervals[1:]
+		}
+	}
+	history.lastSuccess = at
+}
+
+// Suspect implements FailureDetector.
+func (d *phiAccrualFailureDetector) Suspect(nodeID uuid.UUID, now time.Time) bool {
+	history, found := d.histories[nodeID]
+	if !found {
+		return false
+	}
+
+	if len(history.intervals) == 0 {
+		return history.lastFailed
+	}
+
+	return d.phi(history, now) > d.threshold
+}
+
+// phi returns the suspicion level of a node, -log10 of the probability that a successful
+// probe still follows after the time elapsed since the last one
+func (d *phiAccrualFailureDetector) phi(history *probeHistory, now time.Time) float64 {
+	mean := lo.Sum(history.intervals) / float64(len(history.intervals))
+
+	var variance float64
+	for _, interval := range history.intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+	stdDev := max(math.Sqrt(variance/float64(len(history.intervals))), d.minStdDev)
+
+	// Logistic approximation of the normal distribution's cumulative distribution function
+	y := (now.Sub(history.lastSuccess).Seconds() - mean) / stdDev
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+	if y > 0 {
+		return -math.Log10(e / (1 + e))
+	}
+
+	return -math.Log10(1 - 1/(1+e))
+}
+
+// Forget implements FailureDetector.
+func (d *phiAccrualFailureDetector) Forget(nodeID uuid.UUID) {
+	delete(d.histories, nodeID)
+}
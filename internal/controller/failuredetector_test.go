@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPhiAccrualFailureDetector(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nodeID := uuid.New()
+
+	regular := []bool{true, true, true, true, true, true, true, true, true, true}
+
+	tests := []struct {
+		name string
+		// probes are the outcomes of probes one second apart
+		probes      []bool
+		forget      bool
+		sinceLast   time.Duration
+		wantSuspect bool
+	}{
+		{name: "never probed", sinceLast: time.Hour},
+		{name: "only failed probes", probes: []bool{false, false}, wantSuspect: true},
+		{name: "single successful probe", probes: []bool{true}, sinceLast: time.Minute},
+		{name: "probe on time", probes: regular, sinceLast: time.Second},
+		{name: "probe slightly late", probes: regular, sinceLast: 1500 * time.Millisecond},
+		{name: "probes missing", probes: regular, sinceLast: 3 * time.Second, wantSuspect: true},
+		{name: "single failed probe", probes: append(regular, false), sinceLast: time.Second},
+		{name: "forgotten", probes: regular, forget: true, sinceLast: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector, err := NewFailureDetector(FailureDetectorPhiAccrual, 0, 8, time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			last := start
+			for i, ok := range tt.probes {
+				at := start.Add(time.Duration(i) * time.Second)
+				detector.Report(nodeID, ok, at)
+				if ok {
+					last = at
+				}
+			}
+
+			if tt.forget {
+				detector.Forget(nodeID)
+			}
+
+			if got := detector.Suspect(nodeID, last.Add(tt.sinceLast)); got != tt.wantSuspect {
+				t.Errorf("got suspect %v, want %v", got, tt.wantSuspect)
+			}
+		})
+	}
+}
+
+func TestNewFailureDetector(t *testing.T) {
+	tests := []struct {
+		name             string
+		kind             string
+		failureThreshold int
+		phiThreshold     float64
+		wantErr          bool
+	}{
+		{name: "consecutive", kind: FailureDetectorConsecutive, failureThreshold: 3},
+		{name: "consecutive without threshold", kind: FailureDetectorConsecutive, wantErr: true},
+		{name: "phi", kind: FailureDetectorPhiAccrual, phiThreshold: 8},
+		{name: "phi without threshold", kind: FailureDetectorPhiAccrual, wantErr: true},
+		{name: "unknown", kind: "gossip", failureThreshold: 3, phiThreshold: 8, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFailureDetector(tt.kind, tt.failureThreshold, tt.phiThreshold, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	controllerAPI "github.com/computer-technology-team/distributed-kvstore/api/controller"
+	"github.com/computer-technology-team/distributed-kvstore/internal/raft"
+	"github.com/google/uuid"
+	"github.com/mohae/deepcopy"
+	"github.com/samber/lo"
+)
+
+var (
+	// ErrNotLeader is returned for changes of the state requested from an instance that does not lead
+	ErrNotLeader = errors.New("controller instance is not the leader")
+	// ErrNoLeader is returned when no controller instance is known to lead
+	ErrNoLeader = errors.New("no controller instance leads the cluster")
+	// ErrRaftDisabled is returned for Raft requests to a controller running as a single instance
+	ErrRaftDisabled = errors.New("controller instance does not run raft")
+)
+
+// stateProposalTimeout bounds the time a state is waited on to be committed by a majority of the instances
+const stateProposalTimeout = 5 * time.Second
+
+// HAOptions configures the Raft group replicating the state among the controller instances
+type HAOptions struct {
+	// Address the other instances reach this one at
+	Address string
+	// Peers are the addresses of the other instances
+	Peers []string
+	// Dir keeps the Raft log, empty keeps it in memory only
+	Dir               string
+	HeartbeatInterval time.Duration
+	ElectionTimeout   time.Duration
+	// SnapshotThreshold is the number of committed states after which the Raft log is
+	// replaced with a snapshot of the last one
+	SnapshotThreshold int64
+}
+
+// LeaderInfo describes the controller instance leading the cluster
+type LeaderInfo struct {
+	// Address of the leading instance, empty when unknown
+	Address string
+	// Self reports whether this instance leads
+	Self bool
+}
+
+// instanceID derives the Raft ID of a controller instance from its address, so that
+// every instance agrees on the IDs of its peers without exchanging them
+func instanceID(address string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("kvstore-controller://"+address))
+}
+
+// raftTransport sends the Raft requests of the controller instances through the controller API
+type raftTransport struct{}
+
+func (raftTransport) RequestVote(ctx context.Context, peer raft.Peer,
+	req common.RaftVoteRequest) (common.RaftVoteResponse, error) {
+	client, err := controllerAPI.NewClientWithResponses("http://" + peer.Address)
+	if err != nil {
+		return common.RaftVoteResponse{}, fmt.Errorf("could not create peer client: %w", err)
+	}
+
+	resp, err := client.PostRaftVoteWithResponse(ctx, req)
+	if err != nil {
+		return common.RaftVoteResponse{}, fmt.Errorf("could not request vote: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return common.RaftVoteResponse{}, fmt.Errorf("peer returned status %d", resp.StatusCode())
+	}
+
+	return *resp.JSON200, nil
+}
+
+func (raftTransport) AppendEntries(ctx context.Context, peer raft.Peer,
+	req common.RaftAppendRequest) (common.RaftAppendResponse, error) {
+	client, err := controllerAPI.NewClientWithResponses("http://" + peer.Address)
+	if err != nil {
+		return common.RaftAppendResponse{}, fmt.Errorf("could not create peer client: %w", err)
+	}
+
+	resp, err := client.PostRaftAppendWithResponse(ctx, req)
+	if err != nil {
+		return common.RaftAppendResponse{}, fmt.Errorf("could not append entries: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return common.RaftAppendResponse{}, fmt.Errorf("peer returned status %d", resp.StatusCode())
+	}
+
+	return *resp.JSON200, nil
+}
+
+func (raftTransport) InstallSnapshot(ctx context.Context, peer raft.Peer,
+	req common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error) {
+	client, err := controllerAPI.NewClientWithResponses("http://" + peer.Address)
+	if err != nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("could not create peer client: %w", err)
+	}
+
+	resp, err := client.PostRaftSnapshotWithResponse(ctx, req)
+	if err != nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("could not install snapshot: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("peer returned status %d", resp.StatusCode())
+	}
+
+	return *resp.JSON200, nil
+}
+
+// StartHA joins the Raft group of the controller instances. Only the elected leader checks
+// the nodes and changes the state, every change is replicated to the other instances as a
+// full copy of the state. It must be called before the servers and the watcher start.
+func (c *Controller) StartHA(opts HAOptions) error {
+	storage := raft.NewMemoryStorage()
+	if opts.Dir != "" {
+		var err error
+		storage, err = raft.NewFileStorage(opts.Dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	peers := lo.Map(opts.Peers, func(address string, _ int) raft.Peer {
+		return raft.Peer{ID: instanceID(address), Address: address}
+	})
+
+	c.ha = opts
+	c.replicateCh = make(chan struct{}, 1)
+
+	node, err := raft.NewNode(raft.Config{
+		ID:                instanceID(opts.Address),
+		Peers:             peers,
+		Storage:           storage,
+		Transport:         raftTransport{},
+		Apply:             c.applyStateEntry,
+		Snapshot:          c.snapshotReplicatedState,
+		Restore:           c.restoreReplicatedState,
+		SnapshotThreshold: opts.SnapshotThreshold,
+		HeartbeatInterval: opts.HeartbeatInterval,
+		ElectionTimeout:   opts.ElectionTimeout,
+		Logger:            slog.With("component", "controller_raft"),
+	})
+	if err != nil {
+		return fmt.Errorf("could not start controller raft node: %w", err)
+	}
+
+	c.lock.Lock()
+	c.raft = node
+	c.lock.Unlock()
+
+	go c.replicateStates()
+
+	slog.Info("joined controller raft group", "address", opts.Address, "peers", opts.Peers)
+
+	return nil
+}
+
+// applyStateEntry records a state committed by the instances, followers adopt it right away
+// while the leader already holds it
+func (c *Controller) applyStateEntry(entry common.RaftEntry) any {
+	// No-op entry of a new leader
+	if entry.Data == nil {
+		return nil
+	}
+
+	var state common.State
+	if err := json.Unmarshal(*entry.Data, &state); err != nil {
+		slog.Error("could not decode replicated state", "index", entry.Index, "error", err)
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.replicated = &state
+	c.replicatedIndex = entry.Index
+	if c.leading {
+		// Wakes up the dispatches and watchers waiting for the state to be committed
+		c.notifyStateChangedLocked()
+		return nil
+	}
+
+	c.adoptReplicatedStateLocked()
+
+	return nil
+}
+
+// snapshotReplicatedState returns the last committed state for the Raft log to be compacted,
+// the states committed before it are superseded
+func (c *Controller) snapshotReplicatedState() ([]byte, int64, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	data, err := json.Marshal(c.replicated)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not encode state: %w", err)
+	}
+
+	return data, c.replicatedIndex, nil
+}
+
+// restoreReplicatedState records the committed state of a snapshot, loaded on startup or sent
+// by the leader, followers adopt it right away
+func (c *Controller) restoreReplicatedState(data []byte, index int64) error {
+	var state *common.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("could not decode state: %w", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.replicated = state
+	c.replicatedIndex = index
+	if c.leading {
+		return nil
+	}
+
+	c.adoptReplicatedStateLocked()
+
+	return nil
+}
+
+// committedStateLocked returns the last state committed by the instances, which is the state
+// itself for a single instance. Only committed states are handed out to the nodes and the
+// load balancers, a new leader may drop the others. The caller must hold the lock.
+func (c *Controller) committedStateLocked() (common.State, bool) {
+	if c.raft == nil {
+		return c.state, true
+	}
+
+	if c.replicated == nil {
+		return common.State{}, false
+	}
+
+	return *c.replicated, true
+}
+
+// awaitCommitted waits until the instances committed a state of at least version, reporting
+// false when this instance stops leading or the state is not committed within
+// stateProposalTimeout. The receivers get the state again from distributeState then.
+func (c *Controller) awaitCommitted(version int64) bool {
+	timeout := time.NewTimer(stateProposalTimeout)
+	defer timeout.Stop()
+
+	for {
+		c.lock.RLock()
+		if !c.leadingLocked() {
+			c.lock.RUnlock()
+			return false
+		}
+		if committed, found := c.committedStateLocked(); found && committed.Version >= version {
+			c.lock.RUnlock()
+			return true
+		}
+		changed := c.stateChanged
+		c.lock.RUnlock()
+
+		select {
+		case <-changed:
+		case <-timeout.C:
+			slog.Warn("state not committed in time, not dispatching it", "version", version)
+			return false
+		}
+	}
+}
+
+// awaitChange waits until the change that brought the state to version was committed, a
+// change dropped by a former leader or not committed in time reports ErrNotLeader
+func (c *Controller) awaitChange(version int64) error {
+	if !c.awaitCommitted(version) {
+		return fmt.Errorf("%w: state version %d was not committed", ErrNotLeader, version)
+	}
+
+	return nil
+}
+
+// adoptReplicatedStateLocked replaces the state with the last committed one, the caller must hold the lock
+func (c *Controller) adoptReplicatedStateLocked() {
+	if c.replicated == nil {
+		return
+	}
+
+	if err := c.addClientsLocked(*c.replicated); err != nil {
+		slog.Error("could not adopt replicated state", "version", c.replicated.Version, "error", err)
+		return
+	}
+
+	c.state = deepcopy.Copy(*c.replicated).(common.State)
+	c.notifyStateChangedLocked()
+
+	if c.stateStore == nil {
+		return
+	}
+
+	if err := c.stateStore.Save(c.state); err != nil {
+		slog.Error("could not persist state", "version", c.state.Version, "error", err)
+	}
+}
+
+// updateLeadership reports whether this instance leads and whether it just started to.
+// An instance elected by the Raft group only takes over once it applied every state
+// committed by previous leaders, and drops the changes it could not replicate when it
+// stops leading.
+func (c *Controller) updateLeadership() (leading bool, elected bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.raft == nil {
+		elected = !c.leading
+		c.leading = true
+		return true, elected
+	}
+
+	status := c.raft.Status()
+	leading = status.IsLeader && (status.Ready || (c.leading && c.leaderTerm == status.Term))
+
+	switch {
+	case leading && !c.leading:
+		c.leading = true
+		c.leaderTerm = status.Term
+		c.adoptReplicatedStateLocked()
+		// Replicates the state recovered from disk when the group committed none yet
+		c.commitStateLocked()
+		slog.Info("leading the controller cluster", "term", status.Term, "version", c.state.Version)
+		return true, true
+	case !leading && c.leading:
+		c.leading = false
+		c.leaderTerm = 0
+		c.adoptReplicatedStateLocked()
+		slog.Info("stopped leading the controller cluster", "term", status.Term)
+	}
+
+	return leading, false
+}
+
+// leadingLocked reports whether this instance may change the state, the caller must hold the lock
+func (c *Controller) leadingLocked() bool {
+	if c.raft == nil {
+		return true
+	}
+
+	status := c.raft.Status()
+	return c.leading && status.IsLeader && status.Term == c.leaderTerm
+}
+
+// Leader returns the controller instance leading the cluster
+func (c *Controller) Leader() LeaderInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.raft == nil {
+		return LeaderInfo{Address: c.ha.Address, Self: true}
+	}
+
+	if c.leadingLocked() {
+		return LeaderInfo{Address: c.ha.Address, Self: true}
+	}
+
+	// An elected instance that did not take over yet cannot serve requests either
+	leader := c.raft.Status().Leader
+	address, found := lo.Find(c.ha.Peers, func(address string) bool {
+		return instanceID(address) == leader
+	})
+	if !found {
+		return LeaderInfo{}
+	}
+
+	return LeaderInfo{Address: address}
+}
+
+// Address returns the address of this controller instance, empty for a single instance
+func (c *Controller) Address() string {
+	return c.ha.Address
+}
+
+// Instances returns the addresses of every controller instance
+func (c *Controller) Instances() []string {
+	if c.ha.Address == "" {
+		return nil
+	}
+
+	return append([]string{c.ha.Address}, c.ha.Peers...)
+}
+
+// replicateStateLocked hands a copy of the state to the replication loop, the caller must hold the lock
+func (c *Controller) replicateStateLocked() {
+	if c.raft == nil {
+		return
+	}
+
+	state := deepcopy.Copy(c.state).(common.State)
+	c.pendingState.Store(&state)
+
+	select {
+	case c.replicateCh <- struct{}{}:
+	default:
+	}
+}
+
+// replicateStates proposes the states changed by the leader in order. States changed while a
+// proposal is in flight are coalesced, only the latest one is proposed next.
+func (c *Controller) replicateStates() {
+	for {
+		select {
+		case <-c.replicateCh:
+		case <-c.stopWorker:
+			return
+		}
+
+		state := c.pendingState.Swap(nil)
+		if state == nil {
+			continue
+		}
+
+		err := c.proposeState(*state)
+		switch {
+		case err == nil:
+		case errors.Is(err, raft.ErrNotLeader), errors.Is(err, raft.ErrLeadershipLost), errors.Is(err, raft.ErrStopped):
+			slog.Warn("dropped state change of a former leader", "version", state.Version, "error", err)
+		default:
+			slog.Error("could not replicate state, retrying", "version", state.Version, "error", err)
+
+			// A newer state supersedes the failed one
+			c.pendingState.CompareAndSwap(nil, state)
+			time.Sleep(c.ha.HeartbeatInterval)
+
+			select {
+			case c.replicateCh <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// proposeState commits state through the Raft group and waits until it was applied
+func (c *Controller) proposeState(state common.State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not encode state: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stateProposalTimeout)
+	defer cancel()
+
+	if _, err := c.raft.Propose(ctx, data); err != nil {
+		return fmt.Errorf("could not propose state: %w", err)
+	}
+
+	return nil
+}
+
+// HandleRaftVote passes a vote request to the Raft node of this instance
+func (c *Controller) HandleRaftVote(req common.RaftVoteRequest) (common.RaftVoteResponse, error) {
+	if c.raft == nil {
+		return common.RaftVoteResponse{}, ErrRaftDisabled
+	}
+
+	return c.raft.HandleRequestVote(req)
+}
+
+// HandleRaftAppend passes the entries of the leader to the Raft node of this instance
+func (c *Controller) HandleRaftAppend(req common.RaftAppendRequest) (common.RaftAppendResponse, error) {
+	if c.raft == nil {
+		return common.RaftAppendResponse{}, ErrRaftDisabled
+	}
+
+	return c.raft.HandleAppendEntries(req)
+}
+
+// HandleRaftSnapshot passes the snapshot of the leader to the Raft node of this instance
+func (c *Controller) HandleRaftSnapshot(req common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error) {
+	if c.raft == nil {
+		return common.RaftSnapshotResponse{}, ErrRaftDisabled
+	}
+
+	return c.raft.HandleInstallSnapshot(req)
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// ReplicaLag is how far a node hosting a partition is behind the partition's master, from the
+// partition statuses they reported last
+type ReplicaLag struct {
+	NodeID    openapi_types.UUID
+	IsMaster  bool
+	IsSyncing bool
+	// Reported is false until the node reported the status of the partition
+	Reported               bool
+	LastAppliedOperationId int64
+	// Operations is the number of operations the master applied that the node did not
+	Operations int64
+	// Behind is how much earlier the master created the last operation the node applied than
+	// the last operation it applied itself
+	Behind time.Duration
+	// LastHeartbeat is when the node sent its last heartbeat, zero when it does not send any
+	LastHeartbeat time.Time
+}
+
+// ReceiveHeartbeat records a heartbeat of a node. A heartbeat counts as a successful health
+// probe and the partition statuses it carries are applied like the probed ones. Heartbeats
+// of nodes waiting for their admission are ignored.
+func (c *Controller) ReceiveHeartbeat(nodeID openapi_types.UUID, statuses []common.PartitionStatus) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.leadingLocked() {
+		return ErrNotLeader
+	}
+
+	idx := slices.IndexFunc(c.state.Nodes, func(n common.Node) bool { return n.Id == nodeID })
+	if idx < 0 {
+		if lo.ContainsBy(c.state.UnRegisteredNodes, func(n common.Node) bool { return n.Id == nodeID }) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+
+	now := time.Now()
+	c.heartbeats[nodeID] = now
+
+	recovered, changed := c.applyNodeReportLocked(&c.state.Nodes[idx], true, statuses, now)
+	if !changed {
+		return nil
+	}
+
+	c.commitStateLocked()
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	go func() {
+		if recovered {
+			c.dispatchNodeState([]lo.Tuple2[openapi_types.UUID, database.NodeState]{lo.T2(nodeID, stateCopy)})
+		}
+		c.dispatchState(stateCopy)
+	}()
+
+	return nil
+}
+
+// heartbeatFreshLocked reports whether a node sent a heartbeat within the health check
+// interval, its reported partition statuses are current then. The caller must hold the lock.
+func (c *Controller) heartbeatFreshLocked(nodeID openapi_types.UUID, now time.Time) bool {
+	heartbeat, found := c.heartbeats[nodeID]
+	return found && now.Sub(heartbeat) < c.healthCheckInterval
+}
+
+// ReplicationLag reports how far every node hosting a partition is behind its master
+func (c *Controller) ReplicationLag(partitionID string) []ReplicaLag {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	partition, found := c.state.Partitions[partitionID]
+	if !found {
+		return nil
+	}
+
+	master, masterReported := c.reportedLoads[partition.MasterNodeId][partitionID]
+
+	return lo.Map(partition.NodeIds, func(nodeID openapi_types.UUID, _ int) ReplicaLag {
+		lag := ReplicaLag{
+			NodeID:        nodeID,
+			IsMaster:      nodeID == partition.MasterNodeId,
+			LastHeartbeat: c.heartbeats[nodeID],
+		}
+
+		if node, found := lo.Find(c.state.Nodes, func(n common.Node) bool { return n.Id == nodeID }); found {
+			lag.IsSyncing = node.Partitions[partitionID].IsSyncing
+		}
+
+		status, reported := c.reportedLoads[nodeID][partitionID]
+		if !reported {
+			return lag
+		}
+
+		lag.Reported = true
+		lag.LastAppliedOperationId = status.LastAppliedOperationId
+		if !masterReported || lag.IsMaster {
+			return lag
+		}
+
+		lag.Operations = max(master.LastAppliedOperationId-status.LastAppliedOperationId, 0)
+		if master.LastAppliedAt != nil && status.LastAppliedAt != nil {
+			lag.Behind = max(master.LastAppliedAt.Sub(*status.LastAppliedAt), 0)
+		}
+
+		return lag
+	})
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// rebalanceTolerance is the imbalance left in place, as a fraction of the mean utilization, so
+// that fluctuating request rates do not move replicas back and forth
+const rebalanceTolerance = 0.1
+
+// NodeLoad is the load a node reported for the partitions it hosts
+type NodeLoad struct {
+	Weight      float64
+	KeyCount    int64
+	MemoryBytes int64
+	RequestRate float64
+	// Utilization is the share of the cluster load the node carries divided by its weight
+	Utilization float64
+}
+
+// nodeWeight returns the capacity of a node relative to the other nodes
+func nodeWeight(node common.Node) float64 {
+	if node.Weight == nil || *node.Weight <= 0 {
+		return 1
+	}
+
+	return *node.Weight
+}
+
+// recordLoadLocked keeps the partition statuses a node reported, the caller must hold the lock
+func (c *Controller) recordLoadLocked(nodeID openapi_types.UUID, statuses []common.PartitionStatus) {
+	c.reportedLoads[nodeID] = lo.SliceToMap(statuses, func(status common.PartitionStatus) (string, common.PartitionStatus) {
+		return status.PartitionId, status
+	})
+}
+
+// replicaLoadsLocked returns the share of the cluster load every replica carries, keyed by node
+// and partition. The load of a replica is the mean of its shares of the replicas, of the memory
+// and of the request rate, the latter two only once nodes reported any. The caller must hold the lock.
+func (c *Controller) replicaLoadsLocked() map[openapi_types.UUID]map[string]float64 {
+	var replicas int
+	var memory int64
+	var rate float64
+	for _, node := range c.state.Nodes {
+		for partitionID := range node.Partitions {
+			status := c.reportedLoads[node.Id][partitionID]
+			replicas++
+			memory += status.MemoryBytes
+			rate += status.RequestRate
+		}
+	}
+
+	loads := make(map[openapi_types.UUID]map[string]float64, len(c.state.Nodes))
+	for _, node := range c.state.Nodes {
+		loads[node.Id] = make(map[string]float64, len(node.Partitions))
+		for partitionID := range node.Partitions {
+			status := c.reportedLoads[node.Id][partitionID]
+
+			shares := []float64{1 / float64(replicas)}
+			if memory > 0 {
+				shares = append(shares, float64(status.MemoryBytes)/float64(memory))
+			}
+			if rate > 0 {
+				shares = append(shares, status.RequestRate/rate)
+			}
+
+			loads[node.Id][partitionID] = lo.Sum(shares) / float64(len(shares))
+		}
+	}
+
+	return loads
+}
+
+// utilizationsLocked returns the utilization of every node, the caller must hold the lock
+func (c *Controller) utilizationsLocked() map[openapi_types.UUID]float64 {
+	replicaLoads := c.replicaLoadsLocked()
+
+	return lo.SliceToMap(c.state.Nodes, func(node common.Node) (openapi_types.UUID, float64) {
+		return node.Id, lo.Sum(lo.Values(replicaLoads[node.Id])) / nodeWeight(node)
+	})
+}
+
+// NodeLoads returns the load of every registered node keyed by node ID
+func (c *Controller) NodeLoads() map[openapi_types.UUID]NodeLoad {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	utilizations := c.utilizationsLocked()
+
+	loads := make(map[openapi_types.UUID]NodeLoad, len(c.state.Nodes))
+	for _, node := range c.state.Nodes {
+		load := NodeLoad{Weight: nodeWeight(node), Utilization: utilizations[node.Id]}
+		for partitionID := range node.Partitions {
+			status := c.reportedLoads[node.Id][partitionID]
+			load.KeyCount += status.KeyCount
+			load.MemoryBytes += status.MemoryBytes
+			load.RequestRate += status.RequestRate
+		}
+		loads[node.Id] = load
+	}
+
+	return loads
+}
+
+// partitionSizeLocked returns the largest memory size the replicas of a partition reported, the
+// caller must hold the lock
+func (c *Controller) partitionSizeLocked(partition common.Partition) int64 {
+	return lo.Max(lo.Map(partition.NodeIds, func(nodeID openapi_types.UUID, _ int) int64 {
+		return c.reportedLoads[nodeID][partition.Id].MemoryBytes
+	}))
+}
+
+// selectPartitionsToRemove selects the partitions holding the least data, so that resharding
+// migrates as few keys as possible
+func (c *Controller) selectPartitionsToRemove(count int) []string {
+	partitions := lo.Values(c.state.Partitions)
+	slices.SortFunc(partitions, func(a, b common.Partition) int {
+		return cmp.Or(cmp.Compare(c.partitionSizeLocked(a), c.partitionSizeLocked(b)), cmp.Compare(a.Id, b.Id))
+	})
+
+	return lo.Map(partitions[:min(count, len(partitions))], func(p common.Partition, _ int) string {
+		return p.Id
+	})
+}
@@ -0,0 +1,455 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/google/uuid"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// migrationJob is a migration range together with the client of its source master and the
+// address of its target master needed to drive it
+type migrationJob struct {
+	migrationRange common.MigrationRange
+	sourceClient   database.ClientWithResponsesInterface
+	targetAddress  string
+}
+
+// migrationUpdate is the outcome of driving a migration range for one tick
+type migrationUpdate struct {
+	id       openapi_types.UUID
+	status   common.MigrationStatus
+	progress int64
+}
+
+// computeMigrationRanges diffs two consistent hashing rings and returns a migration range
+// for every arc of the ring whose owning partition changed
+func computeMigrationRanges(oldVNodes, newVNodes []common.VirtualNode) []common.MigrationRange {
+	if len(oldVNodes) == 0 || len(newVNodes) == 0 {
+		return nil
+	}
+
+	// Every arc between two consecutive boundaries of either ring is owned by
+	// a single partition in each ring
+	boundaries := make([]int64, 0, len(oldVNodes)+len(newVNodes))
+	for _, vnode := range oldVNodes {
+		boundaries = append(boundaries, vnode.Hash)
+	}
+	for _, vnode := range newVNodes {
+		boundaries = append(boundaries, vnode.Hash)
+	}
+	slices.Sort(boundaries)
+	boundaries = slices.Compact(boundaries)
+
+	var ranges []common.MigrationRange
+	for i, end := range boundaries {
+		start := boundaries[(i+len(boundaries)-1)%len(boundaries)]
+
+		source := ringOwner(oldVNodes, end)
+		target := ringOwner(newVNodes, end)
+		if source == target {
+			continue
+		}
+
+		// Merge with the previous range when the arcs are adjacent
+		if n := len(ranges); n > 0 && ranges[n-1].RangeEnd == start &&
+			ranges[n-1].SourcePartitionId == source && ranges[n-1].TargetPartitionId == target {
+			ranges[n-1].RangeEnd = end
+			continue
+		}
+
+		ranges = append(ranges, common.MigrationRange{
+			Id:                openapi_types.UUID(uuid.New()),
+			RangeStart:        start,
+			RangeEnd:          end,
+			SourcePartitionId: source,
+			TargetPartitionId: target,
+			Status:            common.NotStarted,
+		})
+	}
+
+	return ranges
+}
+
+// ringOwner returns the partition owning hash in a ring sorted by hash
+func ringOwner(vnodes []common.VirtualNode, hash int64) string {
+	idx := sort.Search(len(vnodes), func(i int) bool {
+		return vnodes[i].Hash >= hash
+	})
+
+	if idx == len(vnodes) {
+		idx = 0
+	}
+
+	return vnodes[idx].PartitionId
+}
+
+func sortVirtualNodes(vnodes []common.VirtualNode) {
+	slices.SortFunc(vnodes, func(a, b common.VirtualNode) int {
+		return cmp.Compare(a.Hash, b.Hash)
+	})
+}
+
+// advanceMigrations drives every migration range of an ongoing reshard one step
+// forward in parallel and commits the new ring once all of them completed
+func (c *Controller) advanceMigrations() {
+	c.commitPendingMigrations()
+
+	jobs := c.pendingMigrationJobs()
+	if jobs == nil {
+		return
+	}
+
+	updates := make([]migrationUpdate, len(jobs))
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			update, err := c.driveMigration(job)
+			if err != nil {
+				slog.Error("could not drive migration", "migration_id", job.migrationRange.Id,
+					"source_partition_id", job.migrationRange.SourcePartitionId,
+					"target_partition_id", job.migrationRange.TargetPartitionId, "error", err)
+			}
+			updates[i] = update
+		}()
+	}
+	wg.Wait()
+
+	c.lock.Lock()
+	if c.applyMigrationUpdates(updates) {
+		c.commitStateLocked()
+	}
+
+	if !c.migrationsCompleted() {
+		c.lock.Unlock()
+		return
+	}
+
+	stateCopy := c.commitResharding()
+	c.lock.Unlock()
+
+	slog.Info("resharding completed, committing new ring",
+		"migrations", len(lo.FromPtr(stateCopy.PendingMigrationCommits)))
+
+	c.dispatchNodeState(lo.Map(stateCopy.Nodes, func(n common.Node, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+		return lo.T2(n.Id, stateCopy)
+	}))
+	c.dispatchState(stateCopy)
+}
+
+// commitPendingMigrations asks the source masters of a completed reshard in parallel to delete
+// the keys they migrated, a commit stays pending until its source reported it done
+func (c *Controller) commitPendingMigrations() {
+	jobs := c.pendingMigrationCommits()
+	if len(jobs) == 0 {
+		return
+	}
+
+	done := make([]bool, len(jobs))
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := commitMigration(job); err != nil {
+				slog.Error("could not commit migration", "migration_id", job.migrationRange.Id,
+					"source_partition_id", job.migrationRange.SourcePartitionId, "error", err)
+				return
+			}
+			done[i] = true
+		}()
+	}
+	wg.Wait()
+
+	committed := lo.FilterMap(jobs, func(job migrationJob, i int) (openapi_types.UUID, bool) {
+		return job.migrationRange.Id, done[i]
+	})
+	if len(committed) == 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	pending := lo.Reject(lo.FromPtr(c.state.PendingMigrationCommits), func(m common.MigrationRange, _ int) bool {
+		return lo.Contains(committed, m.Id)
+	})
+	c.state.PendingMigrationCommits = nil
+	if len(pending) > 0 {
+		c.state.PendingMigrationCommits = &pending
+	}
+	c.commitStateLocked()
+}
+
+// pendingMigrationCommits returns the migrations of a completed reshard whose source
+// partitions did not delete the migrated keys yet
+func (c *Controller) pendingMigrationCommits() []migrationJob {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return lo.FilterMap(lo.FromPtr(c.state.PendingMigrationCommits), func(m common.MigrationRange, _ int) (migrationJob, bool) {
+		client, found := c.partitionMasterClient(m.SourcePartitionId)
+		if !found {
+			slog.Warn("master of migration source is unknown, not committing migration",
+				"migration_id", m.Id, "source_partition_id", m.SourcePartitionId)
+		}
+
+		return migrationJob{migrationRange: m, sourceClient: client}, found
+	})
+}
+
+// pendingMigrationJobs returns the migration ranges of an ongoing reshard, nil when there is none
+func (c *Controller) pendingMigrationJobs() []migrationJob {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if !c.state.IsResharding || c.state.MigrationRanges == nil {
+		return nil
+	}
+
+	jobs := make([]migrationJob, 0, len(*c.state.MigrationRanges))
+	for _, migrationRange := range *c.state.MigrationRanges {
+		client, _ := c.partitionMasterClient(migrationRange.SourcePartitionId)
+		jobs = append(jobs, migrationJob{
+			migrationRange: migrationRange,
+			sourceClient:   client,
+			targetAddress:  c.partitionMasterAddress(migrationRange.TargetPartitionId),
+		})
+	}
+
+	return jobs
+}
+
+// partitionMasterClient returns the client of the master node of a partition, the caller must hold the lock
+func (c *Controller) partitionMasterClient(partitionID string) (database.ClientWithResponsesInterface, bool) {
+	partition, found := c.state.Partitions[partitionID]
+	if !found {
+		return nil, false
+	}
+
+	client, found := c.nodeClients[partition.MasterNodeId]
+	return client, found
+}
+
+// partitionMasterAddress returns the address of the master node of a partition, the caller must hold the lock
+func (c *Controller) partitionMasterAddress(partitionID string) string {
+	partition, found := c.state.Partitions[partitionID]
+	if !found {
+		return ""
+	}
+
+	node, found := lo.Find(c.state.Nodes, func(n common.Node) bool {
+		return n.Id == partition.MasterNodeId
+	})
+	if !found {
+		return ""
+	}
+
+	return node.Address
+}
+
+// driveMigration starts a migration that is not running on its source master or polls its progress
+func (c *Controller) driveMigration(job migrationJob) (migrationUpdate, error) {
+	migrationRange := job.migrationRange
+	update := migrationUpdate{
+		id:       migrationRange.Id,
+		status:   migrationRange.Status,
+		progress: lo.FromPtr(migrationRange.Progress),
+	}
+
+	if migrationRange.Status == common.Completed {
+		return update, nil
+	}
+
+	if job.sourceClient == nil || job.targetAddress == "" {
+		return update, fmt.Errorf("master of source or target partition is unknown")
+	}
+	client := job.sourceClient
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.healthCheckTimeout)
+	defer cancel()
+
+	var status *database.MigrationTaskStatus
+
+	if migrationRange.Status == common.InProgress {
+		resp, err := client.GetMigrationStatusWithResponse(ctx, migrationRange.SourcePartitionId, migrationRange.Id)
+		if err != nil {
+			return update, fmt.Errorf("could not get migration status: %w", err)
+		}
+
+		if resp.JSON404 != nil {
+			// The source master lost track of the migration, e.g. it restarted
+			update.status = common.NotStarted
+			return update, nil
+		}
+
+		status = resp.JSON200
+	} else {
+		resp, err := client.StartMigrationWithResponse(ctx, migrationRange.SourcePartitionId, migrationTask(job))
+		if err != nil {
+			return update, fmt.Errorf("could not start migration: %w", err)
+		}
+
+		if resp.JSON409 != nil {
+			return update, fmt.Errorf("source rejected migration: %s", resp.JSON409.Error)
+		}
+
+		status = resp.JSON202
+	}
+
+	if status == nil {
+		return update, fmt.Errorf("unexpected migration response")
+	}
+
+	update.status = status.Status
+	update.progress = status.Progress
+
+	if status.Error != nil {
+		return update, fmt.Errorf("migration failed on source: %s", *status.Error)
+	}
+
+	return update, nil
+}
+
+func migrationTask(job migrationJob) database.MigrationTask {
+	return database.MigrationTask{
+		Id:                job.migrationRange.Id,
+		RangeStart:        job.migrationRange.RangeStart,
+		RangeEnd:          job.migrationRange.RangeEnd,
+		TargetPartitionId: job.migrationRange.TargetPartitionId,
+		TargetAddress:     job.targetAddress,
+	}
+}
+
+// commitMigration asks the source master to delete the keys it migrated
+func commitMigration(job migrationJob) error {
+	resp, err := job.sourceClient.CommitMigrationWithResponse(context.Background(),
+		job.migrationRange.SourcePartitionId, job.migrationRange.Id, migrationTask(job))
+	if err != nil {
+		return fmt.Errorf("could not commit migration: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return fmt.Errorf("commit returned status %d", resp.StatusCode())
+	}
+
+	slog.Info("committed migration", "migration_id", job.migrationRange.Id,
+		"source_partition_id", job.migrationRange.SourcePartitionId, "deleted_keys", resp.JSON200.Progress)
+
+	return nil
+}
+
+// applyMigrationUpdates records the outcome of a tick in the state and reports whether it
+// changed anything, the caller must hold the lock
+func (c *Controller) applyMigrationUpdates(updates []migrationUpdate) bool {
+	if !c.state.IsResharding || c.state.MigrationRanges == nil {
+		return false
+	}
+
+	changed := false
+
+	ranges := *c.state.MigrationRanges
+	for _, update := range updates {
+		idx := slices.IndexFunc(ranges, func(m common.MigrationRange) bool {
+			return m.Id == update.id
+		})
+		if idx == -1 {
+			continue
+		}
+
+		if ranges[idx].Status == update.status && lo.FromPtr(ranges[idx].Progress) == update.progress {
+			continue
+		}
+
+		ranges[idx].Status = update.status
+		ranges[idx].Progress = lo.ToPtr(update.progress)
+		changed = true
+	}
+
+	// Record which nodes are streaming a migration as the source master
+	for i := range c.state.Nodes {
+		node := &c.state.Nodes[i]
+
+		active := lo.FilterMap(ranges, func(m common.MigrationRange, _ int) (string, bool) {
+			partition, found := c.state.Partitions[m.SourcePartitionId]
+			return m.Id.String(), found && m.Status == common.InProgress && partition.MasterNodeId == node.Id
+		})
+
+		if slices.Equal(lo.FromPtr(node.ActiveMigrations), active) {
+			continue
+		}
+
+		node.ActiveMigrations = nil
+		if len(active) > 0 {
+			node.ActiveMigrations = &active
+		}
+		changed = true
+	}
+
+	return changed
+}
+
+// migrationsCompleted reports whether every migration range completed, the caller must hold the lock
+func (c *Controller) migrationsCompleted() bool {
+	if !c.state.IsResharding || c.state.MigrationRanges == nil {
+		return false
+	}
+
+	return lo.EveryBy(*c.state.MigrationRanges, func(m common.MigrationRange) bool {
+		return m.Status == common.Completed
+	})
+}
+
+// commitResharding ends the reshard: partitions left without virtual nodes are retired and
+// the migration ranges are cleared. The migrations whose source partitions survive are kept
+// as pending commits until the sources dropped the moved keys. The caller must hold the lock.
+func (c *Controller) commitResharding() common.State {
+	ringPartitions := lo.SliceToMap(c.state.VirtualNodes, func(vn common.VirtualNode) (string, struct{}) {
+		return vn.PartitionId, struct{}{}
+	})
+
+	commits := lo.Filter(*c.state.MigrationRanges, func(m common.MigrationRange, _ int) bool {
+		_, inRing := ringPartitions[m.SourcePartitionId]
+		return inRing
+	})
+	if len(commits) > 0 {
+		c.state.PendingMigrationCommits = &commits
+	}
+
+	for partitionID := range c.state.Partitions {
+		if _, inRing := ringPartitions[partitionID]; inRing {
+			partition := c.state.Partitions[partitionID]
+			partition.IsMigrating = nil
+			c.state.Partitions[partitionID] = partition
+			continue
+		}
+
+		c.removePartitionFromNodes(partitionID)
+		delete(c.state.Partitions, partitionID)
+		slog.Info("retired partition", "partition_id", partitionID)
+	}
+
+	for i := range c.state.Nodes {
+		c.state.Nodes[i].ActiveMigrations = nil
+	}
+
+	c.state.IsResharding = false
+	c.state.MigrationRanges = &[]common.MigrationRange{}
+	c.commitStateLocked()
+
+	return deepcopy.Copy(c.state).(common.State)
+}
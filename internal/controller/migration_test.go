@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+)
+
+func TestComputeMigrationRanges(t *testing.T) {
+	ring := func(vnodes ...common.VirtualNode) []common.VirtualNode {
+		return vnodes
+	}
+	vnode := func(hash int64, partitionID string) common.VirtualNode {
+		return common.VirtualNode{Hash: hash, PartitionId: partitionID}
+	}
+	migration := func(start, end int64, source, target string) common.MigrationRange {
+		return common.MigrationRange{RangeStart: start, RangeEnd: end, SourcePartitionId: source, TargetPartitionId: target}
+	}
+
+	tests := []struct {
+		name     string
+		old, new []common.VirtualNode
+		want     []common.MigrationRange
+	}{
+		{name: "no old ring", new: ring(vnode(10, "a"))},
+		{name: "no new ring", old: ring(vnode(10, "a"))},
+		{
+			name: "unchanged ring",
+			old:  ring(vnode(10, "a"), vnode(20, "b")),
+			new:  ring(vnode(10, "a"), vnode(20, "b")),
+		},
+		{
+			name: "added partition",
+			old:  ring(vnode(10, "a"), vnode(20, "b")),
+			new:  ring(vnode(10, "a"), vnode(15, "c"), vnode(20, "b")),
+			want: []common.MigrationRange{migration(10, 15, "b", "c")},
+		},
+		{
+			name: "removed partition",
+			old:  ring(vnode(10, "a"), vnode(20, "b"), vnode(30, "c")),
+			new:  ring(vnode(10, "a"), vnode(30, "c")),
+			want: []common.MigrationRange{migration(10, 20, "b", "c")},
+		},
+		{
+			name: "adjacent arcs are merged",
+			old:  ring(vnode(10, "a"), vnode(20, "b"), vnode(30, "b")),
+			new:  ring(vnode(10, "a"), vnode(30, "c")),
+			want: []common.MigrationRange{migration(10, 30, "b", "c")},
+		},
+		{
+			name: "arc wrapping around the ring",
+			old:  ring(vnode(10, "a"), vnode(20, "b")),
+			new:  ring(vnode(10, "a"), vnode(20, "b"), vnode(25, "c")),
+			want: []common.MigrationRange{migration(20, 25, "a", "c")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeMigrationRanges(tt.old, tt.new)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d migration ranges %v, want %d", len(got), got, len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				if got[i].Status != common.NotStarted {
+					t.Errorf("range %d: got status %q, want %q", i, got[i].Status, common.NotStarted)
+				}
+
+				// IDs are random
+				got[i].Id, got[i].Status = want.Id, want.Status
+				if got[i] != want {
+					t.Errorf("range %d: got %+v, want %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
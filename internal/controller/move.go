@@ -0,0 +1,295 @@
+package controller
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// handoverJob is a partition whose master is leaving it while the other nodes caught up,
+// together with the replicas that may take over
+type handoverJob struct {
+	partitionID string
+	epoch       int64
+	master      common.Node
+	candidates  []common.Node
+}
+
+// leavingNodeIDs returns the nodes a partition is moving off
+func leavingNodeIDs(partition common.Partition) []openapi_types.UUID {
+	if partition.LeavingNodeIds == nil {
+		return nil
+	}
+
+	return *partition.LeavingNodeIds
+}
+
+// moveReplicaLocked moves a partition from one node to another: the target is assigned the
+// partition as a syncing replica and the source leaves once the target caught up, the caller
+// must hold the lock
+func (c *Controller) moveReplicaLocked(partitionID string, fromNodeID openapi_types.UUID, toNodeIdx int) {
+	c.addReplicaLocked(partitionID, toNodeIdx)
+
+	partition := c.state.Partitions[partitionID]
+	partition.LeavingNodeIds = lo.ToPtr(append(leavingNodeIDs(partition), fromNodeID))
+	c.state.Partitions[partitionID] = partition
+
+	slog.Info("moving partition replica", "partition_id", partitionID,
+		"from_node_id", fromNodeID, "to_node_id", c.state.Nodes[toNodeIdx].Id)
+}
+
+// addReplicaLocked assigns a partition to a node as a syncing replica that pulls the master's
+// state, the caller must hold the lock
+func (c *Controller) addReplicaLocked(partitionID string, nodeIdx int) {
+	partition := c.state.Partitions[partitionID]
+	node := &c.state.Nodes[nodeIdx]
+
+	partition.NodeIds = append(partition.NodeIds, node.Id)
+	c.state.Partitions[partitionID] = partition
+
+	if node.Partitions == nil {
+		node.Partitions = map[string]common.PartitionRole{}
+	}
+	node.Partitions[partitionID] = common.PartitionRole{
+		IsSyncing: true,
+		Epoch:     partition.Epoch,
+	}
+
+	slog.Info("added partition replica", "partition_id", partitionID, "node_id", node.Id)
+}
+
+// advancePartitionMoves takes the leaving nodes off partitions whose other nodes caught up
+// and removes draining nodes that host no partition anymore. A leaving master is fenced
+// first and hands over once it applied the fence to a replica that applied every operation
+// it applied.
+func (c *Controller) advancePartitionMoves() {
+	jobs := c.pendingHandovers()
+
+	// Replicas are compared with the master they take over from
+	handovers := make(map[string]openapi_types.UUID)
+	statuses := make(map[openapi_types.UUID]map[string]common.PartitionStatus)
+	fetchStatuses := func(node common.Node) map[string]common.PartitionStatus {
+		nodeStatuses, fetched := statuses[node.Id]
+		if !fetched {
+			var err error
+			nodeStatuses, err = c.fetchPartitionsStatus(node)
+			if err != nil {
+				slog.Error("could not get partitions status", "node_id", node.Id,
+					"node_address", node.Address, "error", err)
+			}
+			statuses[node.Id] = nodeStatuses
+		}
+
+		return nodeStatuses
+	}
+
+	for _, job := range jobs {
+		masterStatus, found := fetchStatuses(job.master)[job.partitionID]
+		if !found {
+			continue
+		}
+
+		for _, candidate := range job.candidates {
+			status, found := fetchStatuses(candidate)[job.partitionID]
+			if found && status.LastAppliedOperationId >= masterStatus.LastAppliedOperationId {
+				handovers[job.partitionID] = candidate.Id
+				break
+			}
+		}
+	}
+
+	c.lock.Lock()
+
+	// Draining nodes emptied on this tick are sent the state without their partitions first
+	drainedNodeIDs := lo.FilterMap(c.state.Nodes, func(n common.Node, _ int) (openapi_types.UUID, bool) {
+		return n.Id, isDraining(n) && len(n.Partitions) == 0
+	})
+
+	changed := false
+	var fenced []string
+	nodeIDs := make(map[openapi_types.UUID]struct{})
+	for partitionID, partition := range c.state.Partitions {
+		leaving := leavingNodeIDs(partition)
+		if len(leaving) == 0 || !c.replacementsSyncedLocked(partition) {
+			continue
+		}
+
+		if lo.Contains(leaving, partition.MasterNodeId) && !c.state.UsesRaft() {
+			if !c.masterFencedLocked(partition) {
+				if c.isNodeHealthy(partition.MasterNodeId) {
+					c.fenceMasterLocked(partitionID)
+					fenced = append(fenced, partitionID)
+					nodeIDs[partition.MasterNodeId] = struct{}{}
+					changed = true
+				}
+				continue
+			}
+
+			newMasterID, found := handovers[partitionID]
+			job, jobFound := lo.Find(jobs, func(j handoverJob) bool { return j.partitionID == partitionID })
+			if !found || !jobFound || job.epoch != partition.Epoch {
+				continue
+			}
+
+			c.promoteMaster(partitionID, newMasterID)
+			partition = c.state.Partitions[partitionID]
+		}
+
+		// Raft groups elect a new leader once a leaving leader left them
+		partition.NodeIds = lo.Without(partition.NodeIds, leaving...)
+		partition.LeavingNodeIds = nil
+		c.state.Partitions[partitionID] = partition
+		changed = true
+
+		for i := range c.state.Nodes {
+			node := &c.state.Nodes[i]
+			if !lo.Contains(leaving, node.Id) {
+				continue
+			}
+
+			delete(node.Partitions, partitionID)
+			nodeIDs[node.Id] = struct{}{}
+		}
+
+		for _, nodeID := range partition.NodeIds {
+			nodeIDs[nodeID] = struct{}{}
+		}
+
+		slog.Info("nodes left partition", "partition_id", partitionID, "node_ids", leaving)
+	}
+
+	for _, nodeID := range drainedNodeIDs {
+		c.state.Nodes = slices.DeleteFunc(c.state.Nodes, func(n common.Node) bool { return n.Id == nodeID })
+		delete(c.nodeClients, nodeID)
+		c.failureDetector.Forget(nodeID)
+		delete(c.heartbeats, nodeID)
+		changed = true
+
+		slog.Info("removed drained node", "node_id", nodeID)
+	}
+
+	if !changed {
+		c.lock.Unlock()
+		return
+	}
+
+	c.commitStateLocked()
+	for _, partitionID := range fenced {
+		c.fences[partitionID] = c.state.Version
+	}
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	c.lock.Unlock()
+
+	c.dispatchNodeState(lo.Map(lo.Keys(nodeIDs), func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+		return lo.T2(nodeID, stateCopy)
+	}))
+	c.dispatchState(stateCopy)
+}
+
+// pendingHandovers returns the partitions whose healthy master is leaving them while the
+// other nodes caught up and that acknowledged being fenced, failover takes care of leaving
+// masters that are unhealthy
+func (c *Controller) pendingHandovers() []handoverJob {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.state.UsesRaft() {
+		return nil
+	}
+
+	var jobs []handoverJob
+	for _, partition := range c.state.Partitions {
+		leaving := leavingNodeIDs(partition)
+		if !lo.Contains(leaving, partition.MasterNodeId) || !c.replacementsSyncedLocked(partition) {
+			continue
+		}
+
+		master, found := lo.Find(c.state.Nodes, func(n common.Node) bool {
+			return n.Id == partition.MasterNodeId
+		})
+		if !found || master.Status != common.Healthy || !c.masterFencedLocked(partition) {
+			continue
+		}
+
+		// The fence version is lost with a change of leader, the master then has to hold a
+		// state at least as new as the current one
+		fenceVersion, known := c.fences[partition.Id]
+		if !known {
+			fenceVersion = c.state.Version
+		}
+
+		// The master accepts writes until it applied the fence, its last operation is
+		// only final afterwards
+		if c.distribution.acknowledged(master.Id) < fenceVersion {
+			continue
+		}
+
+		candidates := lo.Filter(c.state.Nodes, func(n common.Node, _ int) bool {
+			return lo.Contains(partition.NodeIds, n.Id) && !lo.Contains(leaving, n.Id) &&
+				n.Status == common.Healthy
+		})
+
+		jobs = append(jobs, handoverJob{
+			partitionID: partition.Id,
+			epoch:       partition.Epoch,
+			master:      master,
+			candidates:  candidates,
+		})
+	}
+
+	return jobs
+}
+
+// fenceMasterLocked stops the master of a partition from accepting writes before it hands
+// over: its role is given the epoch of the coming promotion while the load balancers still
+// route writes with the current one, which the master rejects as stale. The replicas keep
+// the current epoch so that they still apply the master's last operations. The caller must
+// hold the lock.
+func (c *Controller) fenceMasterLocked(partitionID string) {
+	partition := c.state.Partitions[partitionID]
+
+	idx := slices.IndexFunc(c.state.Nodes, func(n common.Node) bool { return n.Id == partition.MasterNodeId })
+	if idx < 0 {
+		return
+	}
+
+	role := c.state.Nodes[idx].Partitions[partitionID]
+	role.Epoch = partition.Epoch + 1
+	c.state.Nodes[idx].Partitions[partitionID] = role
+
+	slog.Info("fenced leaving partition master", "partition_id", partitionID,
+		"node_id", partition.MasterNodeId, "epoch", role.Epoch)
+}
+
+// masterFencedLocked reports whether the master of a partition was fenced, the caller must hold the lock
+func (c *Controller) masterFencedLocked(partition common.Partition) bool {
+	master, found := lo.Find(c.state.Nodes, func(n common.Node) bool { return n.Id == partition.MasterNodeId })
+	return found && master.Partitions[partition.Id].Epoch > partition.Epoch
+}
+
+// replacementsSyncedLocked reports whether the nodes staying on a partition caught up with
+// its master, the caller must hold the lock
+func (c *Controller) replacementsSyncedLocked(partition common.Partition) bool {
+	leaving := leavingNodeIDs(partition)
+
+	remaining := 0
+	for _, n := range c.state.Nodes {
+		role, hosted := n.Partitions[partition.Id]
+		if !hosted || lo.Contains(leaving, n.Id) {
+			continue
+		}
+
+		if role.IsSyncing && !role.IsMaster {
+			return false
+		}
+		remaining++
+	}
+
+	return remaining > 0
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// PlacementPolicy is the failure domain level the replicas of a partition are spread over
+type PlacementPolicy string
+
+const (
+	// PlacementNone only keeps replicas on distinct nodes
+	PlacementNone PlacementPolicy = "none"
+	// PlacementHost spreads replicas over distinct hosts
+	PlacementHost PlacementPolicy = "host"
+	// PlacementRack spreads replicas over distinct racks
+	PlacementRack PlacementPolicy = "rack"
+	// PlacementZone spreads replicas over distinct zones
+	PlacementZone PlacementPolicy = "zone"
+)
+
+// ParsePlacementPolicy parses the placement policy of the controller configuration
+func ParsePlacementPolicy(policy string) (PlacementPolicy, error) {
+	switch p := PlacementPolicy(policy); p {
+	case PlacementNone, PlacementHost, PlacementRack, PlacementZone:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown placement policy %q", policy)
+	}
+}
+
+// domain returns the failure domain of a node under the policy. Racks are scoped by their
+// zone and hosts by their rack, a node missing the label forms a domain of its own.
+func (p PlacementPolicy) domain(node common.Node) string {
+	labels := lo.FromPtr(node.Labels)
+	zone, rack, host := lo.FromPtr(labels.Zone), lo.FromPtr(labels.Rack), lo.FromPtr(labels.Host)
+
+	switch {
+	case p == PlacementZone && zone != "":
+		return zone
+	case p == PlacementRack && rack != "":
+		return zone + "/" + rack
+	case p == PlacementHost && host != "":
+		return zone + "/" + rack + "/" + host
+	default:
+		return node.Id.String()
+	}
+}
+
+// usedDomainsLocked returns the failure domains of the given nodes, the caller must hold the lock
+func (c *Controller) usedDomainsLocked(nodeIDs []openapi_types.UUID) map[string]struct{} {
+	domains := make(map[string]struct{}, len(nodeIDs))
+	for _, node := range c.state.Nodes {
+		if lo.Contains(nodeIDs, node.Id) {
+			domains[c.placementPolicy.domain(node)] = struct{}{}
+		}
+	}
+
+	return domains
+}
+
+// pickNodeLocked returns the index of the node to host another replica next to peers: a node
+// that is not unhealthy, not draining and not in excluded. Nodes in a failure domain none of
+// the peers lie in are preferred, then the least utilized nodes. spread is false when the
+// placement policy could not be met. The caller must hold the lock.
+func (c *Controller) pickNodeLocked(peers []openapi_types.UUID, excluded []openapi_types.UUID) (idx int, spread bool, found bool) {
+	used := c.usedDomainsLocked(peers)
+	utilizations := c.utilizationsLocked()
+
+	best := -1
+	bestSpread := false
+	for i, n := range c.state.Nodes {
+		if n.Status == common.Unhealthy || isDraining(n) || lo.Contains(excluded, n.Id) || lo.Contains(peers, n.Id) {
+			continue
+		}
+
+		_, shared := used[c.placementPolicy.domain(n)]
+		better := best < 0 || (!shared && !bestSpread) ||
+			(!shared == bestSpread && utilizations[n.Id] < utilizations[c.state.Nodes[best].Id])
+		if !better {
+			continue
+		}
+
+		best, bestSpread = i, !shared
+	}
+
+	return best, bestSpread, best >= 0
+}
+
+// pickReplacementLocked returns the index of the node to take over a replica of a partition
+// from the excluded nodes, warning when the placement policy cannot be met. The caller must
+// hold the lock.
+func (c *Controller) pickReplacementLocked(partitionID string, excluded ...openapi_types.UUID) (int, bool) {
+	partition := c.state.Partitions[partitionID]
+	excluded = slices.Concat(excluded, leavingNodeIDs(partition))
+	peers := lo.Without(partition.NodeIds, excluded...)
+
+	idx, spread, found := c.pickNodeLocked(peers, excluded)
+	if found && !spread {
+		slog.Warn("placement policy cannot be met", "partition_id", partitionID,
+			"policy", c.placementPolicy, "node_id", c.state.Nodes[idx].Id)
+	}
+
+	return idx, found
+}
+
+// misplacedReplicaLocked returns a replica of a partition sharing its failure domain with another
+// node of the partition, the caller must hold the lock
+func (c *Controller) misplacedReplicaLocked(partition common.Partition) (openapi_types.UUID, bool) {
+	staying := lo.Without(partition.NodeIds, leavingNodeIDs(partition)...)
+
+	byDomain := make(map[string][]openapi_types.UUID)
+	for _, node := range c.state.Nodes {
+		if lo.Contains(staying, node.Id) {
+			domain := c.placementPolicy.domain(node)
+			byDomain[domain] = append(byDomain[domain], node.Id)
+		}
+	}
+
+	// The master stays, it would need a handover
+	for _, nodeIDs := range byDomain {
+		if len(nodeIDs) < 2 {
+			continue
+		}
+
+		if nodeID, found := lo.Find(nodeIDs, func(id openapi_types.UUID) bool {
+			return id != partition.MasterNodeId
+		}); found {
+			return nodeID, true
+		}
+	}
+
+	return openapi_types.UUID{}, false
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// rebalancePartitions balances the load of the healthy nodes relative to their weights, e.g.
+// after a node joined the cluster. Replicas move from the most utilized node to the least
+// utilized one as long as the target stays below the source's utilization by the tolerance.
+// Nothing moves while resharding, while partitions are still moving, while replicas are syncing
+// or in raft mode, whose groups keep their members.
+func (c *Controller) rebalancePartitions() {
+	c.lock.Lock()
+
+	if c.state.IsResharding || c.state.UsesRaft() || c.movingLocked() || c.syncingLocked() {
+		c.lock.Unlock()
+		return
+	}
+
+	eligible := lo.Filter(lo.Range(len(c.state.Nodes)), func(i int, _ int) bool {
+		return c.state.Nodes[i].Status == common.Healthy && !isDraining(c.state.Nodes[i])
+	})
+	if len(eligible) < 2 {
+		c.lock.Unlock()
+		return
+	}
+
+	replicaLoads := c.replicaLoadsLocked()
+	load := lo.SliceToMap(eligible, func(i int) (int, float64) {
+		return i, lo.Sum(lo.Values(replicaLoads[c.state.Nodes[i].Id]))
+	})
+	utilization := func(i int) float64 {
+		return load[i] / nodeWeight(c.state.Nodes[i])
+	}
+	tolerance := rebalanceTolerance * lo.Sum(lo.Map(eligible, func(i int, _ int) float64 {
+		return utilization(i)
+	})) / float64(len(eligible))
+
+	moved := make(map[string]struct{})
+	for {
+		fullest := lo.MaxBy(eligible, func(a, b int) bool { return utilization(a) > utilization(b) })
+		emptiest := lo.MinBy(eligible, func(a, b int) bool { return utilization(a) < utilization(b) })
+
+		// The largest load the target takes without ending up above the source
+		maxLoad := (utilization(fullest)-tolerance)*nodeWeight(c.state.Nodes[emptiest]) - load[emptiest]
+
+		partitionID, found := c.pickMovablePartitionLocked(fullest, emptiest, moved, replicaLoads, maxLoad)
+		if !found {
+			break
+		}
+
+		replicaLoad := replicaLoads[c.state.Nodes[fullest].Id][partitionID]
+		c.moveReplicaLocked(partitionID, c.state.Nodes[fullest].Id, emptiest)
+		moved[partitionID] = struct{}{}
+		load[fullest] -= replicaLoad
+		load[emptiest] += replicaLoad
+	}
+
+	if len(moved) == 0 {
+		c.lock.Unlock()
+		return
+	}
+
+	slog.Info("rebalancing partitions", "moves", len(moved))
+
+	nodeIDs := make(map[openapi_types.UUID]struct{})
+	for partitionID := range moved {
+		for _, nodeID := range c.state.Partitions[partitionID].NodeIds {
+			nodeIDs[nodeID] = struct{}{}
+		}
+	}
+
+	c.commitStateLocked()
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	c.lock.Unlock()
+
+	c.dispatchNodeState(lo.Map(lo.Keys(nodeIDs), func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+		return lo.T2(nodeID, stateCopy)
+	}))
+	c.dispatchState(stateCopy)
+}
+
+// pickMovablePartitionLocked returns a partition of the node at fromIdx that the node at toIdx
+// does not host, that did not move yet, whose load does not exceed maxLoad and whose failure
+// domains stay distinct. Replicas are preferred since they move without a handover, unless the
+// source masters more partitions than the target, then the heaviest partitions. The caller
+// must hold the lock.
+func (c *Controller) pickMovablePartitionLocked(fromIdx, toIdx int, moved map[string]struct{},
+	replicaLoads map[openapi_types.UUID]map[string]float64, maxLoad float64) (string, bool) {
+	from, to := c.state.Nodes[fromIdx], c.state.Nodes[toIdx]
+	preferMaster := c.masterCountLocked(from.Id) > c.masterCountLocked(to.Id)+1
+
+	candidates := lo.Filter(lo.Keys(from.Partitions), func(partitionID string, _ int) bool {
+		_, hosted := to.Partitions[partitionID]
+		_, wasMoved := moved[partitionID]
+		if hosted || wasMoved || replicaLoads[from.Id][partitionID] > maxLoad {
+			return false
+		}
+
+		// The move must not put two replicas into the same failure domain
+		peers := lo.Without(c.state.Partitions[partitionID].NodeIds, from.Id)
+		_, shared := c.usedDomainsLocked(peers)[c.placementPolicy.domain(to)]
+		return !shared
+	})
+	slices.SortFunc(candidates, func(a, b string) int {
+		if from.Partitions[a].IsMaster != from.Partitions[b].IsMaster {
+			return lo.Ternary(from.Partitions[a].IsMaster == preferMaster, -1, 1)
+		}
+		return cmp.Or(cmp.Compare(replicaLoads[from.Id][b], replicaLoads[from.Id][a]), strings.Compare(a, b))
+	})
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	return candidates[0], true
+}
+
+// masterCountLocked returns the number of partitions a node masters, the caller must hold the lock
+func (c *Controller) masterCountLocked(nodeID openapi_types.UUID) int {
+	return lo.CountBy(lo.Values(c.state.Partitions), func(p common.Partition) bool {
+		return p.MasterNodeId == nodeID
+	})
+}
+
+// movingLocked reports whether a partition is moving between nodes, the caller must hold the lock
+func (c *Controller) movingLocked() bool {
+	return lo.ContainsBy(c.state.Nodes, isDraining) ||
+		lo.ContainsBy(lo.Values(c.state.Partitions), func(p common.Partition) bool {
+			return len(leavingNodeIDs(p)) > 0
+		})
+}
+
+// syncingLocked reports whether a replica is still catching up with its master, the caller must hold the lock
+func (c *Controller) syncingLocked() bool {
+	return lo.ContainsBy(c.state.Nodes, func(n common.Node) bool {
+		return lo.SomeBy(lo.Values(n.Partitions), func(role common.PartitionRole) bool {
+			return role.IsSyncing && !role.IsMaster
+		})
+	})
+}
@@ -0,0 +1,239 @@
+package controller
+
+import (
+	"cmp"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// ReplicationProgress summarizes how far the partitions are from the desired replica count
+type ReplicationProgress struct {
+	Partitions int
+	// UnderReplicated partitions have fewer live nodes than the replica count asks for
+	UnderReplicated int
+	// OverReplicated partitions have more nodes than the replica count asks for
+	OverReplicated int
+	// Moving partitions have nodes leaving them
+	Moving int
+	// Syncing replicas are still catching up with their masters
+	Syncing int
+	// Misplaced partitions have replicas sharing a failure domain
+	Misplaced int
+}
+
+// Converged reports whether every partition has the desired replicas and none is moving
+func (p ReplicationProgress) Converged() bool {
+	return p.UnderReplicated == 0 && p.OverReplicated == 0 && p.Moving == 0 && p.Syncing == 0
+}
+
+// markNodeHealthLocked records since when a node is unhealthy, the caller must hold the lock
+func (c *Controller) markNodeHealthLocked(node common.Node) {
+	switch node.Status {
+	case common.Unhealthy:
+		if _, found := c.unhealthySince[node.Id]; !found {
+			c.unhealthySince[node.Id] = time.Now()
+		}
+	case common.Healthy:
+		delete(c.unhealthySince, node.Id)
+	}
+}
+
+// isNodeDeadLocked reports whether a node has been unhealthy for longer than the dead node
+// timeout, its replicas are replaced then. The caller must hold the lock.
+func (c *Controller) isNodeDeadLocked(nodeID openapi_types.UUID) bool {
+	since, found := c.unhealthySince[nodeID]
+	return found && time.Since(since) >= c.deadNodeTimeout
+}
+
+// reconcileReplicas converges every partition to the replica count: replicas on dead nodes
+// are moved to healthy nodes, missing replicas are added and surplus replicas leave. Replicas
+// sharing a failure domain move to an unused domain when one is available. New replicas are
+// synced from the master before replaced replicas leave. Raft groups keep their members.
+func (c *Controller) reconcileReplicas() {
+	c.lock.Lock()
+
+	if c.state.IsResharding || c.state.UsesRaft() {
+		c.lock.Unlock()
+		return
+	}
+
+	desired := c.state.ReplicaCount + 1
+	nodeIDs := make(map[openapi_types.UUID]struct{})
+	changed := false
+
+	for _, partitionID := range slices.Sorted(maps.Keys(c.state.Partitions)) {
+		partition := c.state.Partitions[partitionID]
+		leaving := leavingNodeIDs(partition)
+		staying := lo.Without(partition.NodeIds, leaving...)
+
+		partitionChanged := false
+
+		// Replicas on dead nodes move to healthy nodes, a dead master is failed over first
+		for _, nodeID := range staying {
+			if nodeID == partition.MasterNodeId || !c.isNodeDeadLocked(nodeID) {
+				continue
+			}
+
+			targetIdx, found := c.pickReplacementLocked(partitionID, nodeID)
+			if !found {
+				slog.Warn("no node available to replace dead replica", "partition_id", partitionID,
+					"node_id", nodeID)
+				break
+			}
+
+			c.moveReplicaLocked(partitionID, nodeID, targetIdx)
+			partitionChanged = true
+		}
+
+		partition = c.state.Partitions[partitionID]
+		staying = lo.Without(partition.NodeIds, leavingNodeIDs(partition)...)
+
+		for missing := desired - len(staying); missing > 0; missing-- {
+			targetIdx, found := c.pickReplacementLocked(partitionID)
+			if !found {
+				slog.Warn("not enough nodes for the replica count", "partition_id", partitionID,
+					"replicas", len(staying), "desired", desired)
+				break
+			}
+
+			c.addReplicaLocked(partitionID, targetIdx)
+			partitionChanged = true
+		}
+
+		partition = c.state.Partitions[partitionID]
+		staying = lo.Without(partition.NodeIds, leavingNodeIDs(partition)...)
+
+		if surplus := len(staying) - desired; surplus > 0 {
+			for _, nodeID := range c.surplusReplicasLocked(partition, staying)[:surplus] {
+				partition.LeavingNodeIds = lo.ToPtr(append(leavingNodeIDs(partition), nodeID))
+				slog.Info("removing surplus partition replica", "partition_id", partitionID, "node_id", nodeID)
+			}
+			c.state.Partitions[partitionID] = partition
+			partitionChanged = true
+		}
+
+		// A replica sharing its failure domain moves once a node in an unused domain is available
+		if !partitionChanged && len(leavingNodeIDs(partition)) == 0 {
+			if nodeID, found := c.misplacedReplicaLocked(partition); found {
+				peers := lo.Without(staying, nodeID)
+				if targetIdx, spread, found := c.pickNodeLocked(peers, []openapi_types.UUID{nodeID}); found && spread {
+					c.moveReplicaLocked(partitionID, nodeID, targetIdx)
+					partitionChanged = true
+				}
+			}
+		}
+
+		if !partitionChanged {
+			continue
+		}
+
+		changed = true
+		for _, nodeID := range c.state.Partitions[partitionID].NodeIds {
+			nodeIDs[nodeID] = struct{}{}
+		}
+	}
+
+	if !changed {
+		c.lock.Unlock()
+		return
+	}
+
+	progress := c.replicationProgressLocked()
+	slog.Info("reconciling partition replicas", "replica_count", c.state.ReplicaCount,
+		"under_replicated", progress.UnderReplicated, "over_replicated", progress.OverReplicated,
+		"moving", progress.Moving, "syncing", progress.Syncing)
+
+	c.commitStateLocked()
+
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	c.lock.Unlock()
+
+	c.dispatchNodeState(lo.Map(lo.Keys(nodeIDs), func(nodeID openapi_types.UUID, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+		return lo.T2(nodeID, stateCopy)
+	}))
+	c.dispatchState(stateCopy)
+}
+
+// surplusReplicasLocked orders the replicas of a partition by how cheaply they can leave:
+// unhealthy nodes first, then syncing replicas, then the most utilized nodes.
+// The master never leaves this way. The caller must hold the lock.
+func (c *Controller) surplusReplicasLocked(partition common.Partition, staying []openapi_types.UUID) []openapi_types.UUID {
+	nodes := lo.Filter(c.state.Nodes, func(n common.Node, _ int) bool {
+		return n.Id != partition.MasterNodeId && lo.Contains(staying, n.Id)
+	})
+	utilizations := c.utilizationsLocked()
+
+	rank := func(n common.Node) int {
+		switch {
+		case n.Status != common.Healthy:
+			return 0
+		case n.Partitions[partition.Id].IsSyncing:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	slices.SortStableFunc(nodes, func(a, b common.Node) int {
+		if rank(a) != rank(b) {
+			return rank(a) - rank(b)
+		}
+		return cmp.Compare(utilizations[b.Id], utilizations[a.Id])
+	})
+
+	return lo.Map(nodes, func(n common.Node, _ int) openapi_types.UUID { return n.Id })
+}
+
+// ReplicationProgress reports how far the partitions are from the replica count
+func (c *Controller) ReplicationProgress() ReplicationProgress {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.replicationProgressLocked()
+}
+
+// replicationProgressLocked reports how far the partitions are from the replica count, the
+// caller must hold the lock
+func (c *Controller) replicationProgressLocked() ReplicationProgress {
+	desired := c.state.ReplicaCount + 1
+
+	progress := ReplicationProgress{Partitions: len(c.state.Partitions)}
+	for _, partition := range c.state.Partitions {
+		leaving := leavingNodeIDs(partition)
+		staying := lo.Without(partition.NodeIds, leaving...)
+		live := lo.CountBy(staying, func(nodeID openapi_types.UUID) bool {
+			return !c.isNodeDeadLocked(nodeID)
+		})
+
+		switch {
+		case live < desired:
+			progress.UnderReplicated++
+		case len(staying) > desired:
+			progress.OverReplicated++
+		}
+
+		if len(leaving) > 0 {
+			progress.Moving++
+		}
+
+		if _, misplaced := c.misplacedReplicaLocked(partition); misplaced {
+			progress.Misplaced++
+		}
+	}
+
+	for _, node := range c.state.Nodes {
+		progress.Syncing += lo.CountBy(lo.Values(node.Partitions), func(role common.PartitionRole) bool {
+			return role.IsSyncing && !role.IsMaster
+		})
+	}
+
+	return progress
+}
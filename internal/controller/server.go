@@ -2,18 +2,46 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/computer-technology-team/distributed-kvstore/api/controller"
 )
 
+const (
+	// defaultStateWatchTimeout is how long a state request with a version waits for a newer state
+	defaultStateWatchTimeout = 30 * time.Second
+	// maxStateWatchTimeout bounds the wait a state request may ask for
+	maxStateWatchTimeout = time.Minute
+)
+
 type server struct {
 	controller *Controller
 }
 
 // PostNodesRegister implements controller.StrictServerInterface.
 func (s *server) PostNodesRegister(ctx context.Context, request controller.PostNodesRegisterRequestObject) (controller.PostNodesRegisterResponseObject, error) {
-	id, err := s.controller.RegisterNodeByAddress(request.Body.Address)
+	if weight := request.Body.Weight; weight != nil && *weight <= 0 {
+		return controller.PostNodesRegister400Response{}, nil
+	}
+
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.PostNodesRegister503JSONResponse(noLeaderResponse(err)), nil
+	}
+
+	if client != nil {
+		return s.forwardNodeRegistration(ctx, client, *request.Body)
+	}
+
+	id, err := s.controller.RegisterNodeByAddress(request.Body.Address, request.Body.Labels, request.Body.Weight)
+	if errors.Is(err, ErrNotLeader) {
+		return controller.PostNodesRegister503JSONResponse(noLeaderResponse(err)), nil
+	}
 	if err != nil {
 		slog.Error("could not register node", "error", err)
 		return controller.PostNodesRegister409Response{}, nil
@@ -24,9 +52,199 @@ func (s *server) PostNodesRegister(ctx context.Context, request controller.PostN
 	}, nil
 }
 
+// forwardNodeRegistration registers a node with the leading instance
+func (s *server) forwardNodeRegistration(ctx context.Context, client controller.ClientWithResponsesInterface,
+	registration controller.NodeRegistration) (controller.PostNodesRegisterResponseObject, error) {
+	resp, err := client.PostNodesRegisterWithResponse(ctx, registration)
+	if err != nil {
+		slog.Error("could not forward node registration to leader", "error", err)
+		return controller.PostNodesRegister503JSONResponse(noLeaderResponse(err)), nil
+	}
+
+	switch {
+	case resp.JSON201 != nil:
+		return controller.PostNodesRegister201JSONResponse(*resp.JSON201), nil
+	case resp.JSON503 != nil:
+		return controller.PostNodesRegister503JSONResponse(*resp.JSON503), nil
+	case resp.StatusCode() == http.StatusBadRequest:
+		return controller.PostNodesRegister400Response{}, nil
+	case resp.StatusCode() == http.StatusConflict:
+		return controller.PostNodesRegister409Response{}, nil
+	}
+
+	return controller.PostNodesRegister503JSONResponse(noLeaderResponse(
+		fmt.Errorf("leader returned status %d", resp.StatusCode()))), nil
+}
+
+// PostNodesNodeIdHeartbeat implements controller.StrictServerInterface.
+func (s *server) PostNodesNodeIdHeartbeat(ctx context.Context, request controller.PostNodesNodeIdHeartbeatRequestObject) (controller.PostNodesNodeIdHeartbeatResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing heartbeat in request body")
+	}
+
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.PostNodesNodeIdHeartbeatdefaultJSONResponse(noLeaderError(err)), nil
+	}
+
+	if client != nil {
+		resp, err := client.PostNodesNodeIdHeartbeatWithResponse(ctx, request.NodeID, *request.Body)
+		if err != nil {
+			slog.Error("could not forward heartbeat to leader", "node_id", request.NodeID, "error", err)
+			return controller.PostNodesNodeIdHeartbeatdefaultJSONResponse(noLeaderError(err)), nil
+		}
+
+		if resp.StatusCode() == http.StatusNoContent {
+			return controller.PostNodesNodeIdHeartbeat204Response{}, nil
+		}
+		return controller.PostNodesNodeIdHeartbeatdefaultJSONResponse(leaderError(resp.StatusCode(), resp.JSONDefault)), nil
+	}
+
+	if err := s.controller.ReceiveHeartbeat(request.NodeID, request.Body.Partitions); err != nil {
+		return controller.PostNodesNodeIdHeartbeatdefaultJSONResponse(changeError(err)), nil
+	}
+
+	return controller.PostNodesNodeIdHeartbeat204Response{}, nil
+}
+
 // GetState implements controller.StrictServerInterface.
 func (s *server) GetState(ctx context.Context, request controller.GetStateRequestObject) (controller.GetStateResponseObject, error) {
-	return controller.GetState200JSONResponse(s.controller.GetState()), nil
+	client, err := s.leaderClient()
+	if err != nil {
+		return controller.GetState503JSONResponse(noLeaderResponse(err)), nil
+	}
+
+	if client == nil {
+		return s.watchState(ctx, request.Params)
+	}
+
+	resp, err := client.GetStateWithResponse(ctx, &request.Params)
+	if err != nil {
+		slog.Error("could not forward state request to leader", "error", err)
+		return controller.GetState503JSONResponse(noLeaderResponse(err)), nil
+	}
+
+	switch {
+	case resp.JSON200 != nil:
+		return controller.GetState200JSONResponse(*resp.JSON200), nil
+	case resp.StatusCode() == http.StatusNotModified:
+		return controller.GetState304Response{}, nil
+	case resp.JSON503 != nil:
+		return controller.GetState503JSONResponse(*resp.JSON503), nil
+	}
+
+	return controller.GetState503JSONResponse(noLeaderResponse(
+		fmt.Errorf("leader returned status %d", resp.StatusCode()))), nil
+}
+
+// watchState answers the committed state right away without a version, and otherwise once
+// the committed state is newer than the version or with 304 when the timeout elapses first
+func (s *server) watchState(ctx context.Context, params controller.GetStateParams) (controller.GetStateResponseObject, error) {
+	if params.SinceVersion == nil {
+		return controller.GetState200JSONResponse(s.controller.CommittedState()), nil
+	}
+
+	timeout := defaultStateWatchTimeout
+	if params.TimeoutSeconds != nil {
+		timeout = min(max(time.Duration(*params.TimeoutSeconds)*time.Second, time.Second), maxStateWatchTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	state, changed := s.controller.WatchState(ctx, *params.SinceVersion)
+	if !changed {
+		return controller.GetState304Response{}, nil
+	}
+
+	return controller.GetState200JSONResponse(state), nil
+}
+
+// PostRaftVote implements controller.StrictServerInterface.
+func (s *server) PostRaftVote(ctx context.Context, request controller.PostRaftVoteRequestObject) (controller.PostRaftVoteResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing vote request in request body")
+	}
+
+	resp, err := s.controller.HandleRaftVote(*request.Body)
+	if errors.Is(err, ErrRaftDisabled) {
+		return controller.PostRaftVote404JSONResponse{
+			Error:   "Raft not enabled",
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return controller.PostRaftVote200JSONResponse(resp), nil
+}
+
+// PostRaftAppend implements controller.StrictServerInterface.
+func (s *server) PostRaftAppend(ctx context.Context, request controller.PostRaftAppendRequestObject) (controller.PostRaftAppendResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing append request in request body")
+	}
+
+	resp, err := s.controller.HandleRaftAppend(*request.Body)
+	if errors.Is(err, ErrRaftDisabled) {
+		return controller.PostRaftAppend404JSONResponse{
+			Error:   "Raft not enabled",
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return controller.PostRaftAppend200JSONResponse(resp), nil
+}
+
+// PostRaftSnapshot implements controller.StrictServerInterface.
+func (s *server) PostRaftSnapshot(ctx context.Context, request controller.PostRaftSnapshotRequestObject) (controller.PostRaftSnapshotResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing snapshot request in request body")
+	}
+
+	resp, err := s.controller.HandleRaftSnapshot(*request.Body)
+	if errors.Is(err, ErrRaftDisabled) {
+		return controller.PostRaftSnapshot404JSONResponse{
+			Error:   "Raft not enabled",
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return controller.PostRaftSnapshot200JSONResponse(resp), nil
+}
+
+// leaderClient returns a client of the leading instance, nil when this instance leads
+func (s *server) leaderClient() (controller.ClientWithResponsesInterface, error) {
+	leader := s.controller.Leader()
+	if leader.Self {
+		return nil, nil
+	}
+
+	if leader.Address == "" {
+		return nil, ErrNoLeader
+	}
+
+	client, err := controller.NewClientWithResponses("http://" + leader.Address)
+	if err != nil {
+		return nil, fmt.Errorf("could not create leader client: %w", err)
+	}
+
+	return client, nil
+}
+
+// noLeaderResponse builds the error returned for requests no controller instance can serve
+func noLeaderResponse(err error) common.ErrorResponse {
+	return common.ErrorResponse{
+		Error:   common.NoLeaderErrorCode,
+		Message: err.Error(),
+	}
 }
 
 func NewServer(controller *Controller) controller.StrictServerInterface {
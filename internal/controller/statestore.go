@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/computer-technology-team/distributed-kvstore/api/loadbalancer"
+	"github.com/mohae/deepcopy"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// StateStore durably keeps the cluster state across controller restarts
+type StateStore interface {
+	// Load returns the last saved state, nil when none was saved yet
+	Load() (*common.State, error)
+	Save(state common.State) error
+}
+
+// fileStateStore keeps the state as a JSON file
+type fileStateStore struct {
+	path string
+}
+
+// NewFileStateStore returns a store keeping the state in the file at path
+func NewFileStateStore(path string) StateStore {
+	return &fileStateStore{path: path}
+}
+
+func (s *fileStateStore) Load() (*common.State, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read state file: %w", err)
+	}
+
+	var state common.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("could not decode state file: %w", err)
+	}
+
+	return &state, nil
+}
+
+// Save replaces the state file atomically so that a crash leaves either the old or the new state
+func (s *fileStateStore) Save(state common.State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not encode state: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("could not create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("could not create state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write state file: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not sync state file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not close state file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("could not replace state file: %w", err)
+	}
+
+	return nil
+}
+
+// commitStateLocked bumps the version of the state, persists it and replicates it to the
+// other controller instances, the caller must hold the lock
+func (c *Controller) commitStateLocked() {
+	c.state.Version++
+	c.notifyStateChangedLocked()
+	c.replicateStateLocked()
+
+	if c.stateStore == nil {
+		return
+	}
+
+	if err := c.stateStore.Save(c.state); err != nil {
+		slog.Error("could not persist state", "version", c.state.Version, "error", err)
+	}
+}
+
+// RecoverState restores the state saved by a previous run of the controller, the watcher
+// dispatches it to the registered nodes and load balancers once this instance leads
+func (c *Controller) RecoverState() error {
+	if c.stateStore == nil {
+		return nil
+	}
+
+	state, err := c.stateStore.Load()
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Switching the replication mode of existing partitions is not supported
+	if c.state.ReplicationMode != nil && state.ReplicationMode != nil &&
+		*c.state.ReplicationMode != *state.ReplicationMode {
+		slog.Warn("keeping the replication mode of the recovered state",
+			"configured_mode", *c.state.ReplicationMode, "recovered_mode", *state.ReplicationMode)
+	}
+	if state.ReplicationMode == nil {
+		state.ReplicationMode = c.state.ReplicationMode
+	}
+
+	if err := c.addClientsLocked(*state); err != nil {
+		return err
+	}
+
+	c.state = *state
+	c.notifyStateChangedLocked()
+
+	slog.Info("recovered state", "version", state.Version, "nodes", len(state.Nodes),
+		"partitions", len(state.Partitions))
+
+	return nil
+}
+
+// addClientsLocked creates the clients of the nodes and the load balancers of a state taken
+// over from disk or from another controller instance, the caller must hold the lock
+func (c *Controller) addClientsLocked(state common.State) error {
+	for _, node := range slices.Concat(state.Nodes, state.UnRegisteredNodes) {
+		if _, found := c.nodeClients[node.Id]; found {
+			continue
+		}
+
+		client, err := database.NewClientWithResponses("http://" + node.Address)
+		if err != nil {
+			return fmt.Errorf("could not create database client for node %s: %w", node.Id, err)
+		}
+		c.nodeClients[node.Id] = client
+	}
+
+	for _, balancer := range lo.FromPtr(state.LoadBalancers) {
+		if _, found := c.balancerClients[balancer.Id]; found {
+			continue
+		}
+
+		client, err := loadbalancer.NewClientWithResponses("http://" + balancer.Address)
+		if err != nil {
+			return fmt.Errorf("could not create client for load balancer %s: %w", balancer.Id, err)
+		}
+		c.balancerClients[balancer.Id] = client
+	}
+
+	return nil
+}
+
+// redispatchState sends the whole state to every registered node and load balancer
+func (c *Controller) redispatchState() {
+	// Other instances may have dispatched other states of the versions this one knows of
+	c.distribution.reset()
+
+	c.lock.RLock()
+	stateCopy := deepcopy.Copy(c.state).(common.State)
+	c.lock.RUnlock()
+
+	c.dispatchNodeState(lo.Map(stateCopy.Nodes, func(n common.Node, _ int) lo.Tuple2[openapi_types.UUID, database.NodeState] {
+		return lo.T2(n.Id, stateCopy)
+	}))
+	c.dispatchState(stateCopy)
+}
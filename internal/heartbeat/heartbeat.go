@@ -0,0 +1,70 @@
+package heartbeat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/controller"
+	"github.com/google/uuid"
+)
+
+// Source reports what a heartbeat carries
+type Source interface {
+	// PartitionsStatus returns the status of every partition the node hosts
+	PartitionsStatus() []common.PartitionStatus
+}
+
+// Send pushes a heartbeat with the partition statuses of source to the controller every
+// interval until ctx is done. Every heartbeat waits at most interval for the controller.
+func Send(ctx context.Context, client controller.ClientWithResponsesInterface, nodeID uuid.UUID,
+	source Source, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// failing keeps a controller that stays unreachable from flooding the log
+	failing := false
+	for {
+		err := send(ctx, client, nodeID, source, interval)
+		switch {
+		case err != nil && !failing:
+			slog.Warn("could not send heartbeat", "node_id", nodeID, "error", err)
+			failing = true
+		case err == nil && failing:
+			slog.Info("heartbeats resumed", "node_id", nodeID)
+			failing = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// send pushes a single heartbeat
+func send(ctx context.Context, client controller.ClientWithResponsesInterface, nodeID uuid.UUID,
+	source Source, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := client.PostNodesNodeIdHeartbeatWithResponse(ctx, nodeID, controller.Heartbeat{
+		Partitions: source.PartitionsStatus(),
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode() == http.StatusNoContent:
+		return nil
+	case resp.JSONDefault != nil:
+		return fmt.Errorf("controller returned %s: %s", resp.JSONDefault.Error, resp.JSONDefault.Message)
+	}
+
+	return fmt.Errorf("unexpected status code %d", resp.StatusCode())
+}
@@ -1,27 +1,158 @@
 package kvstore
 
 import (
+	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/computer-technology-team/distributed-kvstore/api/common"
 )
 
 // KVStore represents a single key-value store for a partition with its status
 type KVStore struct {
-	mu        sync.RWMutex
-	store     map[string]string // Regular map for key-value pairs
-	isMaster  bool              // Whether this node is the master for this partition
-	isSyncing bool              // Whether this partition is currently syncing
-	opLog     []common.Operation
-	nextOpID  int64
+	mu sync.RWMutex
+	// snapshotMu serializes writers of the snapshot file, it is acquired before mu
+	snapshotMu sync.Mutex
+	store      map[string]string // Regular map for key-value pairs
+	isMaster   bool              // Whether this node is the master for this partition
+	isSyncing  bool              // Whether this partition is currently syncing
+	// Whether the content may have diverged from the master, e.g. after a demotion,
+	// and must be replaced by the master's snapshot before serving as a replica
+	needsReset bool
+	// Whether a catch-up with the master is running
+	syncRunning atomic.Bool
+	// Latest epoch of the partition known to this node, writes and replicated
+	// operations from older epochs are rejected
+	epoch    int64
+	opLog    []common.Operation
+	nextOpID int64
+	// Time the master created the last applied operation, nil when unknown
+	lastAppliedAt *time.Time
+	// ID of the last operation covered by the latest snapshot, -1 when there is none
+	snapshotOpID int64
+	wal          *wal   // Durable log of applied operations, nil when running in memory only
+	dir          string // Directory holding the partition files, empty when running in memory only
+	// Size of the keys and values held in store
+	memoryBytes int64
+	// Requests served for the partition, reported to the controller to balance the load
+	requests rateMeter
+	// Keys deleted while the cluster reshards, nil otherwise. A migrated copy of such a key
+	// was read before the deletion and is not imported.
+	tombstones map[string]struct{}
 }
 
-
-// newKVStoreInstance creates a new KVStore instance
-func newKVStoreInstance() *KVStore {
-	return &KVStore{
+// newKVStoreInstance creates a new KVStore instance, replaying the partition's
+// write-ahead log from disk when storage is enabled
+func newKVStoreInstance(partitionID string, storage StorageOptions) (*KVStore, error) {
+	store := &KVStore{
 		store:     make(map[string]string),
 		isMaster:  false,
 		isSyncing: false,
+		// Operation IDs start from zero, so nothing is covered by a snapshot yet
+		snapshotOpID: -1,
+	}
+
+	if !storage.enabled() {
+		return store, nil
+	}
+
+	store.dir = storage.partitionDir(partitionID)
+
+	w, ops, err := openWAL(store.dir, storage)
+	if err != nil {
+		return nil, fmt.Errorf("could not open wal for partition %s: %w", partitionID, err)
+	}
+
+	installing, err := readSnapshotFile(store.dir, installingSnapshotFileName)
+	if err != nil {
+		w.Close()
+		return nil, fmt.Errorf("could not load installed snapshot of partition %s: %w", partitionID, err)
+	}
+
+	// A crash interrupted installing a snapshot, the log may still hold operations of
+	// the replaced content that must not be replayed on top of it
+	if installing != nil {
+		if err := w.Rewrite(nil); err != nil {
+			w.Close()
+			return nil, fmt.Errorf("could not reset wal of partition %s: %w", partitionID, err)
+		}
+		if err := commitInstalledSnapshot(store.dir); err != nil {
+			w.Close()
+			return nil, fmt.Errorf("could not install snapshot of partition %s: %w", partitionID, err)
+		}
+		ops = nil
+	}
+
+	snapshot, err := readSnapshotFile(store.dir, snapshotFileName)
+	if err != nil {
+		w.Close()
+		return nil, fmt.Errorf("could not load snapshot of partition %s: %w", partitionID, err)
+	}
+
+	if snapshot != nil {
+		store.store = snapshot.Data
+		if store.store == nil {
+			store.store = make(map[string]string)
+		}
+		store.memoryBytes = dataSize(store.store)
+		store.nextOpID = snapshot.LastOperationId + 1
+		store.snapshotOpID = snapshot.LastOperationId
 	}
-}
\ No newline at end of file
+
+	for _, op := range ops {
+		// Records older than the snapshot survive a crash between writing the
+		// snapshot and rewriting the log
+		if op.ID <= store.snapshotOpID {
+			continue
+		}
+
+		if err := validateOperation(op); err != nil {
+			w.Close()
+			return nil, fmt.Errorf("invalid operation %d in wal of partition %s: %w", op.ID, partitionID, err)
+		}
+		store.applyToMemory(op)
+	}
+
+	store.wal = w
+
+	return store, nil
+}
+
+// checkEpochLocked rejects a write routed with a different epoch than the partition's,
+// a nil epoch skips the check. The caller must hold the lock.
+func (kv *KVStore) checkEpochLocked(epoch *int64) error {
+	if epoch == nil || *epoch == kv.epoch {
+		return nil
+	}
+
+	return fmt.Errorf("%w: request epoch %d, partition epoch %d", ErrStaleEpoch, *epoch, kv.epoch)
+}
+
+// close releases the files held by the KVStore
+func (kv *KVStore) close() error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	if kv.wal == nil {
+		return nil
+	}
+
+	err := kv.wal.Close()
+	kv.wal = nil
+	return err
+}
+
+// destroy closes the KVStore and removes its files from disk
+func (kv *KVStore) destroy() error {
+	if err := kv.close(); err != nil {
+		return err
+	}
+
+	if kv.dir == "" {
+		return nil
+	}
+
+	return os.RemoveAll(kv.dir)
+}
@@ -0,0 +1,65 @@
+package kvstore
+
+import (
+	"sync"
+	"time"
+)
+
+// requestRateWindow is the number of seconds the request rate of a partition is averaged over
+const requestRateWindow = 10
+
+// rateMeter counts events in one second buckets over the last requestRateWindow seconds
+type rateMeter struct {
+	mu      sync.Mutex
+	buckets [requestRateWindow]int64
+	// second the newest bucket counts the events of
+	second int64
+}
+
+// advanceLocked clears the buckets of the seconds elapsed since the last event, the caller must hold the lock
+func (m *rateMeter) advanceLocked(now int64) {
+	for elapsed := min(now-m.second, requestRateWindow); elapsed > 0; elapsed-- {
+		m.buckets[(now-elapsed+1)%requestRateWindow] = 0
+	}
+	m.second = max(m.second, now)
+}
+
+// record counts an event
+func (m *rateMeter) record() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now().Unix()
+	m.advanceLocked(now)
+	m.buckets[now%requestRateWindow]++
+}
+
+// rate returns the events per second over the window
+func (m *rateMeter) rate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.advanceLocked(time.Now().Unix())
+
+	var total int64
+	for _, count := range m.buckets {
+		total += count
+	}
+
+	return float64(total) / requestRateWindow
+}
+
+// entrySize returns the memory accounted for a key-value pair
+func entrySize(key, value string) int64 {
+	return int64(len(key) + len(value))
+}
+
+// dataSize returns the memory accounted for the pairs of data
+func dataSize(data map[string]string) int64 {
+	var size int64
+	for key, value := range data {
+		size += entrySize(key, value)
+	}
+
+	return size
+}
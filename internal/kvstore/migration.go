@@ -0,0 +1,343 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
+	"github.com/samber/lo"
+)
+
+const (
+	// migrationBatchSize is the number of keys sent to the target partition per request
+	migrationBatchSize = 500
+	// migrationRequestTimeout bounds a single import request to the target partition
+	migrationRequestTimeout = 10 * time.Second
+)
+
+var ErrMigrationNotFound = errors.New("migration not found")
+
+// migrationTask tracks the copy of a hash range of a partition to another partition
+type migrationTask struct {
+	mu          sync.Mutex
+	partitionID string
+	task        database.MigrationTask
+	status      common.MigrationStatus
+	progress    int64
+	err         error
+}
+
+func (t *migrationTask) snapshot() database.MigrationTaskStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := database.MigrationTaskStatus{
+		Id:       t.task.Id,
+		Status:   t.status,
+		Progress: t.progress,
+	}
+
+	if t.err != nil {
+		errMsg := t.err.Error()
+		status.Error = &errMsg
+	}
+
+	return status
+}
+
+func (t *migrationTask) setProgress(progress int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress = progress
+}
+
+func (t *migrationTask) finish(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.status = common.Failed
+		t.err = err
+		return
+	}
+
+	t.status = common.Completed
+}
+
+// StartMigration starts copying every key of the partition whose hash falls in the task range
+// to the target partition. Starting a migration that is already running or completed is a no-op.
+func (ns *NodeStore) StartMigration(partitionID string, task database.MigrationTask) (database.MigrationTaskStatus, error) {
+	store, err := ns.getStableMaster(partitionID)
+	if err != nil {
+		return database.MigrationTaskStatus{}, err
+	}
+
+	ns.migrationsMu.Lock()
+	defer ns.migrationsMu.Unlock()
+
+	if existing, found := ns.migrations[task.Id]; found {
+		status := existing.snapshot()
+		if status.Status != common.Failed {
+			return status, nil
+		}
+	}
+
+	migration := &migrationTask{
+		partitionID: partitionID,
+		task:        task,
+		status:      common.InProgress,
+	}
+	ns.migrations[task.Id] = migration
+
+	go ns.runMigration(store, migration)
+
+	return migration.snapshot(), nil
+}
+
+// GetMigrationStatus returns the progress of a migration started on this node
+func (ns *NodeStore) GetMigrationStatus(partitionID string, migrationID uuid.UUID) (database.MigrationTaskStatus, error) {
+	ns.migrationsMu.Lock()
+	defer ns.migrationsMu.Unlock()
+
+	migration, found := ns.migrations[migrationID]
+	if !found || migration.partitionID != partitionID {
+		return database.MigrationTaskStatus{}, ErrMigrationNotFound
+	}
+
+	return migration.snapshot(), nil
+}
+
+func (ns *NodeStore) runMigration(store *KVStore, migration *migrationTask) {
+	task := migration.task
+
+	logger := slog.With("partition_id", migration.partitionID, "migration_id", task.Id,
+		"target_partition_id", task.TargetPartitionId)
+	logger.Info("starting migration")
+
+	client, err := database.NewClientWithResponses("http://" + task.TargetAddress)
+	if err != nil {
+		migration.finish(fmt.Errorf("could not create target client: %w", err))
+		return
+	}
+
+	keys := store.keysInRange(task.RangeStart, task.RangeEnd)
+
+	var progress int64
+	for batch := range slices.Chunk(keys, migrationBatchSize) {
+		// Values are read right before sending so that writes made since the
+		// keys were listed are carried over
+		pairs := store.pairsForKeys(batch)
+
+		if len(pairs) > 0 {
+			if err := importBatch(client, task.TargetPartitionId, pairs); err != nil {
+				logger.Error("migration failed", "error", err, "progress", progress)
+				migration.finish(err)
+				return
+			}
+		}
+
+		progress += int64(len(batch))
+		migration.setProgress(progress)
+	}
+
+	logger.Info("migration completed", "keys", progress)
+	migration.finish(nil)
+}
+
+func importBatch(client database.ClientWithResponsesInterface, partitionID string, pairs []common.KeyValuePair) error {
+	ctx, cancel := context.WithTimeout(context.Background(), migrationRequestTimeout)
+	defer cancel()
+
+	resp, err := client.ImportKeysWithResponse(ctx, partitionID, database.ImportRequest{Pairs: pairs})
+	if err != nil {
+		return fmt.Errorf("could not import keys to target: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		if resp.JSON409 != nil {
+			return fmt.Errorf("target rejected import: %s", resp.JSON409.Error)
+		}
+		return fmt.Errorf("target returned status %d", resp.StatusCode())
+	}
+
+	return nil
+}
+
+// CommitMigration deletes the keys moved by a completed migration from the source partition
+func (ns *NodeStore) CommitMigration(partitionID string, task database.MigrationTask) (database.MigrationTaskStatus, error) {
+	store, err := ns.getStableMaster(partitionID)
+	if err != nil {
+		return database.MigrationTaskStatus{}, err
+	}
+
+	var deleted int64
+	for _, key := range store.keysInRange(task.RangeStart, task.RangeEnd) {
+		_, found, err := ns.Delete(partitionID, key, nil, common.WriteConcernOne)
+		if err != nil {
+			return database.MigrationTaskStatus{}, fmt.Errorf("could not delete migrated key: %w", err)
+		}
+		if found {
+			deleted++
+		}
+	}
+
+	ns.migrationsMu.Lock()
+	delete(ns.migrations, task.Id)
+	ns.migrationsMu.Unlock()
+
+	slog.Info("committed migration", "partition_id", partitionID, "migration_id", task.Id,
+		"deleted_keys", deleted)
+
+	return database.MigrationTaskStatus{
+		Id:       task.Id,
+		Status:   common.Completed,
+		Progress: deleted,
+	}, nil
+}
+
+// ImportKeys writes migrated key-value pairs into a master partition. Keys that
+// already exist were written directly to this partition during the migration
+// and are newer than the migrated copy, so they are left untouched. So are keys
+// deleted from this partition during the reshard, the delete may have reached
+// the source after the migration read the key.
+func (ns *NodeStore) ImportKeys(partitionID string, pairs []common.KeyValuePair) (int64, error) {
+	store, err := ns.getStableMaster(partitionID)
+	if err != nil {
+		return 0, err
+	}
+
+	group := ns.raftGroup(partitionID)
+
+	var imported int64
+	for _, pair := range pairs {
+		if group != nil {
+			// A delete proposed after this check follows the import in the log and wins
+			store.mu.RLock()
+			deleted := store.hasTombstoneLocked(pair.Key)
+			store.mu.RUnlock()
+			if deleted {
+				continue
+			}
+
+			result, err := ns.proposeRaft(partitionID, group, raftCommand{
+				Type:      raftSetIfAbsent,
+				Key:       pair.Key,
+				Value:     pair.Value,
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				return imported, err
+			}
+			if result.applied {
+				imported++
+			}
+			continue
+		}
+
+		op, applied, err := store.setIfAbsent(pair.Key, pair.Value)
+		if err != nil {
+			return imported, err
+		}
+
+		if applied {
+			if err := ns.replicate(partitionID, op, common.WriteConcernOne); err != nil {
+				return imported, err
+			}
+			imported++
+		}
+	}
+
+	return imported, nil
+}
+
+// setIfAbsent sets key to value unless the key already exists or was deleted during the
+// reshard, reporting whether it was set
+func (kv *KVStore) setIfAbsent(key, value string) (common.Operation, bool, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	if !kv.isMaster {
+		return common.Operation{}, false, errors.New("partition is not a stable master")
+	}
+
+	if _, exists := kv.store[key]; exists || kv.hasTombstoneLocked(key) {
+		return common.Operation{}, false, nil
+	}
+
+	op := common.Operation{
+		ID:        kv.nextOpID,
+		Key:       key,
+		Type:      common.Set,
+		Value:     nullable.NewNullableWithValue(value),
+		Epoch:     kv.epoch,
+		Timestamp: lo.ToPtr(time.Now()),
+	}
+	if err := kv.applyOperation(op); err != nil {
+		return common.Operation{}, false, err
+	}
+
+	return op, true, nil
+}
+
+// trackTombstonesLocked records the keys deleted from now on while the cluster reshards and
+// drops them once it stopped resharding, the caller must hold the lock
+func (kv *KVStore) trackTombstonesLocked(resharding bool) {
+	switch {
+	case !resharding:
+		kv.tombstones = nil
+	case kv.tombstones == nil:
+		kv.tombstones = make(map[string]struct{})
+	}
+}
+
+// addTombstoneLocked records the deletion of a key while the cluster reshards, the caller must hold the lock
+func (kv *KVStore) addTombstoneLocked(key string) {
+	if kv.tombstones != nil {
+		kv.tombstones[key] = struct{}{}
+	}
+}
+
+// hasTombstoneLocked reports whether a key was deleted during the reshard, the caller must hold the lock
+func (kv *KVStore) hasTombstoneLocked(key string) bool {
+	_, found := kv.tombstones[key]
+	return found
+}
+
+// keysInRange lists the keys whose hash falls in (start, end]
+func (kv *KVStore) keysInRange(start, end int64) []string {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+
+	var keys []string
+	for key := range kv.store {
+		if common.HashInRange(common.KeyHash(key), start, end) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// pairsForKeys returns the current value of every key that still exists
+func (kv *KVStore) pairsForKeys(keys []string) []common.KeyValuePair {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+
+	pairs := make([]common.KeyValuePair, 0, len(keys))
+	for _, key := range keys {
+		if value, exists := kv.store[key]; exists {
+			pairs = append(pairs, common.KeyValuePair{Key: key, Value: value})
+		}
+	}
+
+	return pairs
+}
@@ -1,18 +1,34 @@
 package kvstore
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/internal/raft"
 	"github.com/google/uuid"
 	"github.com/oapi-codegen/nullable"
 	"github.com/samber/lo"
 )
 
+var (
+	// ErrStaleEpoch is returned for writes and replicated operations from another epoch of the partition
+	ErrStaleEpoch = errors.New("stale epoch")
+	// ErrReplicaBehind is returned when a replica did not apply an operation a read must observe in time
+	ErrReplicaBehind = errors.New("replica is behind")
+)
+
+// operationWaitTimeout bounds how long a read waits for a replica to apply the operation of its session
+const operationWaitTimeout = 500 * time.Millisecond
+
 // NodeStore manages multiple KVStores for different partitions
 type NodeStore struct {
 	mu          sync.RWMutex
@@ -20,23 +36,167 @@ type NodeStore struct {
 	lastUpdated atomic.Pointer[time.Time] // Last updated timestamp
 	state       common.State
 	id          uuid.UUID
+	storage     StorageOptions
+	replication ReplicationOptions
+	stop        chan struct{}
+	done        chan struct{}
+	// Replication pipelines of the partitions this node is the master of, keyed by
+	// partition ID and replica node ID
+	senders map[string]map[uuid.UUID]*replicaSender
+	// Raft groups of the hosted partitions when the cluster replicates through Raft
+	rafts map[string]*raft.Node
+
+	migrationsMu sync.Mutex
+	migrations   map[uuid.UUID]*migrationTask // Migrations this node is the source of
 }
 
-// NewNodeStore creates a new NodeStore instance
-func NewNodeStore(id uuid.UUID) *NodeStore {
+// NewNodeStore creates a new NodeStore instance, recovering every partition
+// persisted under the storage data directory
+func NewNodeStore(id uuid.UUID, storage StorageOptions, replication ReplicationOptions) (*NodeStore, error) {
 	t := time.Now()
 	ns := &NodeStore{
-		stores: make(map[string]*KVStore),
+		stores:      make(map[string]*KVStore),
+		id:          id,
+		storage:     storage,
+		replication: replication,
+		senders:     make(map[string]map[uuid.UUID]*replicaSender),
+		rafts:       make(map[string]*raft.Node),
+		migrations:  make(map[uuid.UUID]*migrationTask),
 	}
 
 	ns.lastUpdated.Store(&t)
-	return ns
+
+	if err := ns.loadPartitions(); err != nil {
+		ns.Close()
+		return nil, err
+	}
+
+	if storage.SnapshotInterval > 0 {
+		ns.stop = make(chan struct{})
+		ns.done = make(chan struct{})
+		go ns.compactionLoop()
+	}
+
+	return ns, nil
+}
+
+// compactionLoop periodically snapshots partitions and truncates their logs
+func (ns *NodeStore) compactionLoop() {
+	defer close(ns.done)
+
+	ticker := time.NewTicker(ns.storage.SnapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ns.compactPartitions()
+		case <-ns.stop:
+			return
+		}
+	}
 }
 
+// loadPartitions replays the write-ahead log of every partition found in the data directory
+func (ns *NodeStore) loadPartitions() error {
+	if !ns.storage.enabled() {
+		return nil
+	}
+
+	if err := os.MkdirAll(ns.storage.DataDir, 0o755); err != nil {
+		return fmt.Errorf("could not create data directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(ns.storage.DataDir)
+	if err != nil {
+		return fmt.Errorf("could not read data directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		partitionID := entry.Name()
+		store, err := newKVStoreInstance(partitionID, ns.storage)
+		if err != nil {
+			return err
+		}
+
+		// This node may have been the master of the partition and accepted writes
+		// that were never replicated before it went down
+		store.needsReset = true
+
+		ns.stores[partitionID] = store
+		slog.Info("recovered partition from disk", "partition_id", partitionID,
+			"keys", len(store.store), "next_operation_id", store.nextOpID)
+	}
+
+	return nil
+}
+
+// Close flushes and closes the write-ahead logs of all partitions
+func (ns *NodeStore) Close() error {
+	if ns.stop != nil {
+		close(ns.stop)
+		<-ns.done
+	}
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	for _, senders := range ns.senders {
+		for _, sender := range senders {
+			sender.close()
+			<-sender.done
+		}
+	}
+	ns.senders = nil
+
+	var errs []error
+	if err := ns.stopRaftGroups(); err != nil {
+		errs = append(errs, err)
+	}
+
+	for partitionID, store := range ns.stores {
+		if err := store.close(); err != nil {
+			errs = append(errs, fmt.Errorf("could not close partition %s: %w", partitionID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SetState applies the state the controller assigned, a state older than the one the node
+// holds was overtaken by a newer one and is ignored
 func (ns *NodeStore) SetState(state common.State) error {
 	ns.mu.Lock()
 	defer ns.mu.Unlock()
 
+	return ns.setStateLocked(state)
+}
+
+// PatchState applies a patch of the state the node holds, it returns common.ErrStateVersionMismatch
+// when the node does not hold the base version of the patch
+func (ns *NodeStore) PatchState(patch common.StatePatch) error {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	state, err := ns.state.ApplyStatePatch(patch)
+	if err != nil {
+		return err
+	}
+
+	return ns.setStateLocked(state)
+}
+
+// setStateLocked applies a state, the caller must hold the lock
+func (ns *NodeStore) setStateLocked(state common.State) error {
+	if state.Version < ns.state.Version {
+		slog.Warn("ignoring outdated state", "version", state.Version, "current_version", ns.state.Version)
+		return nil
+	}
+
 	ns.state = state
 
 	node, nodeFound := extractNodeFromState(state, ns.id)
@@ -54,66 +214,149 @@ func (ns *NodeStore) SetState(state common.State) error {
 
 	// Add new partitions
 	for _, partitionID := range toBeAdded {
-		store := newKVStoreInstance()
+		store, err := newKVStoreInstance(partitionID, ns.storage)
+		if err != nil {
+			return err
+		}
 		store.isMaster = partitionRoles[partitionID].IsMaster
-		store.isSyncing = partitionRoles[partitionID].IsSyncing
+		store.epoch = partitionRoles[partitionID].Epoch
 		ns.stores[partitionID] = store
 	}
 
-	// Update existing partitions
+	for _, store := range ns.stores {
+		store.mu.Lock()
+		store.trackTombstonesLocked(state.IsResharding)
+		store.mu.Unlock()
+	}
+
+	if state.UsesRaft() {
+		ns.reconcileRaftGroups(node)
+	}
+
+	// Update existing partitions, replicas report on their own when they caught up
+	// with the master so the syncing flag of their role is not applied
+	var syncs []string
 	for partitionID, store := range ns.stores {
-		if role, exists := partitionRoles[partitionID]; exists {
+		if role, exists := partitionRoles[partitionID]; exists && !state.UsesRaft() {
 			store.mu.Lock()
+			// The state was assigned before a promotion this node already knows of
+			if role.Epoch < store.epoch {
+				slog.Warn("ignoring role from stale epoch", "partition_id", partitionID,
+					"role_epoch", role.Epoch, "epoch", store.epoch)
+				store.mu.Unlock()
+				continue
+			}
+			store.epoch = role.Epoch
+
+			if store.isMaster && !role.IsMaster {
+				slog.Info("demoted from master", "partition_id", partitionID)
+				store.needsReset = true
+			}
+
 			store.isMaster = role.IsMaster
-			store.isSyncing = role.IsSyncing
+
+			switch {
+			case store.isMaster:
+				store.needsReset = false
+				store.isSyncing = false
+			case store.needsReset:
+				// The catch-up replaces the content with the master's snapshot first
+				store.isSyncing = true
+				syncs = append(syncs, partitionID)
+			case slices.Contains(toBeAdded, partitionID):
+				// A new replica pulls the master's full state, from its log or its snapshot
+				store.isSyncing = true
+				syncs = append(syncs, partitionID)
+			case store.isSyncing:
+				// A previous catch-up may have given up, e.g. while the master was unreachable
+				syncs = append(syncs, partitionID)
+			}
 			store.mu.Unlock()
 		}
 	}
 
+	for _, partitionID := range syncs {
+		ns.startSync(partitionID, ns.stores[partitionID])
+	}
+
 	// Remove partitions that are no longer assigned to this node
 	for _, partitionID := range toBeRemoved {
+		if err := ns.stores[partitionID].destroy(); err != nil {
+			slog.Error("could not remove partition data", "partition_id", partitionID, "error", err)
+		}
 		delete(ns.stores, partitionID)
 	}
 
+	ns.reconcileReplicaSenders()
+
 	return nil
 }
 
-// Set sets a key-value pair in the specified partition
-func (ns *NodeStore) Set(partitionID string, key, value string) error {
+// Set sets a key-value pair in the specified partition and waits for the replicas the
+// write concern requires, it returns the ID of the operation applying the write. A non-nil
+// epoch must match the partition's epoch, otherwise ErrStaleEpoch is returned.
+func (ns *NodeStore) Set(partitionID string, key, value string, epoch *int64,
+	writeConcern common.WriteConcern) (int64, error) {
 	ns.mu.RLock()
 	store, exists := ns.stores[partitionID]
 	if !exists {
 		ns.mu.RUnlock()
-		return fmt.Errorf("partition %s not found", partitionID)
+		return 0, fmt.Errorf("partition %s not found", partitionID)
 	}
 	ns.mu.RUnlock()
 
+	store.requests.record()
+
+	// Raft groups commit the write on a majority whatever the write concern, and fence
+	// stale leaders with their terms rather than with the partition epoch
+	if group := ns.raftGroup(partitionID); group != nil {
+		result, err := ns.proposeRaft(partitionID, group, raftCommand{
+			Type:      raftSet,
+			Key:       key,
+			Value:     value,
+			Timestamp: time.Now(),
+		})
+		return result.operationID, err
+	}
+
+	op, err := store.set(partitionID, key, value, epoch)
+	if err != nil {
+		return 0, err
+	}
+
+	// Replicas are waited for outside the lock so that other writes can proceed
+	return op.ID, ns.replicate(partitionID, op, writeConcern)
+}
+
+// set applies a set operation to a master partition
+func (kv *KVStore) set(partitionID string, key, value string, epoch *int64) (common.Operation, error) {
 	// Acquire write lock for this specific KVStore
-	store.mu.Lock()
-	defer store.mu.Unlock()
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
 
 	// Only allow writes to master partitions
-	if !store.isMaster {
-		return fmt.Errorf("partition %s is not the master", partitionID)
+	if !kv.isMaster {
+		return common.Operation{}, fmt.Errorf("partition %s is not the master", partitionID)
 	}
 
-	// Set the value in the store
-	store.store[key] = value
+	if err := kv.checkEpochLocked(epoch); err != nil {
+		return common.Operation{}, err
+	}
 
-	// Create and append the operation
+	// Create the operation and apply it to the store
 	op := common.Operation{
-		ID:    store.nextOpID,
-		Key:   key,
-		Type:  common.Set,
-		Value: nullable.NewNullableWithValue(value),
+		ID:        kv.nextOpID,
+		Key:       key,
+		Type:      common.Set,
+		Value:     nullable.NewNullableWithValue(value),
+		Epoch:     kv.epoch,
+		Timestamp: lo.ToPtr(time.Now()),
+	}
+	if err := kv.applyOperation(op); err != nil {
+		return common.Operation{}, err
 	}
-	store.opLog = append(store.opLog, op)
-	store.nextOpID += 1
-
-	// Send operation to replicas asynchronously
-	go ns.sendOperationToReplicas(partitionID, op)
 
-	return nil
+	return op, nil
 }
 
 // Get retrieves a value by key from the specified partition
@@ -126,6 +369,8 @@ func (ns *NodeStore) Get(partitionID string, key string) (string, bool, error) {
 	}
 	ns.mu.RUnlock()
 
+	store.requests.record()
+
 	// Acquire read lock for this specific KVStore
 	store.mu.RLock()
 	defer store.mu.RUnlock()
@@ -139,43 +384,123 @@ func (ns *NodeStore) Get(partitionID string, key string) (string, bool, error) {
 	return value, true, nil
 }
 
-// Delete removes a key-value pair from the specified partition
-func (ns *NodeStore) Delete(partitionID string, key string) (bool, error) {
+// Delete removes a key-value pair from the specified partition and waits for the replicas
+// the write concern requires, it returns the ID of the operation applying the deletion. A
+// non-nil epoch must match the partition's epoch, otherwise ErrStaleEpoch is returned.
+func (ns *NodeStore) Delete(partitionID string, key string, epoch *int64,
+	writeConcern common.WriteConcern) (int64, bool, error) {
 	ns.mu.RLock()
 	store, exists := ns.stores[partitionID]
 	if !exists {
 		ns.mu.RUnlock()
-		return false, fmt.Errorf("partition %s not found", partitionID)
+		return 0, false, fmt.Errorf("partition %s not found", partitionID)
 	}
 	ns.mu.RUnlock()
 
+	store.requests.record()
+
+	if group := ns.raftGroup(partitionID); group != nil {
+		// Tombstones stay out of the replicated state machine, which must apply every entry
+		// the same way on every node. The leader checks them before proposing imports.
+		store.mu.Lock()
+		store.addTombstoneLocked(key)
+		store.mu.Unlock()
+
+		result, err := ns.proposeRaft(partitionID, group, raftCommand{
+			Type:      raftDelete,
+			Key:       key,
+			Timestamp: time.Now(),
+		})
+		if err != nil || !result.applied {
+			return 0, false, err
+		}
+		return result.operationID, true, nil
+	}
+
+	op, deleted, err := store.delete(partitionID, key, epoch)
+	if err != nil || !deleted {
+		return 0, false, err
+	}
+
+	if err := ns.replicate(partitionID, op, writeConcern); err != nil {
+		return op.ID, true, err
+	}
+
+	return op.ID, true, nil
+}
+
+// delete applies a delete operation to a master partition, it reports false when the key does not exist
+func (kv *KVStore) delete(partitionID string, key string, epoch *int64) (common.Operation, bool, error) {
 	// Acquire write lock for this specific KVStore
-	store.mu.Lock()
-	defer store.mu.Unlock()
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
 
 	// Only allow writes to master partitions
-	if !store.isMaster {
-		return false, fmt.Errorf("partition %s is not the master", partitionID)
+	if !kv.isMaster {
+		return common.Operation{}, false, fmt.Errorf("partition %s is not the master", partitionID)
+	}
+
+	if err := kv.checkEpochLocked(epoch); err != nil {
+		return common.Operation{}, false, err
 	}
 
+	kv.addTombstoneLocked(key)
+
 	// Check if the key exists before deleting
-	_, exists = store.store[key]
-	if !exists {
-		return false, nil
+	if _, exists := kv.store[key]; !exists {
+		return common.Operation{}, false, nil
 	}
 
 	// Delete the key
-	delete(store.store, key)
+	op := common.Operation{
+		ID:        kv.nextOpID,
+		Key:       key,
+		Type:      common.Delete,
+		Value:     nullable.NewNullNullable[string](),
+		Epoch:     kv.epoch,
+		Timestamp: lo.ToPtr(time.Now()),
+	}
+	if err := kv.applyOperation(op); err != nil {
+		return common.Operation{}, false, err
+	}
 
-	store.opLog = append(store.opLog, common.Operation{
-		ID:    store.nextOpID,
-		Key:   key,
-		Type:  common.Delete,
-		Value: nullable.NewNullNullable[string](),
-	})
-	store.nextOpID += 1
+	return op, true, nil
+}
+
+// WaitForOperation waits until a replica partition applied operation operationID, masters
+// hold every acknowledged write and never wait. ErrReplicaBehind is returned when the
+// replica did not catch up in time.
+func (ns *NodeStore) WaitForOperation(ctx context.Context, partitionID string, operationID int64) error {
+	ns.mu.RLock()
+	store, exists := ns.stores[partitionID]
+	if !exists {
+		ns.mu.RUnlock()
+		return fmt.Errorf("partition %s not found", partitionID)
+	}
+	ns.mu.RUnlock()
+
+	applied := func() bool {
+		store.mu.RLock()
+		defer store.mu.RUnlock()
+
+		return store.isMaster || store.nextOpID > operationID
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, operationWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for !applied() {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: operation %d not applied", ErrReplicaBehind, operationID)
+		case <-ticker.C:
+		}
+	}
 
-	return true, nil
+	return nil
 }
 
 func (ns *NodeStore) GetState() common.State {
@@ -186,13 +511,52 @@ func (ns *NodeStore) GetState() common.State {
 }
 
 func (ns *NodeStore) GetOperation(partitionID string, operationID int64) (*common.Operation, error) {
+	partitionStore, err := ns.getStableMaster(partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return partitionStore.GetOperation(operationID)
+}
+
+func (ns *NodeStore) GetOperations(partitionID string, fromOperationID int64) ([]common.Operation, error) {
+	partitionStore, err := ns.getStableMaster(partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return partitionStore.GetOperationsAfter(fromOperationID)
+}
+
+// GetSnapshot returns a point-in-time snapshot of a partition this node is the stable master of
+func (ns *NodeStore) GetSnapshot(partitionID string) (*common.Snapshot, error) {
+	partitionStore, err := ns.getStableMaster(partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := partitionStore.Snapshot(partitionID)
+	return &snapshot, nil
+}
+
+// getStableMaster returns the store of a partition this node is the master, or the Raft
+// leader, of and that is not syncing
+func (ns *NodeStore) getStableMaster(partitionID string) (*KVStore, error) {
 	ns.mu.RLock()
-	defer ns.mu.RUnlock()
 	partitionStore, found := ns.stores[partitionID]
+	group := ns.rafts[partitionID]
+	ns.mu.RUnlock()
 	if !found {
 		return nil, errors.New("partition not found")
 	}
 
+	if group != nil {
+		if !group.Status().IsLeader {
+			return nil, errors.New("partition is not a stable master")
+		}
+		return partitionStore, nil
+	}
+
 	partitionStore.mu.RLock()
 	defer partitionStore.mu.RUnlock()
 
@@ -200,27 +564,48 @@ func (ns *NodeStore) GetOperation(partitionID string, operationID int64) (*commo
 		return nil, errors.New("partition is not a stable master")
 	}
 
-	return partitionStore.GetOperation(operationID)
+	return partitionStore, nil
 }
 
-func (ns *NodeStore) GetOperations(partitionID string, fromOperationID int64) ([]common.Operation, error) {
+// PartitionsStatus returns the replication status of every partition hosted by this node
+func (ns *NodeStore) PartitionsStatus() []common.PartitionStatus {
 	ns.mu.RLock()
 	defer ns.mu.RUnlock()
 
-	partitionStore, found := ns.stores[partitionID]
-	if !found {
-		return nil, errors.New("partition not found")
-	}
+	statuses := make([]common.PartitionStatus, 0, len(ns.stores))
+	for partitionID, store := range ns.stores {
+		store.mu.RLock()
+		status := common.PartitionStatus{
+			PartitionId:            partitionID,
+			IsMaster:               store.isMaster,
+			IsSyncing:              store.isSyncing,
+			Epoch:                  store.epoch,
+			LastAppliedOperationId: store.nextOpID - 1,
+			LastAppliedAt:          store.lastAppliedAt,
+			KeyCount:               int64(len(store.store)),
+			MemoryBytes:            store.memoryBytes,
+		}
+		store.mu.RUnlock()
 
-	partitionStore.mu.RLock()
-	defer partitionStore.mu.RUnlock()
+		status.RequestRate = store.requests.rate()
 
-	if !partitionStore.isMaster || partitionStore.isSyncing {
-		return nil, errors.New("partition is not a stable master")
+		if group, exists := ns.rafts[partitionID]; exists {
+			status.IsMaster = group.Status().IsLeader
+		}
+
+		// Senders lock the store themselves
+		if replicas := ns.replicaStatuses(partitionID); len(replicas) > 0 {
+			status.Replicas = &replicas
+		}
+
+		statuses = append(statuses, status)
 	}
 
-	operations := partitionStore.GetOperationsAfter(fromOperationID)
-	return operations, nil
+	slices.SortFunc(statuses, func(a, b common.PartitionStatus) int {
+		return strings.Compare(a.PartitionId, b.PartitionId)
+	})
+
+	return statuses
 }
 
 func extractNodeFromState(state common.State, nodeID uuid.UUID) (common.Node, bool) {
@@ -3,7 +3,7 @@ package kvstore
 import (
 	"errors"
 	"fmt"
-	"log/slog"
+	"slices"
 	"sort"
 
 	"github.com/computer-technology-team/distributed-kvstore/api/common"
@@ -11,6 +11,7 @@ import (
 
 var ErrOperationIsOutOfBound = errors.New("operation is out of bound")
 var ErrOperationNotFound = errors.New("operation not found")
+var ErrOperationCompacted = errors.New("operation was compacted into a snapshot")
 
 func (kv *KVStore) GetOperation(id int64) (*common.Operation, error) {
 	kv.mu.RLock()
@@ -20,62 +21,104 @@ func (kv *KVStore) GetOperation(id int64) (*common.Operation, error) {
 		return nil, ErrOperationIsOutOfBound
 	}
 
-	for _, op := range kv.opLog {
-		if op.ID == id {
-			return &op, nil
-		}
+	idx := kv.opLogIndexAfter(id - 1)
+	if idx < len(kv.opLog) && kv.opLog[idx].ID == id {
+		op := kv.opLog[idx]
+		return &op, nil
 	}
 
 	return nil, ErrOperationNotFound
 }
 
-func (kv *KVStore) GetOperationsAfter(id int64) []common.Operation {
+// GetOperationsAfter returns every operation with an ID greater than id. It fails with
+// ErrOperationCompacted when some of those operations are only available in a snapshot.
+func (kv *KVStore) GetOperationsAfter(id int64) ([]common.Operation, error) {
 	kv.mu.RLock()
 	defer kv.mu.RUnlock()
 
-	idx, found := sort.Find(len(kv.opLog), func(i int) int {
-		return int(id - kv.opLog[i].ID)
+	if id+1 < kv.firstRetainedOpID() {
+		return nil, ErrOperationCompacted
+	}
+
+	idx := kv.opLogIndexAfter(id)
+
+	return slices.Clone(kv.opLog[idx:]), nil
+}
+
+// firstRetainedOpID returns the ID of the oldest operation still in the log,
+// the caller must hold the lock
+func (kv *KVStore) firstRetainedOpID() int64 {
+	if len(kv.opLog) == 0 {
+		return kv.nextOpID
+	}
+
+	return kv.opLog[0].ID
+}
+
+// opLogIndexAfter returns the index of the first operation in the log with an ID
+// greater than id, the caller must hold the lock
+func (kv *KVStore) opLogIndexAfter(id int64) int {
+	return sort.Search(len(kv.opLog), func(i int) bool {
+		return kv.opLog[i].ID > id
 	})
-	if !found {
-		slog.Warn("no operation found after", "after_id", id)
-		return nil
+}
+
+// applyOperation durably logs a single operation and applies it to the KVStore
+func (store *KVStore) applyOperation(op common.Operation) error {
+	if err := validateOperation(op); err != nil {
+		return err
 	}
 
-	var ops []common.Operation
-	for i := idx; i < len(kv.opLog); i++ {
-		op := kv.opLog[i]
-		if op.ID > id {
-			ops = append(ops, op)
+	if store.wal != nil {
+		if err := store.wal.Append(op); err != nil {
+			return fmt.Errorf("could not append operation to wal: %w", err)
 		}
 	}
 
-	return ops
+	store.applyToMemory(op)
+
+	return nil
 }
 
-// applyOperation applies a single operation to the KVStore
-func (store *KVStore) applyOperation(op common.Operation) error {
-	// Apply the operation based on its type
+// validateOperation checks that an operation can be applied
+func validateOperation(op common.Operation) error {
 	switch op.Type {
 	case common.Set:
 		if !op.Value.IsSpecified() {
 			return fmt.Errorf("set operation requires a value")
 		}
-		value, err := op.Value.Get()
-		if err != nil {
+		if _, err := op.Value.Get(); err != nil {
 			return fmt.Errorf("failed to get value from operation")
 		}
-		store.store[op.Key] = value
 	case common.Delete:
-		delete(store.store, op.Key)
 	default:
 		return fmt.Errorf("unknown operation type: %s", op.Type)
 	}
 
+	return nil
+}
+
+// applyToMemory applies a validated operation to the map and the operation log
+func (store *KVStore) applyToMemory(op common.Operation) {
+	if value, exists := store.store[op.Key]; exists {
+		store.memoryBytes -= entrySize(op.Key, value)
+	}
+
+	switch op.Type {
+	case common.Set:
+		store.store[op.Key] = op.Value.MustGet()
+		store.memoryBytes += entrySize(op.Key, op.Value.MustGet())
+	case common.Delete:
+		delete(store.store, op.Key)
+	}
+
 	// Add to operation log
 	store.opLog = append(store.opLog, op)
 	if op.ID >= store.nextOpID {
 		store.nextOpID = op.ID + 1
 	}
-
-	return nil
+	store.epoch = max(store.epoch, op.Epoch)
+	if op.Timestamp != nil {
+		store.lastAppliedAt = op.Timestamp
+	}
 }
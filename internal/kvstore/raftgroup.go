@@ -0,0 +1,385 @@
+package kvstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/computer-technology-team/distributed-kvstore/internal/raft"
+	"github.com/oapi-codegen/nullable"
+)
+
+// raftDirName is the sub-directory of a partition holding its Raft log
+const raftDirName = "raft"
+
+var (
+	// ErrNotLeader is returned for writes to a partition whose Raft group is led by another node
+	ErrNotLeader = errors.New("not the raft leader of the partition")
+	// ErrRaftGroupNotFound is returned for Raft requests to a partition this node runs no group for
+	ErrRaftGroupNotFound = errors.New("raft group not found")
+)
+
+type raftCommandType string
+
+const (
+	raftSet         raftCommandType = "set"
+	raftDelete      raftCommandType = "delete"
+	raftSetIfAbsent raftCommandType = "set_if_absent"
+)
+
+// raftCommand is the data of a Raft log entry, the entry's index is the ID of the
+// operation applying it and its term the operation's epoch
+type raftCommand struct {
+	Type      raftCommandType `json:"type"`
+	Key       string          `json:"key"`
+	Value     string          `json:"value,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// raftResult is the outcome of applying a command, applied is false for deletions of
+// missing keys and imports of existing keys
+type raftResult struct {
+	operationID int64
+	applied     bool
+	err         error
+}
+
+// raftTransport sends the Raft requests of a partition's group through the database API
+type raftTransport struct {
+	partitionID string
+}
+
+func (t raftTransport) RequestVote(ctx context.Context, peer raft.Peer,
+	req common.RaftVoteRequest) (common.RaftVoteResponse, error) {
+	client, err := database.NewClientWithResponses("http://" + peer.Address)
+	if err != nil {
+		return common.RaftVoteResponse{}, fmt.Errorf("could not create peer client: %w", err)
+	}
+
+	resp, err := client.RequestRaftVoteWithResponse(ctx, t.partitionID, req)
+	if err != nil {
+		return common.RaftVoteResponse{}, fmt.Errorf("could not request vote: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return common.RaftVoteResponse{}, fmt.Errorf("peer returned status %d", resp.StatusCode())
+	}
+
+	return *resp.JSON200, nil
+}
+
+func (t raftTransport) AppendEntries(ctx context.Context, peer raft.Peer,
+	req common.RaftAppendRequest) (common.RaftAppendResponse, error) {
+	client, err := database.NewClientWithResponses("http://" + peer.Address)
+	if err != nil {
+		return common.RaftAppendResponse{}, fmt.Errorf("could not create peer client: %w", err)
+	}
+
+	resp, err := client.AppendRaftEntriesWithResponse(ctx, t.partitionID, req)
+	if err != nil {
+		return common.RaftAppendResponse{}, fmt.Errorf("could not append entries: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return common.RaftAppendResponse{}, fmt.Errorf("peer returned status %d", resp.StatusCode())
+	}
+
+	return *resp.JSON200, nil
+}
+
+func (t raftTransport) InstallSnapshot(ctx context.Context, peer raft.Peer,
+	req common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error) {
+	client, err := database.NewClientWithResponses("http://" + peer.Address)
+	if err != nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("could not create peer client: %w", err)
+	}
+
+	resp, err := client.InstallRaftSnapshotWithResponse(ctx, t.partitionID, req)
+	if err != nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("could not install snapshot: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("peer returned status %d", resp.StatusCode())
+	}
+
+	return *resp.JSON200, nil
+}
+
+// reconcileRaftGroups runs a Raft group for every partition hosted by node and stops the
+// others. Roles assigned by the controller are ignored, the groups elect their leaders.
+// The caller must hold the lock.
+func (ns *NodeStore) reconcileRaftGroups(node common.Node) {
+	for partitionID, group := range ns.rafts {
+		if _, hosted := node.Partitions[partitionID]; hosted {
+			continue
+		}
+
+		if err := group.Stop(); err != nil {
+			slog.Error("could not stop raft group", "partition_id", partitionID, "error", err)
+		}
+		delete(ns.rafts, partitionID)
+	}
+
+	for partitionID := range node.Partitions {
+		store, stored := ns.stores[partitionID]
+		if !stored {
+			continue
+		}
+
+		store.mu.Lock()
+		store.isMaster = false
+		store.isSyncing = false
+		store.needsReset = false
+		store.mu.Unlock()
+
+		peers := ns.raftPeers(partitionID)
+		// The controller keeps the members of a group fixed, only their addresses change
+		if group, running := ns.rafts[partitionID]; running {
+			group.SetPeers(peers)
+			continue
+		}
+
+		group, err := ns.startRaftGroup(partitionID, store, peers)
+		if err != nil {
+			slog.Error("could not start raft group", "partition_id", partitionID, "error", err)
+			continue
+		}
+
+		ns.rafts[partitionID] = group
+	}
+}
+
+// raftPeers returns the other nodes hosting a partition, the caller must hold the lock
+func (ns *NodeStore) raftPeers(partitionID string) []raft.Peer {
+	var peers []raft.Peer
+	for _, n := range ns.state.Nodes {
+		if _, hosted := n.Partitions[partitionID]; hosted && n.Id != ns.id {
+			peers = append(peers, raft.Peer{ID: n.Id, Address: n.Address})
+		}
+	}
+
+	return peers
+}
+
+// startRaftGroup starts the Raft node of a partition, its log is kept next to the
+// partition's write-ahead log when storage is enabled
+func (ns *NodeStore) startRaftGroup(partitionID string, store *KVStore, peers []raft.Peer) (*raft.Node, error) {
+	storage := raft.NewMemoryStorage()
+	if store.dir != "" {
+		var err error
+		storage, err = raft.NewFileStorage(filepath.Join(store.dir, raftDirName))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	store.mu.RLock()
+	applied := store.nextOpID - 1
+	store.mu.RUnlock()
+
+	return raft.NewNode(raft.Config{
+		ID:                ns.id,
+		Peers:             peers,
+		Storage:           storage,
+		Transport:         raftTransport{partitionID: partitionID},
+		Apply:             applyRaftEntry(partitionID, store),
+		Applied:           applied,
+		Snapshot:          snapshotRaftGroup(partitionID, store),
+		Restore:           restoreRaftGroup(store),
+		SnapshotThreshold: ns.storage.SnapshotThreshold,
+		HeartbeatInterval: ns.replication.RaftHeartbeatInterval,
+		ElectionTimeout:   ns.replication.RaftElectionTimeout,
+		Logger:            slog.With("partition_id", partitionID),
+	})
+}
+
+// applyRaftEntry returns the state machine of a partition's Raft group
+func applyRaftEntry(partitionID string, store *KVStore) func(common.RaftEntry) any {
+	return func(entry common.RaftEntry) any {
+		// No-op entry of a new leader
+		if entry.Data == nil {
+			return raftResult{operationID: entry.Index}
+		}
+
+		var cmd raftCommand
+		if err := json.Unmarshal(*entry.Data, &cmd); err != nil {
+			slog.Error("could not decode raft entry", "partition_id", partitionID,
+				"index", entry.Index, "error", err)
+			return raftResult{err: fmt.Errorf("could not decode raft entry: %w", err)}
+		}
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		// Replayed after a restart, the operation is already in the write-ahead log
+		if entry.Index < store.nextOpID {
+			return raftResult{operationID: entry.Index}
+		}
+
+		op := common.Operation{
+			ID:        entry.Index,
+			Key:       cmd.Key,
+			Type:      common.Set,
+			Value:     nullable.NewNullableWithValue(cmd.Value),
+			Epoch:     entry.Term,
+			Timestamp: &cmd.Timestamp,
+		}
+
+		_, exists := store.store[cmd.Key]
+		switch cmd.Type {
+		case raftSetIfAbsent:
+			if exists {
+				return raftResult{operationID: entry.Index}
+			}
+		case raftDelete:
+			if !exists {
+				return raftResult{operationID: entry.Index}
+			}
+			op.Type = common.Delete
+			op.Value = nullable.NewNullNullable[string]()
+		}
+
+		if err := store.applyOperation(op); err != nil {
+			slog.Error("could not apply raft entry", "partition_id", partitionID,
+				"index", entry.Index, "error", err)
+			return raftResult{err: err}
+		}
+
+		return raftResult{operationID: op.ID, applied: true}
+	}
+}
+
+// snapshotRaftGroup returns the snapshots of a partition's Raft group, the index of the last
+// entry they cover is the ID of the last operation applied
+func snapshotRaftGroup(partitionID string, store *KVStore) func() ([]byte, int64, error) {
+	return func() ([]byte, int64, error) {
+		snapshot := store.Snapshot(partitionID)
+
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not encode snapshot: %w", err)
+		}
+
+		return data, snapshot.LastOperationId, nil
+	}
+}
+
+// restoreRaftGroup returns the restore function of a partition's Raft group, which replaces
+// the partition's content with the snapshot of the leader
+func restoreRaftGroup(store *KVStore) func([]byte, int64) error {
+	return func(data []byte, index int64) error {
+		var snapshot common.Snapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return fmt.Errorf("could not decode snapshot: %w", err)
+		}
+
+		if snapshot.LastOperationId != index {
+			return fmt.Errorf("snapshot covers operation %d, expected %d", snapshot.LastOperationId, index)
+		}
+
+		store.snapshotMu.Lock()
+		defer store.snapshotMu.Unlock()
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		return store.installSnapshot(snapshot)
+	}
+}
+
+// raftGroup returns the Raft group of a partition, nil when the partition replicates from a primary
+func (ns *NodeStore) raftGroup(partitionID string) *raft.Node {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
+	return ns.rafts[partitionID]
+}
+
+// proposeRaft commits cmd through the Raft group of a partition and waits until it was applied
+func (ns *NodeStore) proposeRaft(partitionID string, group *raft.Node, cmd raftCommand) (raftResult, error) {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return raftResult{}, fmt.Errorf("could not encode raft command: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ns.replication.WriteConcernTimeout)
+	defer cancel()
+
+	value, err := group.Propose(ctx, data)
+	switch {
+	case errors.Is(err, raft.ErrNotLeader), errors.Is(err, raft.ErrLeadershipLost):
+		return raftResult{}, ns.notLeaderError(partitionID, group)
+	case errors.Is(err, context.DeadlineExceeded):
+		return raftResult{}, fmt.Errorf("%w: entry not committed by a majority of the raft group", ErrWriteConcernTimeout)
+	case err != nil:
+		return raftResult{}, err
+	}
+
+	result := value.(raftResult)
+	return result, result.err
+}
+
+// notLeaderError names the leader of the group, when known, for the client to retry there
+func (ns *NodeStore) notLeaderError(partitionID string, group *raft.Node) error {
+	leader := group.Status().Leader
+
+	ns.mu.RLock()
+	node, found := extractNodeFromState(ns.state, leader)
+	ns.mu.RUnlock()
+
+	if !found {
+		return fmt.Errorf("%w %s, no leader known", ErrNotLeader, partitionID)
+	}
+
+	return fmt.Errorf("%w %s, leader is node %s at %s", ErrNotLeader, partitionID, node.Id, node.Address)
+}
+
+// HandleRaftVote passes a vote request to the Raft group of a partition
+func (ns *NodeStore) HandleRaftVote(partitionID string, req common.RaftVoteRequest) (common.RaftVoteResponse, error) {
+	group := ns.raftGroup(partitionID)
+	if group == nil {
+		return common.RaftVoteResponse{}, ErrRaftGroupNotFound
+	}
+
+	return group.HandleRequestVote(req)
+}
+
+// HandleRaftAppend passes the entries of the leader to the Raft group of a partition
+func (ns *NodeStore) HandleRaftAppend(partitionID string, req common.RaftAppendRequest) (common.RaftAppendResponse, error) {
+	group := ns.raftGroup(partitionID)
+	if group == nil {
+		return common.RaftAppendResponse{}, ErrRaftGroupNotFound
+	}
+
+	return group.HandleAppendEntries(req)
+}
+
+// HandleRaftSnapshot passes the snapshot of the leader to the Raft group of a partition
+func (ns *NodeStore) HandleRaftSnapshot(partitionID string, req common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error) {
+	group := ns.raftGroup(partitionID)
+	if group == nil {
+		return common.RaftSnapshotResponse{}, ErrRaftGroupNotFound
+	}
+
+	return group.HandleInstallSnapshot(req)
+}
+
+// stopRaftGroups stops every Raft group, the caller must hold the lock
+func (ns *NodeStore) stopRaftGroups() error {
+	var errs []error
+	for partitionID, group := range ns.rafts {
+		if err := group.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("could not stop raft group of partition %s: %w", partitionID, err))
+		}
+	}
+	ns.rafts = nil
+
+	return errors.Join(errs...)
+}
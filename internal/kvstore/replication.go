@@ -1,62 +1,110 @@
 package kvstore
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"log/slog"
+	"time"
 
 	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/samber/lo"
 )
 
-func (ns *NodeStore) sendOperationToReplicas(partitionID string, op common.Operation) {
-	// Get replicas for this partition from state
-	ns.mu.RLock()
-	node, found := extractNodeFromState(ns.state, ns.id)
-	if !found {
-		ns.mu.RUnlock()
-		return
+// syncTimeout bounds a full catch-up of a replica with its master
+const syncTimeout = 30 * time.Second
+
+// replicationRequestTimeout bounds sending a single operation to a replica
+const replicationRequestTimeout = 5 * time.Second
+
+var (
+	// ErrWriteConcernTimeout is returned when not enough replicas acknowledged a write in time
+	ErrWriteConcernTimeout = errors.New("write concern timed out")
+	// ErrWriteConcernFailed is returned when too many replicas rejected a write to satisfy its write concern
+	ErrWriteConcernFailed = errors.New("write concern failed")
+)
+
+// ReplicationOptions configures how masters replicate operations to their replicas
+type ReplicationOptions struct {
+	// WriteConcernTimeout is how long a write waits for the replica
+	// acknowledgements its write concern requires
+	WriteConcernTimeout time.Duration
+	// QueueSize is the number of operations queued per replica before the
+	// replica is caught up from the log instead
+	QueueSize int
+
+	// RaftHeartbeatInterval is how often Raft leaders contact their followers
+	RaftHeartbeatInterval time.Duration
+	// RaftElectionTimeout is the minimum time without a Raft leader before a
+	// follower starts an election
+	RaftElectionTimeout time.Duration
+}
+
+// replicate queues op to every replica of the partition and waits until as many of them
+// acknowledged it as the write concern requires. Replicas that did not answer yet keep
+// receiving the operation in the background.
+func (ns *NodeStore) replicate(partitionID string, op common.Operation, writeConcern common.WriteConcern) error {
+	senders := ns.replicaSenders(partitionID)
+	if len(senders) == 0 {
+		return nil
 	}
 
-	role, exists := node.Partitions[partitionID]
-	if !exists || !role.IsMaster {
-		ns.mu.RUnlock()
-		return
+	for _, sender := range senders {
+		sender.enqueue(op)
 	}
 
-	// Find all replica nodes for this partition
-	replicaNodes := make([]common.Node, 0)
-	for _, n := range ns.state.Nodes {
-		if r, ok := n.Partitions[partitionID]; ok && !r.IsMaster {
-			replicaNodes = append(replicaNodes, n)
-		}
+	required := writeConcern.RequiredAcks(len(senders))
+	if required == 0 {
+		return nil
 	}
-	ns.mu.RUnlock()
 
-	// Send operation to all replicas
-	for _, replica := range replicaNodes {
-		url := "http://" + replica.Address + "/api/v1/partitions/" + partitionID + "/operations"
-		payload, err := json.Marshal(op)
-		if err != nil {
-			fmt.Printf("failed to marshal operation: %v\n", err)
-			continue
-		}
-		resp, err := http.Post(url, "application/json", bytes.NewReader(payload))
-		if err != nil {
-			fmt.Printf("failed to send operation to replica %s: %v\n", replica.Address, err)
-			continue
+	results := make(chan error, len(senders))
+	for _, sender := range senders {
+		sender.waitFor(op.ID, results)
+	}
+
+	timer := time.NewTimer(ns.replication.WriteConcernTimeout)
+	defer timer.Stop()
+
+	acks, failures := 0, 0
+	for acks < required {
+		select {
+		case err := <-results:
+			if err != nil {
+				failures++
+			} else {
+				acks++
+			}
+
+			if len(senders)-failures < required {
+				return fmt.Errorf("%w: %d of %d required replicas acknowledged operation %d",
+					ErrWriteConcernFailed, acks, required, op.ID)
+			}
+		case <-timer.C:
+			return fmt.Errorf("%w: %d of %d required replicas acknowledged operation %d",
+				ErrWriteConcernTimeout, acks, required, op.ID)
 		}
-		resp.Body.Close()
 	}
+
+	return nil
 }
 
 // ApplyOperation applies an operation to a non-master partition
 func (ns *NodeStore) ApplyOperation(partitionID string, op common.Operation) error {
+	_, _, err := ns.ApplyOperations(partitionID, []common.Operation{op})
+	return err
+}
+
+// ApplyOperations applies a batch of operations sorted by ID to a non-master partition and
+// returns the ID of the last operation applied. Operations already applied are skipped, on
+// a gap the replica stops applying and catches up with the master.
+func (ns *NodeStore) ApplyOperations(partitionID string, ops []common.Operation) (int64, bool, error) {
 	ns.mu.RLock()
 	store, exists := ns.stores[partitionID]
 	if !exists {
 		ns.mu.RUnlock()
-		return fmt.Errorf("partition %s not found", partitionID)
+		return 0, false, fmt.Errorf("partition %s not found", partitionID)
 	}
 	ns.mu.RUnlock()
 
@@ -65,97 +113,235 @@ func (ns *NodeStore) ApplyOperation(partitionID string, op common.Operation) err
 
 	// Only allow operations on non-master partitions
 	if store.isMaster {
-		return fmt.Errorf("partition %s is the master, cannot apply operation", partitionID)
+		return 0, false, fmt.Errorf("partition %s is the master, cannot apply operation", partitionID)
+	}
+
+	for _, op := range ops {
+		// Operations from a master of an older epoch were never acknowledged by the current one
+		if op.Epoch < store.epoch {
+			return store.nextOpID - 1, store.isSyncing,
+				fmt.Errorf("%w: operation epoch %d, partition epoch %d", ErrStaleEpoch, op.Epoch, store.epoch)
+		}
+
+		// Already applied, e.g. resent after a lost acknowledgement
+		if op.ID < store.nextOpID {
+			continue
+		}
+
+		// Operations are missing, request them from the master asynchronously
+		if op.ID > store.nextOpID {
+			store.isSyncing = true
+			ns.startSync(partitionID, store)
+			break
+		}
+
+		if err := store.applyOperation(op); err != nil {
+			return store.nextOpID - 1, store.isSyncing, err
+		}
+	}
+
+	return store.nextOpID - 1, store.isSyncing, nil
+}
+
+// startSync catches store up with the master in the background unless a catch-up is already
+// running. Failed attempts are retried with backoff for as long as this node is a replica of the partition.
+func (ns *NodeStore) startSync(partitionID string, store *KVStore) {
+	if !store.syncRunning.CompareAndSwap(false, true) {
+		return
 	}
 
-	// Check for missing operations (gap > 1)
-	if op.ID > store.nextOpID && (op.ID-store.nextOpID) > 1 {
-		store.isSyncing = true
-		// Request missing operations from master asynchronously
-		go ns.syncReplicaPartitionWithMaster(partitionID)
-		return fmt.Errorf("partition %s is syncing: missing operations", partitionID)
+	go func() {
+		backoff := replicationMinBackoff
+		for ns.isReplicaStore(partitionID, store) {
+			err := ns.syncReplicaPartitionWithMaster(partitionID, store)
+			if err == nil {
+				break
+			}
+
+			slog.Error("failed to sync partition with master", "partition_id", partitionID,
+				"retry_in", backoff, "error", err)
+			time.Sleep(backoff)
+			backoff = min(backoff*2, replicationMaxBackoff)
+		}
+
+		store.syncRunning.Store(false)
+
+		// A reset may have been requested while the catch-up was finishing
+		store.mu.RLock()
+		again := store.needsReset && !store.isMaster
+		store.mu.RUnlock()
+
+		if again {
+			ns.startSync(partitionID, store)
+		}
+	}()
+}
+
+// isReplicaStore reports whether store still holds a partition this node is a replica of
+func (ns *NodeStore) isReplicaStore(partitionID string, store *KVStore) bool {
+	ns.mu.RLock()
+	current, exists := ns.stores[partitionID]
+	ns.mu.RUnlock()
+	if !exists || current != store {
+		return false
 	}
 
-	// Apply the operation using the extracted method
-	if err := store.applyOperation(op); err != nil {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	return !store.isMaster
+}
+
+// syncReplicaPartitionWithMaster catches a replica up with the master by applying the operations it
+// misses. A replica that must be reset, or whose missing operations were compacted away, first
+// installs the master's snapshot. The replica reports it is no longer syncing once caught up.
+func (ns *NodeStore) syncReplicaPartitionWithMaster(partitionID string, store *KVStore) error {
+	client, err := ns.replicaMasterClient(partitionID)
+	if err != nil {
 		return err
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+
+	store.snapshotMu.Lock()
+	defer store.snapshotMu.Unlock()
+
+	store.mu.Lock()
+	reset := store.needsReset
+	store.needsReset = false
+	store.isSyncing = true
+	checkpoint := store.nextOpID - 1
+	store.mu.Unlock()
+
+	// The content may have diverged from the master, start over from its snapshot
+	if reset {
+		checkpoint, err = installSnapshotFromMaster(ctx, client, store, partitionID)
+		if err != nil {
+			ns.scheduleReset(store)
+			return fmt.Errorf("could not reset partition from master snapshot: %w", err)
+		}
+	}
+
+	operations, err := fetchOperationsAfter(ctx, client, partitionID, checkpoint)
+	if errors.Is(err, ErrOperationCompacted) {
+		// The master no longer has the operations we miss, start over from its snapshot
+		checkpoint, err = installSnapshotFromMaster(ctx, client, store, partitionID)
+		if err != nil {
+			return fmt.Errorf("could not install snapshot from master: %w", err)
+		}
+
+		operations, err = fetchOperationsAfter(ctx, client, partitionID, checkpoint)
+	}
+	if err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	// Apply the operations to the local store, skipping the ones that
+	// were replicated to us while we were fetching
+	for _, op := range operations {
+		if op.ID < store.nextOpID {
+			continue
+		}
+
+		if err := store.applyOperation(op); err != nil {
+			return fmt.Errorf("could not apply operation %d: %w", op.ID, err)
+		}
+	}
+
+	// Mark syncing as complete
+	store.isSyncing = false
+	slog.Info("successfully synced partition with master", "partition_id", partitionID,
+		"next_operation_id", store.nextOpID)
+
 	return nil
 }
 
-func (ns *NodeStore) syncReplicaPartitionWithMaster(partitionID string) {
+// scheduleReset marks store to be reset from the master's snapshot by the next catch-up
+func (ns *NodeStore) scheduleReset(store *KVStore) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.needsReset = true
+}
+
+// replicaMasterClient returns a client for the master of a partition this node is a replica of
+func (ns *NodeStore) replicaMasterClient(partitionID string) (database.ClientWithResponsesInterface, error) {
 	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
 	node, found := extractNodeFromState(ns.state, ns.id)
 	if !found {
-		ns.mu.RUnlock()
-		fmt.Printf("failed to sync partition %s: node not found in state\n", partitionID)
-		return
+		return nil, errors.New("node not found in state")
 	}
 
 	role, exists := node.Partitions[partitionID]
 	if !exists || role.IsMaster {
-		ns.mu.RUnlock()
-		fmt.Printf("failed to sync partition %s: not a replica\n", partitionID)
-		return
+		return nil, errors.New("not a replica")
 	}
 
 	// Find the master node for this partition
-	var masterNode *common.Node
-	for _, n := range ns.state.Nodes {
-		if r, ok := n.Partitions[partitionID]; ok && r.IsMaster {
-			masterNode = &n
-			break
-		}
+	masterNode, found := lo.Find(ns.state.Nodes, func(n common.Node) bool {
+		r, ok := n.Partitions[partitionID]
+		return ok && r.IsMaster
+	})
+	if !found {
+		return nil, errors.New("master node not found")
 	}
-	ns.mu.RUnlock()
 
-	if masterNode == nil {
-		fmt.Printf("failed to sync partition %s: master node not found\n", partitionID)
-		return
+	client, err := database.NewClientWithResponses("http://" + masterNode.Address)
+	if err != nil {
+		return nil, fmt.Errorf("could not create master client: %w", err)
 	}
 
-	// Request missing operations from the master
-	url := "http://" + masterNode.Address + "/api/v1/partitions/" + partitionID + "/operations/sync"
-	resp, err := http.Get(url)
+	return client, nil
+}
+
+// fetchOperationsAfter requests the operations following checkpoint from the master
+func fetchOperationsAfter(ctx context.Context, client database.ClientWithResponsesInterface,
+	partitionID string, checkpoint int64) ([]common.Operation, error) {
+	resp, err := client.GetOperationsAfterWithResponse(ctx, partitionID, checkpoint)
 	if err != nil {
-		fmt.Printf("failed to sync partition %s from master: %v\n", partitionID, err)
-		return
+		return nil, fmt.Errorf("could not get operations from master: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		fmt.Printf("failed to sync partition %s: master returned status %d\n", partitionID, resp.StatusCode)
-		return
+	switch {
+	case resp.JSON200 != nil:
+		return *resp.JSON200, nil
+	case resp.JSON410 != nil:
+		return nil, ErrOperationCompacted
+	default:
+		return nil, fmt.Errorf("master returned status %d", resp.StatusCode())
 	}
+}
 
-	var operations []common.Operation
-	if err := json.NewDecoder(resp.Body).Decode(&operations); err != nil {
-		fmt.Printf("failed to decode operations for partition %s: %v\n", partitionID, err)
-		return
+// installSnapshotFromMaster replaces the content of store with the master's snapshot and
+// returns the ID of the last operation it covers, the caller must hold snapshotMu of store
+func installSnapshotFromMaster(ctx context.Context, client database.ClientWithResponsesInterface,
+	store *KVStore, partitionID string) (int64, error) {
+	resp, err := client.GetSnapshotWithResponse(ctx, partitionID)
+	if err != nil {
+		return 0, fmt.Errorf("could not get snapshot from master: %w", err)
 	}
 
-	// Apply the operations to the local store
-	ns.mu.RLock()
-	store, exists := ns.stores[partitionID]
-	ns.mu.RUnlock()
-	if !exists {
-		fmt.Printf("failed to sync partition %s: store not found\n", partitionID)
-		return
+	if resp.JSON200 == nil {
+		return 0, fmt.Errorf("master returned status %d", resp.StatusCode())
 	}
 
+	snapshot := *resp.JSON200
+
 	store.mu.Lock()
 	defer store.mu.Unlock()
 
-	for _, op := range operations {
-		// Apply each operation using the extracted method
-		if err := store.applyOperation(op); err != nil {
-			fmt.Printf("failed to apply operation %d: %v\n", op.ID, err)
-			continue
-		}
+	if err := store.installSnapshot(snapshot); err != nil {
+		return 0, err
 	}
 
-	// Mark syncing as complete
-	store.isSyncing = false
-	fmt.Printf("successfully synced partition %s with master\n", partitionID)
+	slog.Info("installed snapshot from master", "partition_id", partitionID,
+		"last_operation_id", snapshot.LastOperationId)
+
+	return snapshot.LastOperationId, nil
 }
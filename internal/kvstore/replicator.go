@@ -0,0 +1,383 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/google/uuid"
+)
+
+const (
+	// replicationBatchSize is the maximum number of operations sent to a replica per request
+	replicationBatchSize = 256
+	// replicationMinBackoff and replicationMaxBackoff bound the delay between retries to a failing replica
+	replicationMinBackoff = 100 * time.Millisecond
+	replicationMaxBackoff = 5 * time.Second
+)
+
+// ackWaiter is notified once the replica acknowledged operation opID
+type ackWaiter struct {
+	opID   int64
+	result chan<- error
+}
+
+// replicaSender is the long-lived pipeline replicating the operations of a master
+// partition to one of its replicas. Operations are queued as they are applied and
+// sent in ID order in batches. When the queue overflows, or the replica reports it
+// is behind, the missing operations are read back from the partition's log.
+type replicaSender struct {
+	partitionID string
+	nodeID      uuid.UUID
+	address     string
+	store       *KVStore
+	client      database.ClientWithResponsesInterface
+	maxQueue    int
+
+	mu sync.Mutex
+	// queue holds operations not yet acknowledged, sorted by ID
+	queue []common.Operation
+	// ackedID is the ID of the last operation the replica acknowledged
+	ackedID int64
+	waiters []ackWaiter
+
+	notify chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func newReplicaSender(partitionID string, replica common.Node, store *KVStore, maxQueue int) (*replicaSender, error) {
+	client, err := database.NewClientWithResponses("http://" + replica.Address)
+	if err != nil {
+		return nil, fmt.Errorf("could not create replica client: %w", err)
+	}
+
+	store.mu.RLock()
+	// The replica is assumed up to date, it reports how far it actually is
+	// in its first acknowledgement
+	ackedID := store.nextOpID - 1
+	store.mu.RUnlock()
+
+	s := &replicaSender{
+		partitionID: partitionID,
+		nodeID:      replica.Id,
+		address:     replica.Address,
+		store:       store,
+		client:      client,
+		maxQueue:    maxQueue,
+		ackedID:     ackedID,
+		notify:      make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// enqueue schedules op to be sent to the replica
+func (s *replicaSender) enqueue(op common.Operation) {
+	s.mu.Lock()
+	if len(s.queue) >= s.maxQueue {
+		// The replica is too far behind, the operations are read back from the log instead
+		s.queue = nil
+	} else {
+		idx := sort.Search(len(s.queue), func(i int) bool {
+			return s.queue[i].ID >= op.ID
+		})
+		s.queue = slices.Insert(s.queue, idx, op)
+	}
+	s.mu.Unlock()
+
+	s.wake()
+}
+
+func (s *replicaSender) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// waitFor reports on result once the replica acknowledged operation opID or failed for good
+func (s *replicaSender) waitFor(opID int64, result chan<- error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ackedID >= opID {
+		result <- nil
+		return
+	}
+
+	s.waiters = append(s.waiters, ackWaiter{opID: opID, result: result})
+}
+
+// status returns the replication progress of the replica
+func (s *replicaSender) status() common.ReplicaStatus {
+	s.store.mu.RLock()
+	lastOpID := s.store.nextOpID - 1
+	s.store.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return common.ReplicaStatus{
+		NodeId:                      s.nodeID,
+		LastAcknowledgedOperationId: s.ackedID,
+		Lag:                         max(lastOpID-s.ackedID, 0),
+		QueueLength:                 len(s.queue),
+	}
+}
+
+// close stops the sender, waiters still pending are failed
+func (s *replicaSender) close() {
+	close(s.stop)
+}
+
+func (s *replicaSender) run() {
+	defer close(s.done)
+	defer s.failWaiters(errors.New("replication to replica stopped"))
+
+	backoff := replicationMinBackoff
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.notify:
+		}
+
+		for {
+			batch := s.nextBatch()
+			if len(batch) == 0 {
+				break
+			}
+
+			ack, err := s.send(batch)
+			if err != nil {
+				slog.Error("failed to replicate operations", "partition_id", s.partitionID,
+					"replica_address", s.address, "first_operation_id", batch[0].ID,
+					"operations", len(batch), "retry_in", backoff, "error", err)
+
+				if errors.Is(err, ErrStaleEpoch) {
+					// This node is no longer the master, its writes must not be acknowledged
+					s.failWaiters(err)
+				}
+
+				select {
+				case <-s.stop:
+					return
+				case <-time.After(backoff):
+				}
+
+				backoff = min(backoff*2, replicationMaxBackoff)
+				continue
+			}
+
+			backoff = replicationMinBackoff
+			s.acknowledge(ack)
+
+			if ack.IsSyncing {
+				// The replica pulls what it misses from the master on its own
+				select {
+				case <-s.stop:
+					return
+				case <-time.After(replicationMinBackoff):
+				}
+			}
+		}
+	}
+}
+
+// nextBatch returns the operations following the last acknowledged one, taken from
+// the queue or, when the queue does not start there, from the partition's log
+func (s *replicaSender) nextBatch() []common.Operation {
+	s.mu.Lock()
+	next := s.ackedID + 1
+
+	// Drop the operations the replica already has
+	idx := sort.Search(len(s.queue), func(i int) bool {
+		return s.queue[i].ID >= next
+	})
+	s.queue = s.queue[idx:]
+
+	if len(s.queue) > 0 && s.queue[0].ID == next {
+		batch := slices.Clone(s.queue[:min(len(s.queue), replicationBatchSize)])
+		s.mu.Unlock()
+		return batch
+	}
+	s.mu.Unlock()
+
+	return s.store.operationsFrom(next, replicationBatchSize)
+}
+
+func (s *replicaSender) send(batch []common.Operation) (*database.ReplicationAck, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), replicationRequestTimeout)
+	defer cancel()
+
+	resp, err := s.client.ApplyOperationsWithResponse(ctx, s.partitionID, batch)
+	if err != nil {
+		return nil, fmt.Errorf("could not send operations: %w", err)
+	}
+
+	switch {
+	case resp.JSON200 != nil:
+		return resp.JSON200, nil
+	case resp.JSON409 != nil:
+		return nil, fmt.Errorf("%w: %s", ErrStaleEpoch, resp.JSON409.Message)
+	case resp.JSON400 != nil:
+		return nil, fmt.Errorf("replica rejected operations: %s", resp.JSON400.Error)
+	default:
+		return nil, fmt.Errorf("replica returned status %d", resp.StatusCode())
+	}
+}
+
+// acknowledge records the progress reported by the replica and notifies the waiters it satisfies
+func (s *replicaSender) acknowledge(ack *database.ReplicationAck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ackedID = ack.LastAppliedOperationId
+
+	s.waiters = slices.DeleteFunc(s.waiters, func(w ackWaiter) bool {
+		if w.opID > s.ackedID {
+			return false
+		}
+
+		w.result <- nil
+		return true
+	})
+}
+
+func (s *replicaSender) failWaiters(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, w := range s.waiters {
+		w.result <- err
+	}
+	s.waiters = nil
+}
+
+// operationsFrom returns up to limit operations of the log starting at ID from. When
+// from was compacted away the batch starts at the oldest retained operation, so that
+// the replica detects the gap and installs a snapshot.
+func (kv *KVStore) operationsFrom(from int64, limit int) []common.Operation {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+
+	idx := kv.opLogIndexAfter(from - 1)
+	end := min(idx+limit, len(kv.opLog))
+
+	return slices.Clone(kv.opLog[idx:end])
+}
+
+// reconcileReplicaSenders starts a sender for every replica of the partitions this
+// node is the master of and stops the others, the caller must hold the lock
+func (ns *NodeStore) reconcileReplicaSenders() {
+	node, found := extractNodeFromState(ns.state, ns.id)
+	// Raft groups replicate their entries themselves
+	found = found && !ns.state.UsesRaft()
+
+	for partitionID, senders := range ns.senders {
+		role, hosted := node.Partitions[partitionID]
+		_, stored := ns.stores[partitionID]
+		if found && hosted && role.IsMaster && stored {
+			continue
+		}
+
+		for nodeID, sender := range senders {
+			sender.close()
+			delete(senders, nodeID)
+		}
+		delete(ns.senders, partitionID)
+	}
+
+	if !found {
+		return
+	}
+
+	for partitionID, role := range node.Partitions {
+		store, stored := ns.stores[partitionID]
+		if !role.IsMaster || !stored {
+			continue
+		}
+
+		replicas := make(map[uuid.UUID]common.Node)
+		for _, n := range ns.state.Nodes {
+			if r, ok := n.Partitions[partitionID]; ok && !r.IsMaster && n.Id != ns.id {
+				replicas[n.Id] = n
+			}
+		}
+
+		senders := ns.senders[partitionID]
+		if senders == nil {
+			senders = make(map[uuid.UUID]*replicaSender)
+			ns.senders[partitionID] = senders
+		}
+
+		for nodeID, sender := range senders {
+			if replica, ok := replicas[nodeID]; ok && replica.Address == sender.address {
+				continue
+			}
+
+			sender.close()
+			delete(senders, nodeID)
+		}
+
+		for nodeID, replica := range replicas {
+			if _, running := senders[nodeID]; running {
+				continue
+			}
+
+			sender, err := newReplicaSender(partitionID, replica, store, ns.replication.QueueSize)
+			if err != nil {
+				slog.Error("could not start replica sender", "partition_id", partitionID,
+					"replica_address", replica.Address, "error", err)
+				continue
+			}
+
+			senders[nodeID] = sender
+		}
+	}
+}
+
+// replicaSenders returns the senders of a partition this node is the master of
+func (ns *NodeStore) replicaSenders(partitionID string) []*replicaSender {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
+	senders := make([]*replicaSender, 0, len(ns.senders[partitionID]))
+	for _, sender := range ns.senders[partitionID] {
+		senders = append(senders, sender)
+	}
+
+	return senders
+}
+
+// replicaStatuses returns the replication progress of the replicas of a partition
+func (ns *NodeStore) replicaStatuses(partitionID string) []common.ReplicaStatus {
+	senders := ns.senders[partitionID]
+	if len(senders) == 0 {
+		return nil
+	}
+
+	statuses := make([]common.ReplicaStatus, 0, len(senders))
+	for _, sender := range senders {
+		statuses = append(statuses, sender.status())
+	}
+
+	slices.SortFunc(statuses, func(a, b common.ReplicaStatus) int {
+		return slices.Compare(a.NodeId[:], b.NodeId[:])
+	})
+
+	return statuses
+}
@@ -0,0 +1,209 @@
+package kvstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+)
+
+const (
+	snapshotFileName = "snapshot.json"
+	// installingSnapshotFileName holds a snapshot being installed until the log it replaces
+	// was reset, it is then renamed over the snapshot file
+	installingSnapshotFileName = "snapshot.installing.json"
+)
+
+// snapshotLocked builds a point-in-time snapshot of the partition, the caller must hold the lock
+func (kv *KVStore) snapshotLocked(partitionID string) common.Snapshot {
+	return common.Snapshot{
+		PartitionId:     partitionID,
+		LastOperationId: kv.nextOpID - 1,
+		Data:            maps.Clone(kv.store),
+	}
+}
+
+// Snapshot returns a point-in-time snapshot of the partition
+func (kv *KVStore) Snapshot(partitionID string) common.Snapshot {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+
+	return kv.snapshotLocked(partitionID)
+}
+
+// compact persists a snapshot of the partition, drops the write-ahead log records
+// it covers and truncates the in-memory operation log down to retention operations
+func (kv *KVStore) compact(partitionID string, retention int) error {
+	kv.snapshotMu.Lock()
+	defer kv.snapshotMu.Unlock()
+
+	kv.mu.RLock()
+	snapshot := kv.snapshotLocked(partitionID)
+	kv.mu.RUnlock()
+
+	// Writing the snapshot happens outside the lock so that writes are not blocked,
+	// operations applied meanwhile stay in the log and are kept by the rewrite below
+	if kv.dir != "" {
+		if err := writeSnapshotFile(kv.dir, snapshotFileName, snapshot); err != nil {
+			return err
+		}
+	}
+
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	idx := kv.opLogIndexAfter(snapshot.LastOperationId)
+
+	if kv.wal != nil {
+		if err := kv.wal.Rewrite(kv.opLog[idx:]); err != nil {
+			return fmt.Errorf("could not truncate wal: %w", err)
+		}
+	}
+
+	kv.snapshotOpID = snapshot.LastOperationId
+	kv.opLog = truncateOpLog(kv.opLog, max(len(kv.opLog)-idx, retention))
+
+	return nil
+}
+
+// installSnapshot replaces the content of the partition with snapshot, the caller must hold
+// both snapshotMu and the lock. The log holds operations of the replaced content that may
+// come after the snapshot's last operation, so the snapshot is first written aside and only
+// replaces the snapshot file once the log was reset.
+func (kv *KVStore) installSnapshot(snapshot common.Snapshot) error {
+	if kv.dir != "" {
+		if err := writeSnapshotFile(kv.dir, installingSnapshotFileName, snapshot); err != nil {
+			return err
+		}
+	}
+
+	if kv.wal != nil {
+		if err := kv.wal.Rewrite(nil); err != nil {
+			return fmt.Errorf("could not reset wal: %w", err)
+		}
+	}
+
+	if kv.dir != "" {
+		if err := commitInstalledSnapshot(kv.dir); err != nil {
+			return err
+		}
+	}
+
+	kv.store = snapshot.Data
+	if kv.store == nil {
+		kv.store = make(map[string]string)
+	}
+	kv.memoryBytes = dataSize(kv.store)
+	kv.opLog = nil
+	kv.nextOpID = snapshot.LastOperationId + 1
+	kv.snapshotOpID = snapshot.LastOperationId
+
+	return nil
+}
+
+// opsSinceSnapshot returns the number of operations applied after the latest snapshot
+func (kv *KVStore) opsSinceSnapshot() int64 {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+
+	return kv.nextOpID - 1 - kv.snapshotOpID
+}
+
+// truncateOpLog keeps the last keep operations of opLog in a freshly allocated
+// slice so that the memory of the dropped operations can be reclaimed
+func truncateOpLog(opLog []common.Operation, keep int) []common.Operation {
+	if keep >= len(opLog) {
+		return opLog
+	}
+
+	return append([]common.Operation(nil), opLog[len(opLog)-keep:]...)
+}
+
+// commitInstalledSnapshot replaces the snapshot file in dir with the installed snapshot
+func commitInstalledSnapshot(dir string) error {
+	if err := os.Rename(filepath.Join(dir, installingSnapshotFileName), filepath.Join(dir, snapshotFileName)); err != nil {
+		return fmt.Errorf("could not replace snapshot file: %w", err)
+	}
+
+	return syncDir(dir)
+}
+
+// writeSnapshotFile atomically replaces the snapshot file name in dir
+func writeSnapshotFile(dir, name string, snapshot common.Snapshot) error {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("could not marshal snapshot: %w", err)
+	}
+
+	path := filepath.Join(dir, name)
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not create snapshot file: %w", err)
+	}
+
+	if _, err := file.Write(payload); err != nil {
+		file.Close()
+		return fmt.Errorf("could not write snapshot file: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("could not sync snapshot file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("could not close snapshot file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("could not replace snapshot file: %w", err)
+	}
+
+	return syncDir(dir)
+}
+
+// readSnapshotFile loads the snapshot file name stored in dir, returning nil when there is none
+func readSnapshotFile(dir, name string) (*common.Snapshot, error) {
+	payload, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot file: %w", err)
+	}
+
+	var snapshot common.Snapshot
+	if err := json.Unmarshal(payload, &snapshot); err != nil {
+		return nil, fmt.Errorf("could not decode snapshot file: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// compactPartitions snapshots every partition that applied at least
+// SnapshotThreshold operations since its last snapshot
+func (ns *NodeStore) compactPartitions() {
+	ns.mu.RLock()
+	stores := maps.Clone(ns.stores)
+	ns.mu.RUnlock()
+
+	for partitionID, store := range stores {
+		if store.opsSinceSnapshot() < ns.storage.SnapshotThreshold {
+			continue
+		}
+
+		if err := store.compact(partitionID, ns.storage.LogRetention); err != nil {
+			slog.Error("could not compact partition", "partition_id", partitionID, "error", err)
+			continue
+		}
+
+		slog.Info("compacted partition", "partition_id", partitionID)
+	}
+}
@@ -0,0 +1,68 @@
+package kvstore
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+)
+
+func TestNewKVStoreInstanceFinishesInterruptedInstall(t *testing.T) {
+	storage := StorageOptions{DataDir: t.TempDir()}
+	dir := storage.partitionDir("partition")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// The log of the replaced content goes past the installed snapshot
+	if err := os.WriteFile(filepath.Join(dir, walFileName),
+		slices.Concat(walRecord(t, 0), walRecord(t, 1), walRecord(t, 2)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	installed := common.Snapshot{
+		PartitionId:     "partition",
+		LastOperationId: 0,
+		Data:            map[string]string{"installed": "value"},
+	}
+	if err := writeSnapshotFile(dir, installingSnapshotFileName, installed); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := newKVStoreInstance("partition", storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.wal.Close()
+
+	if !maps.Equal(store.store, installed.Data) {
+		t.Errorf("got content %v, want %v", store.store, installed.Data)
+	}
+	if store.nextOpID != installed.LastOperationId+1 {
+		t.Errorf("got next operation %d, want %d", store.nextOpID, installed.LastOperationId+1)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, installingSnapshotFileName)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("installing snapshot was not committed: %v", err)
+	}
+
+	snapshot, err := readSnapshotFile(dir, snapshotFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot == nil || !maps.Equal(snapshot.Data, installed.Data) {
+		t.Errorf("got snapshot %v, want %v", snapshot, installed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, walFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 0 {
+		t.Errorf("wal of the replaced content was kept, %d bytes", len(data))
+	}
+}
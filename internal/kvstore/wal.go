@@ -0,0 +1,365 @@
+package kvstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+)
+
+// SyncPolicy controls when the write-ahead log is flushed to stable storage
+type SyncPolicy string
+
+const (
+	// SyncAlways fsyncs the log after every appended operation
+	SyncAlways SyncPolicy = "always"
+	// SyncInterval fsyncs the log periodically in the background
+	SyncInterval SyncPolicy = "interval"
+	// SyncNever leaves flushing to the operating system
+	SyncNever SyncPolicy = "never"
+)
+
+const (
+	walFileName     = "wal.log"
+	walHeaderLength = 8
+	// maxWALRecordLength bounds the payload of a record, a larger length read back can only
+	// come from a corrupt header
+	maxWALRecordLength = 64 << 20
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrCorruptWAL is returned when opening a write-ahead log that is damaged before its last record
+var ErrCorruptWAL = errors.New("wal is corrupt")
+
+// StorageOptions configures where and how partitions are persisted and compacted
+type StorageOptions struct {
+	// DataDir is the directory holding one sub-directory per partition.
+	// An empty DataDir keeps partitions in memory only.
+	DataDir      string
+	SyncPolicy   SyncPolicy
+	SyncInterval time.Duration
+
+	// SnapshotInterval is how often partitions are checked for compaction,
+	// zero disables snapshots
+	SnapshotInterval time.Duration
+	// SnapshotThreshold is the number of operations since the last snapshot
+	// that triggers a new one
+	SnapshotThreshold int64
+	// LogRetention is the number of operations kept in memory behind a snapshot
+	// so that slightly lagging replicas can still catch up from the log
+	LogRetention int
+}
+
+func (o StorageOptions) enabled() bool {
+	return o.DataDir != ""
+}
+
+func (o StorageOptions) partitionDir(partitionID string) string {
+	return filepath.Join(o.DataDir, partitionID)
+}
+
+// ParseSyncPolicy validates a sync policy name coming from configuration
+func ParseSyncPolicy(policy string) (SyncPolicy, error) {
+	switch SyncPolicy(policy) {
+	case SyncAlways, SyncInterval, SyncNever:
+		return SyncPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("unknown wal sync policy %q", policy)
+	}
+}
+
+// wal is an append-only, checksummed log of the operations applied to a partition.
+// Every record is framed as a 4 byte big-endian payload length, a 4 byte CRC32-C
+// of the payload and the JSON encoded operation.
+type wal struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	policy SyncPolicy
+	dirty  bool
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// openWAL opens (or creates) the log in dir and returns every intact operation in it.
+// A torn or corrupted last record, as left behind by a crash in the middle of a write,
+// is truncated so that new records are appended after the last valid one. A log damaged
+// before its last record is left untouched and fails with ErrCorruptWAL.
+func openWAL(dir string, opts StorageOptions) (*wal, []common.Operation, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, fmt.Errorf("could not create wal directory: %w", err)
+	}
+
+	path := filepath.Join(dir, walFileName)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open wal file: %w", err)
+	}
+
+	ops, validSize, err := readWALRecords(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("could not truncate wal tail: %w", err)
+	}
+
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("could not seek wal: %w", err)
+	}
+
+	w := &wal{
+		path:   path,
+		file:   file,
+		policy: opts.SyncPolicy,
+	}
+
+	if w.policy == SyncInterval && opts.SyncInterval > 0 {
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+		go w.syncLoop(opts.SyncInterval)
+	}
+
+	return w, ops, nil
+}
+
+// readWALRecords decodes records from the start of file until the end, returning the
+// decoded operations and the size of the valid prefix. Only the last record may be invalid,
+// as left behind by a crash in the middle of a write, an invalid record followed by more
+// data fails with ErrCorruptWAL.
+func readWALRecords(file *os.File) ([]common.Operation, int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, fmt.Errorf("could not seek wal: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, walHeaderLength)
+
+	var ops []common.Operation
+	var offset int64
+
+	// invalid drops the record at offset when it is the last one and fails otherwise
+	invalid := func(reason string) ([]common.Operation, int64, error) {
+		if _, err := reader.Peek(1); err != nil {
+			slog.Warn("discarding invalid last wal record", "offset", offset, "reason", reason)
+			return ops, offset, nil
+		}
+
+		return nil, 0, fmt.Errorf("%w: %s at offset %d is followed by other records", ErrCorruptWAL, reason, offset)
+	}
+
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if !errors.Is(err, io.EOF) {
+				slog.Warn("discarding torn wal record header", "offset", offset)
+			}
+			return ops, offset, nil
+		}
+
+		length := binary.BigEndian.Uint32(header[:4])
+		checksum := binary.BigEndian.Uint32(header[4:])
+
+		if length > maxWALRecordLength {
+			return invalid(fmt.Sprintf("record length %d exceeds the limit", length))
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			slog.Warn("discarding torn wal record", "offset", offset)
+			return ops, offset, nil
+		}
+
+		if crc32.Checksum(payload, crcTable) != checksum {
+			return invalid("checksum mismatch")
+		}
+
+		var op common.Operation
+		if err := json.Unmarshal(payload, &op); err != nil {
+			return invalid(fmt.Sprintf("undecodable record (%v)", err))
+		}
+
+		ops = append(ops, op)
+		offset += int64(walHeaderLength) + int64(length)
+	}
+}
+
+// Append writes op to the end of the log, syncing it according to the sync policy
+func (w *wal) Append(op common.Operation) error {
+	record, err := encodeWALRecord(op)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.file.Write(record); err != nil {
+		return fmt.Errorf("could not write wal record: %w", err)
+	}
+
+	if w.policy == SyncAlways {
+		if err := w.file.Sync(); err != nil {
+			return fmt.Errorf("could not sync wal: %w", err)
+		}
+		return nil
+	}
+
+	w.dirty = true
+	return nil
+}
+
+// Rewrite atomically replaces the content of the log with ops. It is used to
+// drop the records already covered by a snapshot.
+func (w *wal) Rewrite(ops []common.Operation) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	tmpPath := w.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not create wal file: %w", err)
+	}
+
+	writer := bufio.NewWriter(tmp)
+	for _, op := range ops {
+		record, err := encodeWALRecord(op)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+
+		if _, err := writer.Write(record); err != nil {
+			tmp.Close()
+			return fmt.Errorf("could not write wal record: %w", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write wal records: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not sync wal: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not replace wal: %w", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		slog.Warn("could not close replaced wal file", "error", err)
+	}
+
+	w.file = tmp
+	w.dirty = false
+
+	return syncDir(filepath.Dir(w.path))
+}
+
+func encodeWALRecord(op common.Operation) ([]byte, error) {
+	payload, err := json.Marshal(op)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal operation: %w", err)
+	}
+
+	if len(payload) > maxWALRecordLength {
+		return nil, fmt.Errorf("operation of %d bytes exceeds the wal record limit of %d bytes",
+			len(payload), maxWALRecordLength)
+	}
+
+	record := make([]byte, walHeaderLength+len(payload))
+	binary.BigEndian.PutUint32(record[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:walHeaderLength], crc32.Checksum(payload, crcTable))
+	copy(record[walHeaderLength:], payload)
+
+	return record, nil
+}
+
+// syncDir fsyncs a directory so that renames inside it are durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("could not open directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("could not sync directory: %w", err)
+	}
+
+	return nil
+}
+
+// Sync flushes any unsynced records to stable storage
+func (w *wal) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.syncLocked()
+}
+
+func (w *wal) syncLocked() error {
+	if !w.dirty {
+		return nil
+	}
+
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("could not sync wal: %w", err)
+	}
+
+	w.dirty = false
+	return nil
+}
+
+func (w *wal) syncLoop(interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.Sync(); err != nil {
+				slog.Error("periodic wal sync failed", "error", err)
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Close syncs and closes the log
+func (w *wal) Close() error {
+	if w.stop != nil {
+		close(w.stop)
+		<-w.done
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.dirty = true
+	syncErr := w.syncLocked()
+	closeErr := w.file.Close()
+
+	return errors.Join(syncErr, closeErr)
+}
@@ -0,0 +1,115 @@
+package kvstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/oapi-codegen/nullable"
+)
+
+func walRecord(t *testing.T, id int64) []byte {
+	t.Helper()
+
+	record, err := encodeWALRecord(common.Operation{
+		ID:    id,
+		Key:   "key",
+		Type:  common.Set,
+		Value: nullable.NewNullableWithValue("value"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return record
+}
+
+func TestReadWALRecords(t *testing.T) {
+	first, second := walRecord(t, 0), walRecord(t, 1)
+
+	badChecksum := slices.Clone(second)
+	badChecksum[walHeaderLength]++
+
+	oversized := slices.Clone(second)
+	binary.BigEndian.PutUint32(oversized[:4], maxWALRecordLength+1)
+
+	tests := []struct {
+		name    string
+		content []byte
+		wantIDs []int64
+		wantErr error
+	}{
+		{name: "empty"},
+		{name: "intact", content: slices.Concat(first, second), wantIDs: []int64{0, 1}},
+		{name: "torn header", content: slices.Concat(first, second[:4]), wantIDs: []int64{0}},
+		{name: "torn payload", content: slices.Concat(first, second[:len(second)-1]), wantIDs: []int64{0}},
+		{name: "checksum mismatch at end", content: slices.Concat(first, badChecksum), wantIDs: []int64{0}},
+		{name: "oversized length at end", content: slices.Concat(first, oversized[:walHeaderLength]), wantIDs: []int64{0}},
+		{name: "checksum mismatch before others", content: slices.Concat(badChecksum, first), wantErr: ErrCorruptWAL},
+		{name: "oversized length before others", content: slices.Concat(oversized, first), wantErr: ErrCorruptWAL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), walFileName)
+			if err := os.WriteFile(path, tt.content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			ops, size, err := readWALRecords(file)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			ids := make([]int64, 0, len(ops))
+			for _, op := range ops {
+				ids = append(ids, op.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("got operations %v, want %v", ids, tt.wantIDs)
+			}
+
+			wantSize := int64(len(first) * len(tt.wantIDs))
+			if size != wantSize {
+				t.Errorf("got valid size %d, want %d", size, wantSize)
+			}
+		})
+	}
+}
+
+func TestOpenWALKeepsCorruptLog(t *testing.T) {
+	dir := t.TempDir()
+
+	badChecksum := walRecord(t, 0)
+	badChecksum[walHeaderLength]++
+	content := slices.Concat(badChecksum, walRecord(t, 1))
+
+	if err := os.WriteFile(filepath.Join(dir, walFileName), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := openWAL(dir, StorageOptions{}); !errors.Is(err, ErrCorruptWAL) {
+		t.Fatalf("got error %v, want %v", err, ErrCorruptWAL)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, walFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(data, content) {
+		t.Error("corrupt wal was modified")
+	}
+}
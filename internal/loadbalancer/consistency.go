@@ -0,0 +1,161 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// partitionStatuses is the latest reported status of every partition keyed by node ID and partition ID
+type partitionStatuses map[openapi_types.UUID]map[string]common.PartitionStatus
+
+// readNodes returns the healthy nodes of partition that may serve a read with the given consistency
+func (s *server) readNodes(state *common.State, partition *common.Partition,
+	consistency common.ReadConsistency) []common.Node {
+	nodes := lo.Filter(state.Nodes, func(node common.Node, _ int) bool {
+		role, exists := node.Partitions[partition.Id]
+		if !exists || node.Status != common.Healthy {
+			return false
+		}
+
+		// Replicas still syncing with the master may miss writes
+		return role.IsMaster || !role.IsSyncing
+	})
+
+	switch consistency {
+	case common.ReadConsistencyStrong:
+		return lo.Filter(nodes, func(node common.Node, _ int) bool {
+			return node.Id == partition.MasterNodeId
+		})
+	case common.ReadConsistencyBounded:
+		statuses := s.statuses.Load()
+		if statuses == nil {
+			return lo.Filter(nodes, func(node common.Node, _ int) bool {
+				return node.Id == partition.MasterNodeId
+			})
+		}
+
+		return lo.Filter(nodes, func(node common.Node, _ int) bool {
+			return node.Id == partition.MasterNodeId || s.withinStaleness(*statuses, partition, node.Id)
+		})
+	default:
+		return nodes
+	}
+}
+
+// withinStaleness reports whether the last reported status of the replica on nodeID is
+// close enough to the master's for bounded reads: at most maxStalenessOps operations or
+// maxStaleness behind it, either bound suffices
+func (s *server) withinStaleness(statuses partitionStatuses, partition *common.Partition,
+	nodeID openapi_types.UUID) bool {
+	master, found := statuses[partition.MasterNodeId][partition.Id]
+	if !found {
+		return false
+	}
+
+	replica, found := statuses[nodeID][partition.Id]
+	if !found || replica.IsSyncing {
+		return false
+	}
+
+	lagOps := master.LastAppliedOperationId - replica.LastAppliedOperationId
+	if lagOps <= 0 {
+		return true
+	}
+
+	if lagOps <= s.maxStalenessOps {
+		return true
+	}
+
+	// Without timestamps only the operation bound can be checked
+	if master.LastAppliedAt == nil || replica.LastAppliedAt == nil {
+		return false
+	}
+
+	return master.LastAppliedAt.Sub(*replica.LastAppliedAt) <= s.maxStaleness
+}
+
+// pollPartitionsStatus periodically fetches the status of the partitions of every
+// healthy node, it is used to decide which replicas may serve bounded reads
+func (s *server) pollPartitionsStatus(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshPartitionsStatus(ctx, interval)
+		}
+	}
+}
+
+func (s *server) refreshPartitionsStatus(ctx context.Context, timeout time.Duration) {
+	state := s.statePtr.Load()
+	if state == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	statuses := make(partitionStatuses)
+
+	for _, node := range state.Nodes {
+		if node.Status != common.Healthy {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			nodeStatuses, err := s.fetchPartitionsStatus(ctx, node)
+			if err != nil {
+				slog.DebugContext(ctx, "could not get partitions status", "node_id", node.Id,
+					"node_address", node.Address, "error", err)
+				return
+			}
+
+			mu.Lock()
+			statuses[node.Id] = nodeStatuses
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	s.statuses.Store(&statuses)
+}
+
+// fetchPartitionsStatus returns the status of every partition hosted by node keyed by partition ID
+func (s *server) fetchPartitionsStatus(ctx context.Context, node common.Node) (map[string]common.PartitionStatus, error) {
+	client, err := database.NewClientWithResponses("http://"+node.Address,
+		database.WithHTTPClient(s.httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("could not create database client: %w", err)
+	}
+
+	resp, err := client.GetPartitionsStatusWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get partitions status: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("partitions status returned status %d", resp.StatusCode())
+	}
+
+	return lo.SliceToMap(*resp.JSON200, func(status common.PartitionStatus) (string, common.PartitionStatus) {
+		return status.PartitionId, status
+	}), nil
+}
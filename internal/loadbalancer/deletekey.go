@@ -2,6 +2,7 @@ package loadbalancer
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -14,7 +15,9 @@ import (
 // DeleteKey implements LoadBalancer.
 func (s *server) DeleteKey(ctx context.Context,
 	request kvstoreAPI.DeleteKeyRequestObject) (kvstoreAPI.DeleteKeyResponseObject, error) {
-	partition, err := s.statePtr.Load().GetPartition(request.Key)
+	state := s.statePtr.Load()
+
+	partition, migrationTarget, err := writePartitions(state, request.Key)
 	if err != nil {
 		slog.ErrorContext(ctx, "could not get partition", "method", "delete", "error", err)
 		return kvstoreAPI.DeleteKeydefaultJSONResponse{
@@ -25,94 +28,100 @@ func (s *server) DeleteKey(ctx context.Context,
 		}, nil
 	}
 
-	masterNode, found := lo.Find(s.statePtr.Load().Nodes, func(node common.Node) bool {
-		return node.Id == partition.MasterNodeId
-	})
-
-	if !found {
-		slog.ErrorContext(ctx, "master node not found", "method", "delete",
-			"partition_id", partition.Id)
-		return kvstoreAPI.DeleteKeydefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "master node not found",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	params := &database.DeleteKeyFromPartitionParams{
+		W: lo.CoalesceOrEmpty(request.Params.W, &s.writeConcern),
 	}
 
-	// Check if the node has this partition and it's healthy
-	if masterNode.Partitions == nil {
-		slog.ErrorContext(ctx, "master node has no partitions", "method", "delete",
-			"partition_id", partition.Id, "node_id", masterNode.Id)
+	resp, err := s.deleteKeyFromPartition(ctx, state, partition, request.Key, params)
+	if err != nil {
 		return kvstoreAPI.DeleteKeydefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "master node has no partitions",
-			},
-			StatusCode: http.StatusInternalServerError,
+			Body:       errorResponse(err),
+			StatusCode: errorStatusCode(err),
 		}, nil
 	}
 
-	if masterNode.Status != common.Healthy {
-		slog.ErrorContext(ctx, "master partition not healthy", "method", "delete",
-			"partition_id", partition.Id, "node_id", masterNode.Id)
-		return kvstoreAPI.DeleteKeydefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "master partition not healthy",
-			},
-			StatusCode: http.StatusServiceUnavailable,
-		}, nil
+	// The range of the key is being migrated, the key may already have been
+	// copied to the target partition
+	if migrationTarget != nil {
+		if _, err := s.deleteKeyFromPartition(ctx, state, migrationTarget, request.Key, params); err != nil {
+			slog.ErrorContext(ctx, "could not delete key from migration target", "method", "delete",
+				"partition_id", migrationTarget.Id, "error", err)
+			return kvstoreAPI.DeleteKeydefaultJSONResponse{
+				Body: common.ErrorResponse{
+					Error: "could not delete key from migration target partition",
+				},
+				StatusCode: http.StatusServiceUnavailable,
+			}, nil
+		}
 	}
 
-	partitionRole, exists := masterNode.Partitions[partition.Id]
-	if !exists || !partitionRole.IsMaster {
-		slog.ErrorContext(ctx, "node is not master for this partition", "method", "delete",
-			"partition_id", partition.Id, "node_id", masterNode.Id)
-		return kvstoreAPI.DeleteKeydefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "node is not master for this partition",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	if resp.JSON200 != nil {
+		deleted := *resp.JSON200
+
+		// The operation ID is only handed out inside the opaque session token
+		if deleted.OperationId != nil {
+			deleted.SessionToken = lo.ToPtr(encodeSessionToken(partition, *deleted.OperationId))
+			deleted.OperationId = nil
+		}
+
+		return kvstoreAPI.DeleteKey200JSONResponse(deleted), nil
 	}
 
-	client, err := database.NewClientWithResponses("http://"+masterNode.Address,
-		database.WithHTTPClient(s.httpClient))
+	return kvstoreAPI.DeleteKey404JSONResponse(*resp.JSON404), nil
+}
+
+// deleteKeyFromPartition deletes key on the master of partition, the returned
+// response is either a successful deletion or a not found
+func (s *server) deleteKeyFromPartition(ctx context.Context, state *common.State, partition *common.Partition,
+	key string, params *database.DeleteKeyFromPartitionParams) (*database.DeleteKeyFromPartitionResponse, error) {
+	clients, err := s.writeClients(state, partition)
 	if err != nil {
-		slog.ErrorContext(ctx, "could not create client", "method", "delete", "error", err)
-		return kvstoreAPI.DeleteKeydefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "could not create client",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+		slog.ErrorContext(ctx, "could not reach master", "method", "delete",
+			"partition_id", partition.Id, "error", err)
+		return nil, err
 	}
 
-	// Call the database API to delete the key from the partition
-	resp, err := client.DeleteKeyFromPartitionWithResponse(ctx, partition.Id, request.Key)
-	if err != nil {
-		slog.ErrorContext(ctx, "error in delete key", "method", "delete", "error", err)
-		return kvstoreAPI.DeleteKeydefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "could not delete key",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	var resp *database.DeleteKeyFromPartitionResponse
+	var masterNode common.Node
+	for _, target := range clients {
+		masterNode = target.node
+
+		// Call the database API to delete the key from the partition, fenced by the
+		// epoch so that a demoted master rejects it
+		resp, err = target.client.DeleteKeyFromPartitionWithResponse(ctx, partition.Id, key,
+			&database.DeleteKeyFromPartitionParams{Epoch: &partition.Epoch, W: params.W})
+		if err != nil {
+			slog.ErrorContext(ctx, "error in delete key", "method", "delete", "error", err)
+			return nil, errors.New("could not delete key")
+		}
+
+		if !isNotLeader(resp.JSON409) {
+			break
+		}
 	}
 
-	if resp.JSON200 != nil {
-		return kvstoreAPI.DeleteKey200JSONResponse(*resp.JSON200), nil
-	} else if resp.JSON404 != nil {
-		return kvstoreAPI.DeleteKey404JSONResponse(*resp.JSON404), nil
-	} else if resp.JSON500 != nil {
-		slog.ErrorContext(ctx, "unexpected response from server", "method", "delete", "error", resp.JSON500.Error)
-	} else {
-		slog.ErrorContext(ctx, "unexpected response from server", "method", "delete")
+	switch {
+	case resp.JSON200 != nil, resp.JSON404 != nil:
+		return resp, nil
+	case resp.JSON409 != nil:
+		slog.WarnContext(ctx, "master rejected write", "method", "delete",
+			"partition_id", partition.Id, "node_id", masterNode.Id, "error", resp.JSON409.Message)
+		return nil, staleEpochError(resp.JSON409)
+	case resp.JSON504 != nil:
+		slog.WarnContext(ctx, "write concern not satisfied", "method", "delete",
+			"partition_id", partition.Id, "node_id", masterNode.Id, "error", resp.JSON504.Message)
+		return nil, &routingError{
+			err:        errors.New(resp.JSON504.Message),
+			statusCode: http.StatusGatewayTimeout,
+			code:       resp.JSON504.Error,
+		}
+	case resp.JSON500 != nil:
+		slog.ErrorContext(ctx, "unexpected response from server", "method", "delete",
+			"node_id", masterNode.Id, "error", resp.JSON500.Error)
+	default:
+		slog.ErrorContext(ctx, "unexpected response from server", "method", "delete",
+			"node_id", masterNode.Id)
 	}
 
-	return kvstoreAPI.DeleteKeydefaultJSONResponse{
-		Body: common.ErrorResponse{
-			Error: "unexpected response from server",
-		},
-		StatusCode: http.StatusInternalServerError,
-	}, nil
+	return nil, errors.New("unexpected response from server")
 }
@@ -2,6 +2,7 @@ package loadbalancer
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"math/rand/v2"
 	"net/http"
@@ -16,9 +17,12 @@ import (
 func (s *server) GetValue(ctx context.Context,
 	request kvstoreAPI.GetValueRequestObject) (kvstoreAPI.GetValueResponseObject, error) {
 
-	partition, err := s.statePtr.Load().GetPartition(request.Key)
+	state := s.statePtr.Load()
+
+	// Keys of a range that is still being migrated are read from the source partition
+	partition, err := readPartition(state, request.Key)
 	if err != nil {
-		slog.ErrorContext(ctx, "could not get partition", "method", "set", "error", err)
+		slog.ErrorContext(ctx, "could not get partition", "method", "get", "error", err)
 		return kvstoreAPI.GetValuedefaultJSONResponse{
 			Body: common.ErrorResponse{
 				Error: "could not get partition",
@@ -27,20 +31,35 @@ func (s *server) GetValue(ctx context.Context,
 		}, nil
 	}
 
-	// Find nodes that are replicas for this partition
-	replicaNodes := lo.Filter(s.statePtr.Load().Nodes, func(node common.Node, _ int) bool {
-		if node.Partitions == nil {
-			return false
+	consistency := lo.FromPtrOr(request.Params.Consistency, s.readConsistency)
+
+	// Find the nodes of this partition that may serve the read
+	healthyReplicas := s.readNodes(state, partition, consistency)
+
+	// A read of the partition of the session's last write must observe that write
+	params := &database.GetValueFromPartitionParams{}
+	if request.Params.Session != nil {
+		session, err := decodeSessionToken(*request.Params.Session)
+		if err != nil {
+			return kvstoreAPI.GetValuedefaultJSONResponse{
+				Body: common.ErrorResponse{
+					Error: err.Error(),
+				},
+				StatusCode: http.StatusBadRequest,
+			}, nil
 		}
-		_, hasPartition := node.Partitions[partition.Id]
-		return hasPartition
-	})
 
-	// Filter healthy replicas
-	healthyReplicas := lo.Filter(replicaNodes, func(node common.Node, _ int) bool {
-		_, exists := node.Partitions[partition.Id]
-		return exists && node.Status == common.Healthy
-	})
+		switch {
+		case session.PartitionID != partition.Id:
+		case session.Epoch != partition.Epoch:
+			// The write was made in another epoch, whose operation IDs the nodes do not
+			// share, the master holds every write that survived the change of epoch
+			healthyReplicas = masterNodes(healthyReplicas, partition)
+		default:
+			healthyReplicas = s.sessionNodes(healthyReplicas, partition, session)
+			params.MinOperationId = &session.OperationID
+		}
+	}
 
 	if len(healthyReplicas) == 0 {
 		return kvstoreAPI.GetValuedefaultJSONResponse{
@@ -54,19 +73,22 @@ func (s *server) GetValue(ctx context.Context,
 	selectedReplicaIdx := rand.IntN(len(healthyReplicas))
 	replica := healthyReplicas[selectedReplicaIdx]
 
-	client, err := database.NewClientWithResponses("http://"+replica.Address,
-		database.WithHTTPClient(s.httpClient))
-	if err != nil {
-		slog.ErrorContext(ctx, "could not create client", "method", "get", "error", err)
-		return kvstoreAPI.GetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "could not create client",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	resp, err := s.getValueFromNode(ctx, replica, partition.Id, request.Key, params)
+
+	// The replica did not catch up with the session in time, the master has every write
+	if err == nil && resp.JSON503 != nil && replica.Id != partition.MasterNodeId {
+		slog.WarnContext(ctx, "replica behind session, reading from master", "method", "get",
+			"partition_id", partition.Id, "replica_id", replica.Id)
+
+		master, found := lo.Find(healthyReplicas, func(node common.Node) bool {
+			return node.Id == partition.MasterNodeId
+		})
+		if found {
+			replica = master
+			resp, err = s.getValueFromNode(ctx, replica, partition.Id, request.Key, params)
+		}
 	}
 
-	resp, err := client.GetValueFromPartitionWithResponse(ctx, partition.Id, request.Key)
 	if err != nil {
 		slog.ErrorContext(ctx, "error getting value from replica",
 			"method", "get", "error", err)
@@ -83,6 +105,11 @@ func (s *server) GetValue(ctx context.Context,
 		return kvstoreAPI.GetValue200JSONResponse(*resp.JSON200), nil
 	case resp.JSON404 != nil:
 		return kvstoreAPI.GetValue404JSONResponse(*resp.JSON404), nil
+	case resp.JSON503 != nil:
+		return kvstoreAPI.GetValuedefaultJSONResponse{
+			Body:       *resp.JSON503,
+			StatusCode: http.StatusServiceUnavailable,
+		}, nil
 	case resp.JSON500 != nil:
 		slog.ErrorContext(ctx, "unexpected error in getting value from replica",
 			"method", "get", "error", resp.JSON500.Error, "replica_id", replica.Id)
@@ -98,3 +125,20 @@ func (s *server) GetValue(ctx context.Context,
 		StatusCode: http.StatusInternalServerError,
 	}, nil
 }
+
+// getValueFromNode reads key of a partition from node
+func (s *server) getValueFromNode(ctx context.Context, node common.Node, partitionID, key string,
+	params *database.GetValueFromPartitionParams) (*database.GetValueFromPartitionResponse, error) {
+	client, err := database.NewClientWithResponses("http://"+node.Address,
+		database.WithHTTPClient(s.httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("could not create client: %w", err)
+	}
+
+	resp, err := client.GetValueFromPartitionWithResponse(ctx, partitionID, key, params)
+	if err != nil {
+		return nil, fmt.Errorf("could not get value: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,175 @@
+package loadbalancer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/samber/lo"
+)
+
+// routingError is an error in routing a request together with the status code returned to the client
+type routingError struct {
+	err        error
+	statusCode int
+	// code is the error code returned to the client, the error message is returned when empty
+	code string
+}
+
+func (e *routingError) Error() string {
+	return e.err.Error()
+}
+
+func newRoutingError(message string, statusCode int) *routingError {
+	return &routingError{err: errors.New(message), statusCode: statusCode}
+}
+
+// readPartition returns the partition that holds the current value of key. While the
+// range of the key is being migrated its data is still in the source partition.
+func readPartition(state *common.State, key string) (*common.Partition, error) {
+	if migrationRange, found := state.GetMigrationRange(key); found && migrationRange.Status != common.Completed {
+		return state.GetPartitionByID(migrationRange.SourcePartitionId)
+	}
+
+	return state.GetPartition(key)
+}
+
+// writePartitions returns the partition whose response is returned for a write of key
+// and, while the range of the key is being migrated, the target partition that must
+// receive the write too so that it is not overwritten by an older migrated value
+func writePartitions(state *common.State, key string) (*common.Partition, *common.Partition, error) {
+	migrationRange, found := state.GetMigrationRange(key)
+	if !found || migrationRange.Status == common.Completed {
+		partition, err := state.GetPartition(key)
+		return partition, nil, err
+	}
+
+	source, err := state.GetPartitionByID(migrationRange.SourcePartitionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := state.GetPartitionByID(migrationRange.TargetPartitionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return source, target, nil
+}
+
+// masterClient returns a database client for the healthy master node of partition
+func (s *server) masterClient(state *common.State, partition *common.Partition) (database.ClientWithResponsesInterface, *common.Node, error) {
+	masterNode, found := lo.Find(state.Nodes, func(node common.Node) bool {
+		return node.Id == partition.MasterNodeId
+	})
+
+	if !found {
+		return nil, nil, newRoutingError("master node not found", http.StatusInternalServerError)
+	}
+
+	// Check if the node has this partition and it's healthy
+	if masterNode.Partitions == nil {
+		return nil, &masterNode, newRoutingError("master node has no partitions", http.StatusInternalServerError)
+	}
+
+	partitionRole, exists := masterNode.Partitions[partition.Id]
+	if !exists || !partitionRole.IsMaster {
+		return nil, &masterNode, newRoutingError("node is not master for this partition", http.StatusInternalServerError)
+	}
+
+	if masterNode.Status != common.Healthy {
+		return nil, &masterNode, newRoutingError("master partition not healthy", http.StatusServiceUnavailable)
+	}
+
+	client, err := database.NewClientWithResponses("http://"+masterNode.Address,
+		database.WithHTTPClient(s.httpClient))
+	if err != nil {
+		return nil, &masterNode, newRoutingError("could not create client", http.StatusInternalServerError)
+	}
+
+	return client, &masterNode, nil
+}
+
+// nodeClient is a database client for a node
+type nodeClient struct {
+	client database.ClientWithResponsesInterface
+	node   common.Node
+}
+
+// writeClients returns clients for the nodes to try a write of partition on, in order. Only the
+// master is tried unless the partition replicates through Raft, then its other healthy nodes
+// follow since one of them may have been elected leader after the last health check.
+func (s *server) writeClients(state *common.State, partition *common.Partition) ([]nodeClient, error) {
+	var clients []nodeClient
+
+	client, masterNode, err := s.masterClient(state, partition)
+	if err == nil {
+		clients = append(clients, nodeClient{client: client, node: *masterNode})
+	}
+
+	if !state.UsesRaft() {
+		return clients, err
+	}
+
+	for _, node := range state.Nodes {
+		if node.Id == partition.MasterNodeId || node.Status != common.Healthy ||
+			!lo.Contains(partition.NodeIds, node.Id) {
+			continue
+		}
+
+		client, clientErr := database.NewClientWithResponses("http://"+node.Address,
+			database.WithHTTPClient(s.httpClient))
+		if clientErr != nil {
+			continue
+		}
+
+		clients = append(clients, nodeClient{client: client, node: node})
+	}
+
+	if len(clients) == 0 {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// isNotLeader reports whether a node rejected a write because it does not lead the partition's Raft group
+func isNotLeader(resp *common.ErrorResponse) bool {
+	return resp != nil && resp.Error == common.NotLeaderErrorCode
+}
+
+// errorResponse returns the body to answer a failed request with
+func errorResponse(err error) common.ErrorResponse {
+	var routingErr *routingError
+	if errors.As(err, &routingErr) && routingErr.code != "" {
+		return common.ErrorResponse{
+			Error:   routingErr.code,
+			Message: routingErr.Error(),
+		}
+	}
+
+	return common.ErrorResponse{
+		Error: err.Error(),
+	}
+}
+
+// staleEpochError converts a STALE_EPOCH or NOT_LEADER rejection of a node, the state of
+// the load balancer is older or newer than the node's and the request may be retried
+func staleEpochError(resp *common.ErrorResponse) error {
+	return &routingError{
+		err:        errors.New(resp.Message),
+		statusCode: http.StatusConflict,
+		code:       resp.Error,
+	}
+}
+
+// errorStatusCode returns the status code to answer a failed request with
+func errorStatusCode(err error) int {
+	var routingErr *routingError
+	if errors.As(err, &routingErr) {
+		return routingErr.statusCode
+	}
+
+	return http.StatusInternalServerError
+}
@@ -2,14 +2,17 @@ package loadbalancer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync/atomic"
+	"time"
 
 	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/computer-technology-team/distributed-kvstore/api/controller"
 	kvstoreAPI "github.com/computer-technology-team/distributed-kvstore/api/kvstore"
 	"github.com/computer-technology-team/distributed-kvstore/api/loadbalancer"
+	"github.com/computer-technology-team/distributed-kvstore/internal/statewatch"
 )
 
 type LoadBalancer interface {
@@ -17,15 +20,103 @@ type LoadBalancer interface {
 	loadbalancer.StrictServerInterface
 }
 
+// Options configures the defaults of client requests
+type Options struct {
+	// WriteConcern is used for set and delete requests that do not specify one
+	WriteConcern common.WriteConcern
+	// ReadConsistency is used for get requests that do not specify one
+	ReadConsistency common.ReadConsistency
+	// MaxStalenessOps and MaxStaleness bound how far behind the master a replica
+	// serving bounded reads may be, in operations and in time
+	MaxStalenessOps int64
+	MaxStaleness    time.Duration
+	// StatusPollInterval is how often the replication status of the nodes is refreshed
+	StatusPollInterval time.Duration
+	// StateWatchTimeout is how long a watch of the controller's state waits for a change,
+	// 0 only relies on the controller pushing the state
+	StateWatchTimeout time.Duration
+}
+
 type server struct {
 	statePtr   atomic.Pointer[common.State]
 	httpClient *http.Client
+	// statuses is the latest replication status of the nodes, nil until first fetched
+	statuses atomic.Pointer[partitionStatuses]
+
+	writeConcern    common.WriteConcern
+	readConsistency common.ReadConsistency
+	maxStalenessOps int64
+	maxStaleness    time.Duration
 }
 
-// SetState implements LoadBalancer.
+// SetState implements LoadBalancer. The acknowledgement carries the version held.
 func (s *server) SetState(ctx context.Context, request loadbalancer.SetStateRequestObject) (loadbalancer.SetStateResponseObject, error) {
-	s.statePtr.Store(request.Body)
-	return loadbalancer.SetState200JSONResponse{}, nil
+	if request.Body == nil {
+		return nil, errors.New("missing state in request body")
+	}
+
+	return loadbalancer.SetState200JSONResponse{Version: s.storeState(request.Body)}, nil
+}
+
+// storeState replaces the state unless it is older than the one held, which was overtaken by
+// a newer one, and returns the version held
+func (s *server) storeState(state *common.State) int64 {
+	for {
+		current := s.statePtr.Load()
+		if current != nil && current.Version > state.Version {
+			return current.Version
+		}
+
+		if s.statePtr.CompareAndSwap(current, state) {
+			return state.Version
+		}
+	}
+}
+
+// StateVersion implements statewatch.Receiver.
+func (s *server) StateVersion() int64 {
+	if state := s.statePtr.Load(); state != nil {
+		return state.Version
+	}
+
+	return 0
+}
+
+// ApplyState implements statewatch.Receiver.
+func (s *server) ApplyState(state common.State) error {
+	s.storeState(&state)
+	return nil
+}
+
+// PatchState implements LoadBalancer.
+func (s *server) PatchState(ctx context.Context, request loadbalancer.PatchStateRequestObject) (loadbalancer.PatchStateResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing state patch in request body")
+	}
+
+	current := s.statePtr.Load()
+	if current == nil {
+		return loadbalancer.PatchState409JSONResponse{
+			Error:   common.StateVersionMismatchErrorCode,
+			Message: "no state to patch",
+		}, nil
+	}
+
+	state, err := current.ApplyStatePatch(*request.Body)
+	if err == nil && !s.statePtr.CompareAndSwap(current, &state) {
+		err = fmt.Errorf("%w: state changed while patching", common.ErrStateVersionMismatch)
+	}
+	if err != nil {
+		if errors.Is(err, common.ErrStateVersionMismatch) {
+			return loadbalancer.PatchState409JSONResponse{
+				Error:   common.StateVersionMismatchErrorCode,
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to patch state: %w", err)
+	}
+
+	return loadbalancer.PatchState200JSONResponse{Version: state.Version}, nil
 }
 
 // PingServer implements LoadBalancer.
@@ -34,15 +125,33 @@ func (s *server) PingServer(ctx context.Context,
 	return kvstoreAPI.PingServer200JSONResponse{Ping: "Pong"}, nil
 }
 
-func NewServer(ctx context.Context, controllerClient controller.ClientWithResponsesInterface) (LoadBalancer, error) {
-	resp, err := controllerClient.GetStateWithResponse(ctx)
+func NewServer(ctx context.Context, controllerClient controller.ClientWithResponsesInterface,
+	opts Options) (LoadBalancer, error) {
+	resp, err := controllerClient.GetStateWithResponse(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get state from controller: %w", err)
 	}
 
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("failed to get state from controller: unexpected status code %d", resp.StatusCode())
+	}
+
 	srv := &server{
-		httpClient: http.DefaultClient,
+		httpClient:      http.DefaultClient,
+		writeConcern:    opts.WriteConcern,
+		readConsistency: opts.ReadConsistency,
+		maxStalenessOps: opts.MaxStalenessOps,
+		maxStaleness:    opts.MaxStaleness,
 	}
 	srv.statePtr.Store(resp.JSON200)
+
+	if opts.StatusPollInterval > 0 {
+		go srv.pollPartitionsStatus(ctx, opts.StatusPollInterval)
+	}
+
+	if opts.StateWatchTimeout > 0 {
+		go statewatch.Watch(ctx, controllerClient, srv, opts.StateWatchTimeout)
+	}
+
 	return srv, nil
 }
@@ -0,0 +1,67 @@
+package loadbalancer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// sessionToken identifies the last write of a client session, reads of the same
+// partition carrying it are only served by nodes that applied the write. Operation IDs
+// are only comparable within the epoch of the partition the write was made in.
+type sessionToken struct {
+	PartitionID string `json:"p"`
+	Epoch       int64  `json:"e"`
+	OperationID int64  `json:"o"`
+}
+
+// encodeSessionToken returns the opaque form of the token handed to clients
+func encodeSessionToken(partition *common.Partition, operationID int64) string {
+	data, _ := json.Marshal(sessionToken{PartitionID: partition.Id, Epoch: partition.Epoch, OperationID: operationID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSessionToken(token string) (sessionToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return sessionToken{}, fmt.Errorf("invalid session token: %w", err)
+	}
+
+	var session sessionToken
+	if err := json.Unmarshal(data, &session); err != nil {
+		return sessionToken{}, fmt.Errorf("invalid session token: %w", err)
+	}
+
+	return session, nil
+}
+
+// sessionNodes narrows nodes to the master and the replicas last reported to have applied
+// the session's write. Without reported statuses every node is kept, the node itself waits
+// for the write before answering.
+func (s *server) sessionNodes(nodes []common.Node, partition *common.Partition, session sessionToken) []common.Node {
+	statuses := s.statuses.Load()
+	if statuses == nil {
+		return nodes
+	}
+
+	return lo.Filter(nodes, func(node common.Node, _ int) bool {
+		return node.Id == partition.MasterNodeId || appliedOperation(*statuses, node.Id, partition.Id, session.OperationID)
+	})
+}
+
+// masterNodes narrows nodes to the master of partition
+func masterNodes(nodes []common.Node, partition *common.Partition) []common.Node {
+	return lo.Filter(nodes, func(node common.Node, _ int) bool {
+		return node.Id == partition.MasterNodeId
+	})
+}
+
+// appliedOperation reports whether the partition on nodeID was last reported to have applied operationID
+func appliedOperation(statuses partitionStatuses, nodeID openapi_types.UUID, partitionID string, operationID int64) bool {
+	status, found := statuses[nodeID][partitionID]
+	return found && !status.IsSyncing && status.LastAppliedOperationId >= operationID
+}
@@ -2,6 +2,7 @@ package loadbalancer
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -14,7 +15,9 @@ import (
 // SetValue implements LoadBalancer.
 func (s *server) SetValue(ctx context.Context,
 	request kvstoreAPI.SetValueRequestObject) (kvstoreAPI.SetValueResponseObject, error) {
-	partition, err := s.statePtr.Load().GetPartition(request.Key)
+	state := s.statePtr.Load()
+
+	partition, migrationTarget, err := writePartitions(state, request.Key)
 	if err != nil {
 		slog.ErrorContext(ctx, "could not get partition", "method", "set", "error", err)
 		return kvstoreAPI.SetValuedefaultJSONResponse{
@@ -25,94 +28,98 @@ func (s *server) SetValue(ctx context.Context,
 		}, nil
 	}
 
-	masterReplica, found := lo.Find(s.statePtr.Load().Nodes, func(node common.Node) bool {
-		return node.Id == partition.MasterNodeId
-	})
+	// Create the database request body
+	dbRequestBody := database.SetValueInPartitionJSONRequestBody{
+		Value: request.Body.Value,
+	}
 
-	if !found {
-		slog.ErrorContext(ctx, "master node not found", "method", "set",
-			"partition_id", partition.Id)
-		return kvstoreAPI.SetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "master node not found",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	params := &database.SetValueInPartitionParams{
+		W: lo.CoalesceOrEmpty(request.Params.W, &s.writeConcern),
 	}
 
-	// Check if the node has this partition and it's healthy
-	if masterReplica.Partitions == nil {
-		slog.ErrorContext(ctx, "master node has no partitions", "method", "set",
-			"partition_id", partition.Id, "node_id", masterReplica.Id)
+	resp, err := s.setValueInPartition(ctx, state, partition, request.Key, params, dbRequestBody)
+	if err != nil {
 		return kvstoreAPI.SetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "master node has no partitions",
-			},
-			StatusCode: http.StatusInternalServerError,
+			Body:       errorResponse(err),
+			StatusCode: errorStatusCode(err),
 		}, nil
 	}
 
-	partitionRole, exists := masterReplica.Partitions[partition.Id]
-	if !exists || !partitionRole.IsMaster {
-		slog.ErrorContext(ctx, "node is not master for this partition", "method", "set",
-			"partition_id", partition.Id, "node_id", masterReplica.Id)
-		return kvstoreAPI.SetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "node is not master for this partition",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	// The range of the key is being migrated, the target partition must not
+	// keep an older copy of the value
+	if migrationTarget != nil {
+		if _, err := s.setValueInPartition(ctx, state, migrationTarget, request.Key, params, dbRequestBody); err != nil {
+			slog.ErrorContext(ctx, "could not set value in migration target", "method", "set",
+				"partition_id", migrationTarget.Id, "error", err)
+			return kvstoreAPI.SetValuedefaultJSONResponse{
+				Body: common.ErrorResponse{
+					Error: "could not set value in migration target partition",
+				},
+				StatusCode: http.StatusServiceUnavailable,
+			}, nil
+		}
 	}
 
-	if masterReplica.Status != common.Healthy {
-		slog.ErrorContext(ctx, "master partition not healthy", "method", "set",
-			"partition_id", partition.Id, "node_id", masterReplica.Id)
-		return kvstoreAPI.SetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "master partition not healthy",
-			},
-			StatusCode: http.StatusServiceUnavailable,
-		}, nil
+	// The operation ID is only handed out inside the opaque session token
+	if resp.OperationId != nil {
+		resp.SessionToken = lo.ToPtr(encodeSessionToken(partition, *resp.OperationId))
+		resp.OperationId = nil
 	}
 
-	client, err := database.NewClientWithResponses("http://"+masterReplica.Address,
-		database.WithHTTPClient(s.httpClient))
+	return kvstoreAPI.SetValue200JSONResponse(*resp), nil
+}
+
+// setValueInPartition sets the value of key on the master of partition
+func (s *server) setValueInPartition(ctx context.Context, state *common.State, partition *common.Partition,
+	key string, params *database.SetValueInPartitionParams,
+	body database.SetValueInPartitionJSONRequestBody) (*common.KeyValuePair, error) {
+	clients, err := s.writeClients(state, partition)
 	if err != nil {
-		slog.ErrorContext(ctx, "could not create client", "method", "set", "error", err)
-		return kvstoreAPI.SetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "could not create client",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+		slog.ErrorContext(ctx, "could not reach master", "method", "set",
+			"partition_id", partition.Id, "error", err)
+		return nil, err
 	}
 
-	// Create the database request body
-	dbRequestBody := database.SetValueInPartitionJSONRequestBody{
-		Value: request.Body.Value,
+	var resp *database.SetValueInPartitionResponse
+	var masterNode common.Node
+	for _, target := range clients {
+		masterNode = target.node
+
+		// Call the database API to set the value in the partition, fenced by the
+		// epoch so that a demoted master rejects it
+		resp, err = target.client.SetValueInPartitionWithResponse(ctx, partition.Id, key,
+			&database.SetValueInPartitionParams{Epoch: &partition.Epoch, W: params.W}, body)
+		if err != nil {
+			slog.ErrorContext(ctx, "error in set value", "method", "set", "error", err)
+			return nil, errors.New("could not set value")
+		}
+
+		if !isNotLeader(resp.JSON409) {
+			break
+		}
 	}
 
-	// Call the database API to set the value in the partition
-	resp, err := client.SetValueInPartitionWithResponse(ctx, partition.Id, request.Key, dbRequestBody)
-	if err != nil {
-		slog.ErrorContext(ctx, "error in set value", "method", "set", "error", err)
-		return kvstoreAPI.SetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "could not set value",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	if resp.JSON409 != nil {
+		slog.WarnContext(ctx, "master rejected write", "method", "set",
+			"partition_id", partition.Id, "node_id", masterNode.Id, "error", resp.JSON409.Message)
+		return nil, staleEpochError(resp.JSON409)
 	}
 
-	if resp.JSON200 != nil {
-		return kvstoreAPI.SetValue200JSONResponse(*resp.JSON200), nil
-	} else {
-		slog.ErrorContext(ctx, "unexpected response from server", "method", "set", "error", resp.JSON500.Error)
-		return kvstoreAPI.SetValuedefaultJSONResponse{
-			Body: common.ErrorResponse{
-				Error: "unexpected response from server",
-			},
-			StatusCode: http.StatusInternalServerError,
-		}, nil
+	if resp.JSON504 != nil {
+		slog.WarnContext(ctx, "write concern not satisfied", "method", "set",
+			"partition_id", partition.Id, "node_id", masterNode.Id, "error", resp.JSON504.Message)
+		return nil, &routingError{
+			err:        errors.New(resp.JSON504.Message),
+			statusCode: http.StatusGatewayTimeout,
+			code:       resp.JSON504.Error,
+		}
 	}
+
+	if resp.JSON200 == nil {
+		slog.ErrorContext(ctx, "unexpected response from server", "method", "set",
+			"node_id", masterNode.Id, "status_code", resp.StatusCode())
+		return nil, errors.New("unexpected response from server")
+	}
+
+	return resp.JSON200, nil
 }
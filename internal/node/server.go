@@ -2,27 +2,72 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/computer-technology-team/distributed-kvstore/api/common"
 	"github.com/computer-technology-team/distributed-kvstore/api/database"
+	"github.com/computer-technology-team/distributed-kvstore/internal/heartbeat"
 	internalKVStore "github.com/computer-technology-team/distributed-kvstore/internal/kvstore"
+	"github.com/computer-technology-team/distributed-kvstore/internal/statewatch"
 	"github.com/google/uuid"
 	"github.com/oapi-codegen/nullable"
 	"github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
 )
 
+// Server serves the database API of a node
+type Server interface {
+	database.StrictServerInterface
+	statewatch.Receiver
+	heartbeat.Source
+
+	// Close flushes and releases the node's persisted partitions
+	Close() error
+}
+
 type server struct {
 	nodeStore *internalKVStore.NodeStore
 	id        uuid.UUID
 }
 
-func NewServer(id types.UUID) database.StrictServerInterface {
+func NewServer(id types.UUID, storage internalKVStore.StorageOptions,
+	replication internalKVStore.ReplicationOptions) (Server, error) {
+	nodeStore, err := internalKVStore.NewNodeStore(id, storage, replication)
+	if err != nil {
+		return nil, fmt.Errorf("could not create node store: %w", err)
+	}
+
 	return &server{
-		nodeStore: internalKVStore.NewNodeStore(id),
+		nodeStore: nodeStore,
 		id:        id,
+	}, nil
+}
+
+// Close implements Server.
+func (s *server) Close() error {
+	return s.nodeStore.Close()
+}
+
+// StateVersion implements statewatch.Receiver.
+func (s *server) StateVersion() int64 {
+	return s.nodeStore.GetState().Version
+}
+
+// ApplyState implements statewatch.Receiver.
+func (s *server) ApplyState(state common.State) error {
+	// The controller may have pushed the state meanwhile
+	if state.Version <= s.StateVersion() {
+		return nil
 	}
+
+	return s.nodeStore.SetState(state)
+}
+
+// PartitionsStatus implements heartbeat.Source.
+func (s *server) PartitionsStatus() []common.PartitionStatus {
+	return s.nodeStore.PartitionsStatus()
 }
 
 // Database API implementation
@@ -49,7 +94,28 @@ func (s *server) UpdateNodeState(ctx context.Context, request database.UpdateNod
 		return database.UpdateNodeState500JSONResponse{Error: fmt.Sprintf("failed to set state: %v", err)}, nil
 	}
 
-	return database.UpdateNodeState200Response{}, nil
+	return database.UpdateNodeState200JSONResponse{Version: s.nodeStore.GetState().Version}, nil
+}
+
+// PatchNodeState implements database.StrictServerInterface.
+func (s *server) PatchNodeState(ctx context.Context, request database.PatchNodeStateRequestObject) (database.PatchNodeStateResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing state patch in request body")
+	}
+
+	if err := s.nodeStore.PatchState(*request.Body); err != nil {
+		if errors.Is(err, common.ErrStateVersionMismatch) {
+			return database.PatchNodeState409JSONResponse{
+				Error:   common.StateVersionMismatchErrorCode,
+				Message: err.Error(),
+			}, nil
+		}
+
+		slog.Error("failed to patch state", "error", err)
+		return database.PatchNodeState500JSONResponse{Error: fmt.Sprintf("failed to patch state: %v", err)}, nil
+	}
+
+	return database.PatchNodeState200JSONResponse{Version: s.nodeStore.GetState().Version}, nil
 }
 
 // KVStore API with partition ID implementation
@@ -59,6 +125,21 @@ func (s *server) GetValueFromPartition(ctx context.Context, request database.Get
 
 	slog.Info("GetValueFromPartition called", "partitionID", partitionID, "key", key)
 
+	// The read belongs to a session and must observe the session's last write
+	if request.Params.MinOperationId != nil {
+		if err := s.nodeStore.WaitForOperation(ctx, partitionID, *request.Params.MinOperationId); err != nil {
+			if errors.Is(err, internalKVStore.ErrReplicaBehind) {
+				return database.GetValueFromPartition503JSONResponse{
+					Error:   common.ReplicaBehindErrorCode,
+					Message: err.Error(),
+				}, nil
+			}
+			return database.GetValueFromPartition404JSONResponse{
+				Error: err.Error(),
+			}, nil
+		}
+	}
+
 	// Get the value directly from the specified partition
 	if value, exists, err := s.nodeStore.Get(partitionID, key); err == nil && exists {
 		return database.GetValueFromPartition200JSONResponse{
@@ -89,16 +170,35 @@ func (s *server) SetValueInPartition(ctx context.Context, request database.SetVa
 	slog.Info("SetValueInPartition details", "partitionID", partitionID, "key", key, "value", value)
 
 	// Set the value directly in the specified partition
-	if err := s.nodeStore.Set(partitionID, key, value); err != nil {
+	writeConcern := lo.FromPtrOr(request.Params.W, common.WriteConcernOne)
+	operationID, err := s.nodeStore.Set(partitionID, key, value, request.Params.Epoch, writeConcern)
+	if err != nil {
 		slog.Error("Failed to set value", "partitionID", partitionID, "key", key, "error", err)
+		switch {
+		case errors.Is(err, internalKVStore.ErrStaleEpoch):
+			return database.SetValueInPartition409JSONResponse(staleEpochResponse(err)), nil
+		case errors.Is(err, internalKVStore.ErrNotLeader):
+			return database.SetValueInPartition409JSONResponse(notLeaderResponse(err)), nil
+		case errors.Is(err, internalKVStore.ErrWriteConcernTimeout):
+			return database.SetValueInPartition504JSONResponse{
+				Error:   common.WriteConcernTimeoutErrorCode,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, internalKVStore.ErrWriteConcernFailed):
+			return database.SetValueInPartition504JSONResponse{
+				Error:   common.WriteConcernFailedErrorCode,
+				Message: err.Error(),
+			}, nil
+		}
 		return database.SetValueInPartition400JSONResponse{
 			Error: err.Error(),
 		}, nil
 	}
 
 	return database.SetValueInPartition200JSONResponse{
-		Key:   key,
-		Value: value,
+		Key:         key,
+		Value:       value,
+		OperationId: &operationID,
 	}, nil
 }
 
@@ -108,9 +208,26 @@ func (s *server) DeleteKeyFromPartition(ctx context.Context, request database.De
 
 	slog.Info("DeleteKeyFromPartition called", "partitionID", partitionID, "key", key)
 
-	deleted, err := s.nodeStore.Delete(partitionID, key)
+	writeConcern := lo.FromPtrOr(request.Params.W, common.WriteConcernOne)
+	operationID, deleted, err := s.nodeStore.Delete(partitionID, key, request.Params.Epoch, writeConcern)
 	if err != nil {
 		slog.Error("Failed to delete key", "partitionID", partitionID, "key", key, "error", err)
+		switch {
+		case errors.Is(err, internalKVStore.ErrStaleEpoch):
+			return database.DeleteKeyFromPartition409JSONResponse(staleEpochResponse(err)), nil
+		case errors.Is(err, internalKVStore.ErrNotLeader):
+			return database.DeleteKeyFromPartition409JSONResponse(notLeaderResponse(err)), nil
+		case errors.Is(err, internalKVStore.ErrWriteConcernTimeout):
+			return database.DeleteKeyFromPartition504JSONResponse{
+				Error:   common.WriteConcernTimeoutErrorCode,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, internalKVStore.ErrWriteConcernFailed):
+			return database.DeleteKeyFromPartition504JSONResponse{
+				Error:   common.WriteConcernFailedErrorCode,
+				Message: err.Error(),
+			}, nil
+		}
 		return database.DeleteKeyFromPartition500JSONResponse{
 			Error: err.Error(),
 		}, nil
@@ -124,10 +241,28 @@ func (s *server) DeleteKeyFromPartition(ctx context.Context, request database.De
 	}
 
 	return database.DeleteKeyFromPartition200JSONResponse{
-		Key: key,
+		Key:         key,
+		Deleted:     true,
+		OperationId: &operationID,
 	}, nil
 }
 
+// staleEpochResponse builds the error returned for requests from another epoch of a partition
+func staleEpochResponse(err error) common.ErrorResponse {
+	return common.ErrorResponse{
+		Error:   common.StaleEpochErrorCode,
+		Message: err.Error(),
+	}
+}
+
+// notLeaderResponse builds the error returned for writes to a node that does not lead the partition's Raft group
+func notLeaderResponse(err error) common.ErrorResponse {
+	return common.ErrorResponse{
+		Error:   common.NotLeaderErrorCode,
+		Message: err.Error(),
+	}
+}
+
 // GetOperation implements the replication endpoint to get a specific operation by ID
 func (s *server) GetOperation(ctx context.Context, request database.GetOperationRequestObject) (database.GetOperationResponseObject, error) {
 	partitionId := request.PartitionID
@@ -182,6 +317,12 @@ func (s *server) GetOperationsAfter(ctx context.Context, request database.GetOpe
 			"partition_id", partitionId,
 			"last_operation_id", lastOperationId)
 
+		if errors.Is(err, internalKVStore.ErrOperationCompacted) {
+			return database.GetOperationsAfter410JSONResponse{
+				Error: "Operations were compacted, install the snapshot instead",
+			}, nil
+		}
+
 		if err.Error() == "partition not found" {
 			return database.GetOperationsAfter200JSONResponse([]common.Operation{}), nil
 		}
@@ -209,6 +350,26 @@ func (s *server) GetOperationsAfter(ctx context.Context, request database.GetOpe
 	return database.GetOperationsAfter200JSONResponse(operations), nil
 }
 
+// GetSnapshot implements the replication endpoint to get a point-in-time snapshot of a partition
+func (s *server) GetSnapshot(ctx context.Context, request database.GetSnapshotRequestObject) (database.GetSnapshotResponseObject, error) {
+	partitionId := request.PartitionID
+
+	slog.Info("GetSnapshot called", "partitionID", partitionId)
+
+	snapshot, err := s.nodeStore.GetSnapshot(partitionId)
+	if err != nil {
+		slog.Error("failed to get snapshot", "error", err, "partition_id", partitionId)
+		return database.GetSnapshot404JSONResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	slog.Info("returning snapshot", "partition_id", partitionId,
+		"last_operation_id", snapshot.LastOperationId, "keys", len(snapshot.Data))
+
+	return database.GetSnapshot200JSONResponse(*snapshot), nil
+}
+
 // ApplyOperation implements the endpoint for applying operations to a replica
 func (s *server) ApplyOperation(ctx context.Context, request database.ApplyOperationRequestObject) (database.ApplyOperationResponseObject, error) {
 	if request.Body == nil {
@@ -222,6 +383,9 @@ func (s *server) ApplyOperation(ctx context.Context, request database.ApplyOpera
 
 	err := s.nodeStore.ApplyOperation(partitionID, operation)
 	if err != nil {
+		if errors.Is(err, internalKVStore.ErrStaleEpoch) {
+			return database.ApplyOperation409JSONResponse(staleEpochResponse(err)), nil
+		}
 		return database.ApplyOperation400JSONResponse{
 			Error: err.Error(),
 		}, nil
@@ -229,3 +393,169 @@ func (s *server) ApplyOperation(ctx context.Context, request database.ApplyOpera
 
 	return database.ApplyOperation200Response{}, nil
 }
+
+// ApplyOperations implements the endpoint for applying a batch of operations to a replica
+func (s *server) ApplyOperations(ctx context.Context, request database.ApplyOperationsRequestObject) (database.ApplyOperationsResponseObject, error) {
+	if request.Body == nil {
+		return database.ApplyOperations400JSONResponse{
+			Error: "Missing operations in request body",
+		}, nil
+	}
+
+	lastApplied, isSyncing, err := s.nodeStore.ApplyOperations(request.PartitionID, *request.Body)
+	if err != nil {
+		if errors.Is(err, internalKVStore.ErrStaleEpoch) {
+			return database.ApplyOperations409JSONResponse(staleEpochResponse(err)), nil
+		}
+		return database.ApplyOperations400JSONResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return database.ApplyOperations200JSONResponse{
+		LastAppliedOperationId: lastApplied,
+		IsSyncing:              isSyncing,
+	}, nil
+}
+
+// StartMigration implements the endpoint starting the copy of a hash range to another partition
+func (s *server) StartMigration(ctx context.Context, request database.StartMigrationRequestObject) (database.StartMigrationResponseObject, error) {
+	if request.Body == nil {
+		return database.StartMigration409JSONResponse{
+			Error: "Missing migration task in request body",
+		}, nil
+	}
+
+	slog.Info("StartMigration called", "partitionID", request.PartitionID, "migrationID", request.Body.Id)
+
+	status, err := s.nodeStore.StartMigration(request.PartitionID, *request.Body)
+	if err != nil {
+		slog.Error("failed to start migration", "error", err, "partition_id", request.PartitionID)
+		return database.StartMigration409JSONResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return database.StartMigration202JSONResponse(status), nil
+}
+
+// GetMigrationStatus implements the endpoint reporting the progress of a migration
+func (s *server) GetMigrationStatus(ctx context.Context, request database.GetMigrationStatusRequestObject) (database.GetMigrationStatusResponseObject, error) {
+	status, err := s.nodeStore.GetMigrationStatus(request.PartitionID, request.MigrationID)
+	if err != nil {
+		return database.GetMigrationStatus404JSONResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return database.GetMigrationStatus200JSONResponse(status), nil
+}
+
+// CommitMigration implements the endpoint deleting the keys moved by a completed migration
+func (s *server) CommitMigration(ctx context.Context, request database.CommitMigrationRequestObject) (database.CommitMigrationResponseObject, error) {
+	if request.Body == nil {
+		return database.CommitMigration409JSONResponse{
+			Error: "Missing migration task in request body",
+		}, nil
+	}
+
+	slog.Info("CommitMigration called", "partitionID", request.PartitionID, "migrationID", request.MigrationID)
+
+	task := *request.Body
+	task.Id = request.MigrationID
+
+	status, err := s.nodeStore.CommitMigration(request.PartitionID, task)
+	if err != nil {
+		slog.Error("failed to commit migration", "error", err, "partition_id", request.PartitionID)
+		return database.CommitMigration409JSONResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return database.CommitMigration200JSONResponse(status), nil
+}
+
+// ImportKeys implements the endpoint receiving migrated key-value pairs
+func (s *server) ImportKeys(ctx context.Context, request database.ImportKeysRequestObject) (database.ImportKeysResponseObject, error) {
+	if request.Body == nil {
+		return database.ImportKeys409JSONResponse{
+			Error: "Missing pairs in request body",
+		}, nil
+	}
+
+	imported, err := s.nodeStore.ImportKeys(request.PartitionID, request.Body.Pairs)
+	if err != nil {
+		slog.Error("failed to import keys", "error", err, "partition_id", request.PartitionID)
+		return database.ImportKeys409JSONResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return database.ImportKeys200JSONResponse{Imported: imported}, nil
+}
+
+// GetPartitionsStatus implements the endpoint reporting the replication status of the node's partitions
+func (s *server) GetPartitionsStatus(ctx context.Context, request database.GetPartitionsStatusRequestObject) (database.GetPartitionsStatusResponseObject, error) {
+	return database.GetPartitionsStatus200JSONResponse(s.nodeStore.PartitionsStatus()), nil
+}
+
+// RequestRaftVote implements the endpoint through which Raft candidates request votes
+func (s *server) RequestRaftVote(ctx context.Context, request database.RequestRaftVoteRequestObject) (database.RequestRaftVoteResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing vote request in request body")
+	}
+
+	resp, err := s.nodeStore.HandleRaftVote(request.PartitionID, *request.Body)
+	if errors.Is(err, internalKVStore.ErrRaftGroupNotFound) {
+		return database.RequestRaftVote404JSONResponse{
+			Error:   "Raft group not found",
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return database.RequestRaftVote200JSONResponse(resp), nil
+}
+
+// AppendRaftEntries implements the endpoint through which Raft leaders replicate their log
+func (s *server) AppendRaftEntries(ctx context.Context, request database.AppendRaftEntriesRequestObject) (database.AppendRaftEntriesResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing append request in request body")
+	}
+
+	resp, err := s.nodeStore.HandleRaftAppend(request.PartitionID, *request.Body)
+	if errors.Is(err, internalKVStore.ErrRaftGroupNotFound) {
+		return database.AppendRaftEntries404JSONResponse{
+			Error:   "Raft group not found",
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return database.AppendRaftEntries200JSONResponse(resp), nil
+}
+
+// InstallRaftSnapshot implements the endpoint through which Raft leaders send the snapshot
+// of a compacted log
+func (s *server) InstallRaftSnapshot(ctx context.Context, request database.InstallRaftSnapshotRequestObject) (database.InstallRaftSnapshotResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("missing snapshot request in request body")
+	}
+
+	resp, err := s.nodeStore.HandleRaftSnapshot(request.PartitionID, *request.Body)
+	if errors.Is(err, internalKVStore.ErrRaftGroupNotFound) {
+		return database.InstallRaftSnapshot404JSONResponse{
+			Error:   "Raft group not found",
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return database.InstallRaftSnapshot200JSONResponse(resp), nil
+}
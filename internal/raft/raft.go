@@ -0,0 +1,1005 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxAppendEntries is the number of entries sent to a follower per request
+	maxAppendEntries = 256
+	// snapshotTimeout bounds the transfer of a snapshot, which carries the whole state machine
+	snapshotTimeout = time.Minute
+)
+
+var (
+	// ErrNotLeader is returned when proposing to a node that is not the leader
+	ErrNotLeader = errors.New("not the raft leader")
+	// ErrLeadershipLost is returned when an entry was overwritten by another leader before it committed
+	ErrLeadershipLost = errors.New("raft leadership lost before the entry committed")
+	// ErrStopped is returned when the node stopped while waiting for an entry
+	ErrStopped = errors.New("raft node stopped")
+)
+
+// Peer is another member of the group
+type Peer struct {
+	ID      uuid.UUID
+	Address string
+}
+
+// Transport sends the Raft requests of a node to its peers
+type Transport interface {
+	RequestVote(ctx context.Context, peer Peer, req common.RaftVoteRequest) (common.RaftVoteResponse, error)
+	AppendEntries(ctx context.Context, peer Peer, req common.RaftAppendRequest) (common.RaftAppendResponse, error)
+	InstallSnapshot(ctx context.Context, peer Peer, req common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error)
+}
+
+// Config configures a Raft node
+type Config struct {
+	ID uuid.UUID
+	// Peers are the other members of the group
+	Peers     []Peer
+	Storage   Storage
+	Transport Transport
+	// Apply is called in log order for every committed entry, its result is returned to
+	// the proposer. It is also called for the no-op entries of new leaders, which have no data.
+	Apply func(entry common.RaftEntry) any
+	// Applied is the index of the last entry the state machine already applied
+	Applied int64
+	// Snapshot returns the state machine together with the index of the last entry it
+	// applied. Once SnapshotThreshold entries were applied since the last snapshot the
+	// entries it covers are dropped from the log, leaders send it to followers missing them.
+	// Nil keeps the whole log.
+	Snapshot func() (data []byte, index int64, err error)
+	// Restore replaces the state machine with a snapshot sent by the leader, or loaded on
+	// startup when the state machine applied fewer entries than the snapshot covers
+	Restore func(data []byte, index int64) error
+	// SnapshotThreshold is the number of applied entries that triggers a snapshot
+	SnapshotThreshold int64
+	// HeartbeatInterval is how often the leader contacts its followers
+	HeartbeatInterval time.Duration
+	// ElectionTimeout is the minimum time without a leader before a follower starts an
+	// election, the actual timeout is picked randomly up to twice as long
+	ElectionTimeout time.Duration
+	Logger          *slog.Logger
+}
+
+type role int
+
+const (
+	follower role = iota
+	candidate
+	leader
+)
+
+func (r role) String() string {
+	switch r {
+	case leader:
+		return "leader"
+	case candidate:
+		return "candidate"
+	default:
+		return "follower"
+	}
+}
+
+// Status is a point-in-time view of a node
+type Status struct {
+	Term     int64
+	IsLeader bool
+	// Leader is the ID of the known leader of the term, uuid.Nil when unknown
+	Leader uuid.UUID
+	// Ready reports a leader that committed an entry of its term and applied every
+	// committed entry, its state machine holds everything previous leaders committed
+	Ready        bool
+	CommitIndex  int64
+	AppliedIndex int64
+	LastLogIndex int64
+}
+
+type proposalResult struct {
+	value any
+	err   error
+}
+
+// waiter is a proposer waiting for its entry to be applied
+type waiter struct {
+	term   int64
+	result chan proposalResult
+}
+
+// Node is a member of a Raft group
+type Node struct {
+	mu     sync.Mutex
+	cfg    Config
+	logger *slog.Logger
+	peers  []Peer
+
+	// Persisted before being changed
+	term     int64
+	votedFor *uuid.UUID
+	// log[0] stands for the last entry covered by the snapshot, or is a sentinel at index 0
+	// without a snapshot, so that the entry at index i is log[i-firstIndex()]
+	log []common.RaftEntry
+
+	commitIndex int64
+	lastApplied int64
+	role        role
+	leader      uuid.UUID
+	deadline    time.Time
+
+	// Replication progress of every peer, only maintained by leaders
+	nextIndex   map[uuid.UUID]int64
+	matchIndex  map[uuid.UUID]int64
+	inflight    map[uuid.UUID]bool
+	lastContact map[uuid.UUID]time.Time
+
+	waiters map[int64]waiter
+	// applyMu is held while entries are applied or the state machine is restored
+	applyMu sync.Mutex
+	applyCh chan struct{}
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewNode restores a node from its storage and starts it as a follower
+func NewNode(cfg Config) (*Node, error) {
+	hardState, snapshot, entries, err := cfg.Storage.Load()
+	if err != nil {
+		return nil, fmt.Errorf("could not load raft storage: %w", err)
+	}
+
+	var first common.RaftEntry
+	if snapshot != nil {
+		first = common.RaftEntry{Index: snapshot.Index, Term: snapshot.Term}
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	n := &Node{
+		cfg:         cfg,
+		logger:      logger,
+		peers:       slices.Clone(cfg.Peers),
+		term:        hardState.Term,
+		votedFor:    hardState.VotedFor,
+		log:         append([]common.RaftEntry{first}, entries...),
+		nextIndex:   make(map[uuid.UUID]int64),
+		matchIndex:  make(map[uuid.UUID]int64),
+		inflight:    make(map[uuid.UUID]bool),
+		lastContact: make(map[uuid.UUID]time.Time),
+		waiters:     make(map[int64]waiter),
+		applyCh:     make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+	}
+
+	applied := cfg.Applied
+	if snapshot != nil && applied < snapshot.Index {
+		if cfg.Restore == nil {
+			return nil, errors.New("raft snapshot found but no restore function configured")
+		}
+		if err := cfg.Restore(snapshot.Data, snapshot.Index); err != nil {
+			return nil, fmt.Errorf("could not restore raft snapshot: %w", err)
+		}
+		applied = snapshot.Index
+	}
+
+	// Entries the state machine applied were committed. A state machine that applied nothing
+	// yet reports -1, the sentinel at index 0 counts as applied.
+	n.lastApplied = max(min(applied, n.lastIndex()), n.firstIndex())
+	n.commitIndex = n.lastApplied
+	n.resetDeadlineLocked()
+
+	n.wg.Add(2)
+	go n.run()
+	go n.applyLoop()
+
+	return n, nil
+}
+
+// Stop stops the node and closes its storage
+func (n *Node) Stop() error {
+	close(n.stop)
+	n.wg.Wait()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.cfg.Storage.Close()
+}
+
+// Status returns the current status of the node
+func (n *Node) Status() Status {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return Status{
+		Term:         n.term,
+		IsLeader:     n.role == leader,
+		Leader:       n.leader,
+		Ready:        n.role == leader && n.entryAt(n.commitIndex).Term == n.term && n.lastApplied >= n.commitIndex,
+		CommitIndex:  n.commitIndex,
+		AppliedIndex: n.lastApplied,
+		LastLogIndex: n.lastIndex(),
+	}
+}
+
+// SetPeers replaces the other members of the group
+func (n *Node) SetPeers(peers []Peer) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.peers = slices.Clone(peers)
+
+	ids := make(map[uuid.UUID]bool, len(peers))
+	for _, peer := range peers {
+		ids[peer.ID] = true
+		if _, known := n.nextIndex[peer.ID]; !known && n.role == leader {
+			n.nextIndex[peer.ID] = n.lastIndex() + 1
+			n.matchIndex[peer.ID] = 0
+			n.lastContact[peer.ID] = time.Now()
+		}
+	}
+
+	for id := range n.nextIndex {
+		if !ids[id] {
+			delete(n.nextIndex, id)
+			delete(n.matchIndex, id)
+			delete(n.lastContact, id)
+		}
+	}
+
+	if n.role == leader {
+		n.maybeCommitLocked()
+		n.broadcastLocked()
+	}
+}
+
+// Propose appends data to the log of the leader and waits until it is committed and applied,
+// returning the result of Apply. ErrNotLeader is returned when this node is not the leader.
+func (n *Node) Propose(ctx context.Context, data []byte) (any, error) {
+	n.mu.Lock()
+	if n.role != leader {
+		n.mu.Unlock()
+		return nil, ErrNotLeader
+	}
+
+	entry := common.RaftEntry{Index: n.lastIndex() + 1, Term: n.term, Data: &data}
+	if err := n.appendLocked([]common.RaftEntry{entry}); err != nil {
+		n.mu.Unlock()
+		return nil, err
+	}
+
+	result := make(chan proposalResult, 1)
+	n.waiters[entry.Index] = waiter{term: entry.Term, result: result}
+
+	n.maybeCommitLocked()
+	n.broadcastLocked()
+	n.mu.Unlock()
+
+	select {
+	case res := <-result:
+		return res.value, res.err
+	case <-ctx.Done():
+		n.mu.Lock()
+		delete(n.waiters, entry.Index)
+		n.mu.Unlock()
+		return nil, ctx.Err()
+	case <-n.stop:
+		return nil, ErrStopped
+	}
+}
+
+// HandleRequestVote answers the vote request of a candidate
+func (n *Node) HandleRequestVote(req common.RaftVoteRequest) (common.RaftVoteResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.stopped() {
+		return common.RaftVoteResponse{}, ErrStopped
+	}
+
+	if req.Term > n.term {
+		if err := n.becomeFollowerLocked(req.Term, uuid.Nil); err != nil {
+			return common.RaftVoteResponse{}, err
+		}
+	}
+
+	if req.Term < n.term {
+		return common.RaftVoteResponse{Term: n.term}, nil
+	}
+
+	lastTerm := n.entryAt(n.lastIndex()).Term
+	upToDate := req.LastLogTerm > lastTerm ||
+		(req.LastLogTerm == lastTerm && req.LastLogIndex >= n.lastIndex())
+	canVote := n.votedFor == nil || *n.votedFor == req.CandidateId
+
+	if !upToDate || !canVote {
+		return common.RaftVoteResponse{Term: n.term}, nil
+	}
+
+	if n.votedFor == nil {
+		candidateID := req.CandidateId
+		n.votedFor = &candidateID
+		if err := n.saveHardStateLocked(); err != nil {
+			n.votedFor = nil
+			return common.RaftVoteResponse{}, err
+		}
+	}
+
+	n.resetDeadlineLocked()
+
+	return common.RaftVoteResponse{Term: n.term, VoteGranted: true}, nil
+}
+
+// HandleAppendEntries stores the entries sent by the leader
+func (n *Node) HandleAppendEntries(req common.RaftAppendRequest) (common.RaftAppendResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.stopped() {
+		return common.RaftAppendResponse{}, ErrStopped
+	}
+
+	if req.Term < n.term {
+		return common.RaftAppendResponse{Term: n.term, LastLogIndex: n.lastIndex()}, nil
+	}
+
+	if req.Term > n.term || n.role != follower {
+		if err := n.becomeFollowerLocked(req.Term, req.LeaderId); err != nil {
+			return common.RaftAppendResponse{}, err
+		}
+	}
+	n.leader = req.LeaderId
+	n.resetDeadlineLocked()
+
+	prevIndex, prevTerm, entries := req.PrevLogIndex, req.PrevLogTerm, req.Entries
+	// The entries covered by the snapshot are committed and match the leader's
+	if prevIndex < n.firstIndex() {
+		entries = entries[min(n.firstIndex()-prevIndex, int64(len(entries))):]
+		prevIndex, prevTerm = n.firstIndex(), n.log[0].Term
+	}
+
+	// The logs diverge before the new entries, the leader retries from an earlier entry
+	if prevIndex > n.lastIndex() {
+		return common.RaftAppendResponse{Term: n.term, LastLogIndex: n.lastIndex()}, nil
+	}
+	if n.entryAt(prevIndex).Term != prevTerm {
+		return common.RaftAppendResponse{Term: n.term, LastLogIndex: prevIndex - 1}, nil
+	}
+
+	for len(entries) > 0 && entries[0].Index <= n.lastIndex() {
+		if n.entryAt(entries[0].Index).Term != entries[0].Term {
+			// Entries from a leader that lost its leadership before committing them
+			if err := n.cfg.Storage.TruncateFrom(entries[0].Index); err != nil {
+				return common.RaftAppendResponse{}, fmt.Errorf("could not truncate raft log: %w", err)
+			}
+			n.log = n.log[:entries[0].Index-n.firstIndex()]
+			break
+		}
+		entries = entries[1:]
+	}
+
+	if len(entries) > 0 {
+		if err := n.appendLocked(entries); err != nil {
+			return common.RaftAppendResponse{}, err
+		}
+	}
+
+	if req.LeaderCommit > n.commitIndex {
+		n.commitIndex = max(n.commitIndex, min(req.LeaderCommit, req.PrevLogIndex+int64(len(req.Entries))))
+		n.notifyApply()
+	}
+
+	return common.RaftAppendResponse{Term: n.term, Success: true, LastLogIndex: n.lastIndex()}, nil
+}
+
+// HandleInstallSnapshot replaces the state machine and the log of a follower with the
+// snapshot of a leader that compacted the entries the follower misses
+func (n *Node) HandleInstallSnapshot(req common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error) {
+	n.mu.Lock()
+
+	if n.stopped() {
+		n.mu.Unlock()
+		return common.RaftSnapshotResponse{}, ErrStopped
+	}
+
+	if req.Term < n.term {
+		n.mu.Unlock()
+		return common.RaftSnapshotResponse{Term: n.term}, nil
+	}
+
+	if req.Term > n.term || n.role != follower {
+		if err := n.becomeFollowerLocked(req.Term, req.LeaderId); err != nil {
+			n.mu.Unlock()
+			return common.RaftSnapshotResponse{}, err
+		}
+	}
+	n.leader = req.LeaderId
+	n.resetDeadlineLocked()
+	n.mu.Unlock()
+
+	// Nothing is applied while the state machine is replaced
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+
+	n.mu.Lock()
+	applied := n.lastApplied
+	n.mu.Unlock()
+
+	if req.LastIncludedIndex <= applied {
+		return common.RaftSnapshotResponse{Term: req.Term}, nil
+	}
+
+	if n.cfg.Restore == nil {
+		return common.RaftSnapshotResponse{}, errors.New("no restore function configured")
+	}
+
+	snapshot := Snapshot{Index: req.LastIncludedIndex, Term: req.LastIncludedTerm, Data: req.Data}
+	if err := n.cfg.Storage.SaveSnapshot(snapshot); err != nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("could not persist raft snapshot: %w", err)
+	}
+
+	if err := n.cfg.Restore(req.Data, req.LastIncludedIndex); err != nil {
+		return common.RaftSnapshotResponse{}, fmt.Errorf("could not restore raft snapshot: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.installSnapshotLocked(snapshot); err != nil {
+		return common.RaftSnapshotResponse{}, err
+	}
+
+	n.logger.Info("installed raft snapshot", "index", snapshot.Index, "term", snapshot.Term)
+
+	return common.RaftSnapshotResponse{Term: n.term}, nil
+}
+
+// installSnapshotLocked replaces the entries a restored snapshot covers, the entries following
+// it are kept when the log holds the snapshot's last entry
+func (n *Node) installSnapshotLocked(snapshot Snapshot) error {
+	if snapshot.Index <= n.lastIndex() && n.entryAt(snapshot.Index).Term == snapshot.Term {
+		if err := n.cfg.Storage.Compact(snapshot.Index); err != nil {
+			return fmt.Errorf("could not compact raft log: %w", err)
+		}
+		n.log = append([]common.RaftEntry{{Index: snapshot.Index, Term: snapshot.Term}},
+			n.log[snapshot.Index-n.firstIndex()+1:]...)
+	} else {
+		if err := n.cfg.Storage.TruncateFrom(n.firstIndex() + 1); err != nil {
+			return fmt.Errorf("could not truncate raft log: %w", err)
+		}
+		if err := n.cfg.Storage.Compact(snapshot.Index); err != nil {
+			return fmt.Errorf("could not compact raft log: %w", err)
+		}
+		n.log = []common.RaftEntry{{Index: snapshot.Index, Term: snapshot.Term}}
+	}
+
+	n.commitIndex = max(n.commitIndex, snapshot.Index)
+	n.lastApplied = snapshot.Index
+
+	// Proposals the snapshot covers committed, but their results are unknown
+	for index, w := range n.waiters {
+		if index <= snapshot.Index {
+			delete(n.waiters, index)
+			w.result <- proposalResult{err: ErrLeadershipLost}
+		}
+	}
+
+	n.notifyApply()
+
+	return nil
+}
+
+// run drives elections and heartbeats
+func (n *Node) run() {
+	defer n.wg.Done()
+
+	ticker := time.NewTicker(n.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			n.tick()
+		case <-n.stop:
+			return
+		}
+	}
+}
+
+func (n *Node) tick() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.role != leader {
+		if time.Now().After(n.deadline) {
+			n.startElectionLocked()
+		}
+		return
+	}
+
+	// A leader cut off from a majority steps down instead of accepting writes that can
+	// never commit
+	if !n.hasRecentQuorumLocked() {
+		n.logger.Warn("raft leader lost contact with a majority, stepping down", "term", n.term)
+		n.role = follower
+		n.leader = uuid.Nil
+		n.resetDeadlineLocked()
+		return
+	}
+
+	n.broadcastLocked()
+}
+
+func (n *Node) startElectionLocked() {
+	n.term++
+	n.role = candidate
+	n.leader = uuid.Nil
+	self := n.cfg.ID
+	n.votedFor = &self
+	n.resetDeadlineLocked()
+
+	if err := n.saveHardStateLocked(); err != nil {
+		n.logger.Error("could not persist raft term", "term", n.term, "error", err)
+		return
+	}
+
+	n.logger.Debug("starting raft election", "term", n.term)
+
+	votes := 1
+	if votes >= n.quorum() {
+		n.becomeLeaderLocked()
+		return
+	}
+
+	req := common.RaftVoteRequest{
+		Term:         n.term,
+		CandidateId:  n.cfg.ID,
+		LastLogIndex: n.lastIndex(),
+		LastLogTerm:  n.entryAt(n.lastIndex()).Term,
+	}
+
+	for _, peer := range n.peers {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), n.cfg.ElectionTimeout)
+			defer cancel()
+
+			resp, err := n.cfg.Transport.RequestVote(ctx, peer, req)
+			if err != nil {
+				n.logger.Debug("raft vote request failed", "peer", peer.ID, "error", err)
+				return
+			}
+
+			n.mu.Lock()
+			defer n.mu.Unlock()
+
+			if n.stopped() {
+				return
+			}
+
+			if resp.Term > n.term {
+				if err := n.becomeFollowerLocked(resp.Term, uuid.Nil); err != nil {
+					n.logger.Error("could not persist raft term", "term", resp.Term, "error", err)
+				}
+				return
+			}
+
+			if n.role != candidate || n.term != req.Term || !resp.VoteGranted {
+				return
+			}
+
+			votes++
+			if votes >= n.quorum() {
+				n.becomeLeaderLocked()
+			}
+		}()
+	}
+}
+
+func (n *Node) becomeLeaderLocked() {
+	n.role = leader
+	n.leader = n.cfg.ID
+
+	now := time.Now()
+	for _, peer := range n.peers {
+		n.nextIndex[peer.ID] = n.lastIndex() + 1
+		n.matchIndex[peer.ID] = 0
+		n.lastContact[peer.ID] = now
+	}
+
+	n.logger.Info("became raft leader", "term", n.term)
+
+	// Entries of previous terms only commit along with an entry of the current term
+	noop := common.RaftEntry{Index: n.lastIndex() + 1, Term: n.term}
+	if err := n.appendLocked([]common.RaftEntry{noop}); err != nil {
+		n.logger.Error("could not append raft no-op entry", "error", err)
+	}
+
+	n.maybeCommitLocked()
+	n.broadcastLocked()
+}
+
+// becomeFollowerLocked moves to term as a follower, the vote is reset when the term changes
+func (n *Node) becomeFollowerLocked(term int64, leaderID uuid.UUID) error {
+	if term > n.term {
+		n.term = term
+		n.votedFor = nil
+		if err := n.saveHardStateLocked(); err != nil {
+			return err
+		}
+	}
+
+	if n.role != follower {
+		n.logger.Info("became raft follower", "term", n.term, "previous_role", n.role)
+	}
+
+	n.role = follower
+	n.leader = leaderID
+
+	return nil
+}
+
+// broadcastLocked sends the missing entries, or a heartbeat, to every peer without a request in flight
+func (n *Node) broadcastLocked() {
+	for _, peer := range n.peers {
+		n.sendAppendLocked(peer)
+	}
+}
+
+func (n *Node) sendAppendLocked(peer Peer) {
+	if n.inflight[peer.ID] {
+		return
+	}
+
+	next, known := n.nextIndex[peer.ID]
+	if !known {
+		return
+	}
+
+	// The entries the peer misses were compacted
+	if next <= n.firstIndex() {
+		n.sendSnapshotLocked(peer)
+		return
+	}
+
+	end := min(n.lastIndex()+1, next+maxAppendEntries)
+	req := common.RaftAppendRequest{
+		Term:         n.term,
+		LeaderId:     n.cfg.ID,
+		PrevLogIndex: next - 1,
+		PrevLogTerm:  n.entryAt(next - 1).Term,
+		Entries:      slices.Clone(n.log[next-n.firstIndex() : end-n.firstIndex()]),
+		LeaderCommit: n.commitIndex,
+	}
+
+	n.inflight[peer.ID] = true
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), n.cfg.ElectionTimeout)
+		defer cancel()
+
+		resp, err := n.cfg.Transport.AppendEntries(ctx, peer, req)
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		n.inflight[peer.ID] = false
+
+		if n.stopped() {
+			return
+		}
+
+		if err != nil {
+			// Retried on the next heartbeat
+			n.logger.Debug("raft append request failed", "peer", peer.ID, "error", err)
+			return
+		}
+
+		if resp.Term > n.term {
+			if err := n.becomeFollowerLocked(resp.Term, uuid.Nil); err != nil {
+				n.logger.Error("could not persist raft term", "term", resp.Term, "error", err)
+			}
+			return
+		}
+
+		if n.role != leader || n.term != req.Term {
+			return
+		}
+
+		if _, known := n.nextIndex[peer.ID]; !known {
+			return
+		}
+
+		n.lastContact[peer.ID] = time.Now()
+
+		if resp.Success {
+			match := req.PrevLogIndex + int64(len(req.Entries))
+			n.matchIndex[peer.ID] = max(n.matchIndex[peer.ID], match)
+			n.nextIndex[peer.ID] = max(n.nextIndex[peer.ID], match+1)
+			n.maybeCommitLocked()
+		} else {
+			n.nextIndex[peer.ID] = max(1, min(req.PrevLogIndex, resp.LastLogIndex+1))
+		}
+
+		if n.nextIndex[peer.ID] <= n.lastIndex() {
+			n.sendAppendLocked(peer)
+		}
+	}()
+}
+
+// sendSnapshotLocked sends a snapshot of the state machine to a peer missing compacted entries
+func (n *Node) sendSnapshotLocked(peer Peer) {
+	if n.cfg.Snapshot == nil {
+		return
+	}
+
+	n.inflight[peer.ID] = true
+	term := n.term
+
+	go func() {
+		// The snapshot is taken outside the lock, it may cover entries applied meanwhile
+		data, index, err := n.cfg.Snapshot()
+
+		n.mu.Lock()
+		if err != nil || n.role != leader || n.term != term || index < n.firstIndex() || index > n.lastIndex() {
+			n.inflight[peer.ID] = false
+			n.mu.Unlock()
+			if err != nil {
+				n.logger.Error("could not take raft snapshot", "peer", peer.ID, "error", err)
+			}
+			return
+		}
+
+		req := common.RaftSnapshotRequest{
+			Term:              term,
+			LeaderId:          n.cfg.ID,
+			LastIncludedIndex: index,
+			LastIncludedTerm:  n.entryAt(index).Term,
+			Data:              data,
+		}
+		n.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+		defer cancel()
+
+		resp, err := n.cfg.Transport.InstallSnapshot(ctx, peer, req)
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		n.inflight[peer.ID] = false
+
+		if n.stopped() {
+			return
+		}
+
+		if err != nil {
+			// Retried on the next heartbeat
+			n.logger.Warn("raft snapshot request failed", "peer", peer.ID, "index", index, "error", err)
+			return
+		}
+
+		if resp.Term > n.term {
+			if err := n.becomeFollowerLocked(resp.Term, uuid.Nil); err != nil {
+				n.logger.Error("could not persist raft term", "term", resp.Term, "error", err)
+			}
+			return
+		}
+
+		if n.role != leader || n.term != term {
+			return
+		}
+
+		if _, known := n.nextIndex[peer.ID]; !known {
+			return
+		}
+
+		n.logger.Info("sent raft snapshot", "peer", peer.ID, "index", index)
+
+		n.lastContact[peer.ID] = time.Now()
+		n.matchIndex[peer.ID] = max(n.matchIndex[peer.ID], index)
+		n.nextIndex[peer.ID] = max(n.nextIndex[peer.ID], index+1)
+		n.maybeCommitLocked()
+
+		if n.nextIndex[peer.ID] <= n.lastIndex() {
+			n.sendAppendLocked(peer)
+		}
+	}()
+}
+
+// maybeCommitLocked advances the commit index to the last entry of the current term stored by a majority
+func (n *Node) maybeCommitLocked() {
+	for index := n.lastIndex(); index > n.commitIndex; index-- {
+		if n.entryAt(index).Term != n.term {
+			// Terms only decrease going back, no earlier entry is of the current term either
+			return
+		}
+
+		replicas := 1
+		for _, peer := range n.peers {
+			if n.matchIndex[peer.ID] >= index {
+				replicas++
+			}
+		}
+
+		if replicas >= n.quorum() {
+			n.commitIndex = index
+			n.notifyApply()
+			return
+		}
+	}
+}
+
+// hasRecentQuorumLocked reports whether a majority of the group heard from the leader within an election timeout
+func (n *Node) hasRecentQuorumLocked() bool {
+	reachable := 1
+	for _, peer := range n.peers {
+		if time.Since(n.lastContact[peer.ID]) <= n.cfg.ElectionTimeout {
+			reachable++
+		}
+	}
+
+	return reachable >= n.quorum()
+}
+
+// applyLoop applies committed entries in log order and hands the results to their proposers
+func (n *Node) applyLoop() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.applyCh:
+		case <-n.stop:
+			return
+		}
+
+		n.applyMu.Lock()
+		n.applyCommitted()
+		n.compact()
+		n.applyMu.Unlock()
+	}
+}
+
+// applyCommitted applies the committed entries the state machine did not apply yet, the
+// caller must hold applyMu
+func (n *Node) applyCommitted() {
+	n.mu.Lock()
+	entries := slices.Clone(n.log[n.lastApplied+1-n.firstIndex() : n.commitIndex+1-n.firstIndex()])
+	n.mu.Unlock()
+
+	for _, entry := range entries {
+		value := n.cfg.Apply(entry)
+
+		n.mu.Lock()
+		n.lastApplied = entry.Index
+		if w, found := n.waiters[entry.Index]; found {
+			delete(n.waiters, entry.Index)
+			if w.term == entry.Term {
+				w.result <- proposalResult{value: value}
+			} else {
+				w.result <- proposalResult{err: ErrLeadershipLost}
+			}
+		}
+		n.mu.Unlock()
+	}
+}
+
+// compact replaces the applied entries of the log with a snapshot of the state machine once
+// SnapshotThreshold entries were applied since the last one, the caller must hold applyMu
+func (n *Node) compact() {
+	if n.cfg.Snapshot == nil || n.cfg.SnapshotThreshold <= 0 {
+		return
+	}
+
+	n.mu.Lock()
+	due := n.lastApplied-n.firstIndex() >= n.cfg.SnapshotThreshold
+	n.mu.Unlock()
+
+	if !due {
+		return
+	}
+
+	data, index, err := n.cfg.Snapshot()
+	if err != nil {
+		n.logger.Error("could not take raft snapshot", "error", err)
+		return
+	}
+
+	n.mu.Lock()
+	if index <= n.firstIndex() || index > n.lastApplied {
+		n.mu.Unlock()
+		return
+	}
+	term := n.entryAt(index).Term
+	n.mu.Unlock()
+
+	// Writing the snapshot happens outside the lock so that replication is not blocked, the
+	// entries it covers are committed and cannot be truncated meanwhile
+	if err := n.cfg.Storage.SaveSnapshot(Snapshot{Index: index, Term: term, Data: data}); err != nil {
+		n.logger.Error("could not persist raft snapshot", "index", index, "error", err)
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.cfg.Storage.Compact(index); err != nil {
+		n.logger.Error("could not compact raft log", "index", index, "error", err)
+		return
+	}
+
+	n.log = append([]common.RaftEntry{{Index: index, Term: term}}, n.log[index-n.firstIndex()+1:]...)
+
+	n.logger.Debug("compacted raft log", "index", index, "term", term)
+}
+
+func (n *Node) notifyApply() {
+	select {
+	case n.applyCh <- struct{}{}:
+	default:
+	}
+}
+
+// appendLocked durably appends entries following the last one of the log
+func (n *Node) appendLocked(entries []common.RaftEntry) error {
+	if err := n.cfg.Storage.Append(entries); err != nil {
+		return fmt.Errorf("could not append to raft log: %w", err)
+	}
+
+	n.log = append(n.log, entries...)
+
+	return nil
+}
+
+func (n *Node) saveHardStateLocked() error {
+	if err := n.cfg.Storage.SaveHardState(HardState{Term: n.term, VotedFor: n.votedFor}); err != nil {
+		return fmt.Errorf("could not persist raft state: %w", err)
+	}
+
+	return nil
+}
+
+func (n *Node) resetDeadlineLocked() {
+	timeout := n.cfg.ElectionTimeout + rand.N(n.cfg.ElectionTimeout)
+	n.deadline = time.Now().Add(timeout)
+}
+
+// firstIndex is the index of log[0], the last entry covered by the snapshot
+func (n *Node) firstIndex() int64 {
+	return n.log[0].Index
+}
+
+func (n *Node) lastIndex() int64 {
+	return n.firstIndex() + int64(len(n.log)-1)
+}
+
+// entryAt returns the entry at index, which must lie between firstIndex and lastIndex
+func (n *Node) entryAt(index int64) common.RaftEntry {
+	return n.log[index-n.firstIndex()]
+}
+
+// quorum is the number of members forming a majority of the group
+func (n *Node) quorum() int {
+	return (len(n.peers)+1)/2 + 1
+}
+
+func (n *Node) stopped() bool {
+	select {
+	case <-n.stop:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,258 @@
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/google/uuid"
+)
+
+// votingTransport grants every vote and fails every append, so that a leader never commits
+type votingTransport struct{}
+
+func (votingTransport) RequestVote(_ context.Context, _ Peer, req common.RaftVoteRequest) (common.RaftVoteResponse, error) {
+	return common.RaftVoteResponse{Term: req.Term, VoteGranted: true}, nil
+}
+
+func (votingTransport) AppendEntries(context.Context, Peer, common.RaftAppendRequest) (common.RaftAppendResponse, error) {
+	return common.RaftAppendResponse{}, errors.New("unreachable")
+}
+
+func (votingTransport) InstallSnapshot(context.Context, Peer, common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error) {
+	return common.RaftSnapshotResponse{}, errors.New("unreachable")
+}
+
+func TestStatusOfLeaderWithoutCommittedEntries(t *testing.T) {
+	node, err := NewNode(Config{
+		ID:                uuid.New(),
+		Peers:             []Peer{{ID: uuid.New()}, {ID: uuid.New()}},
+		Storage:           NewMemoryStorage(),
+		Transport:         votingTransport{},
+		Apply:             func(common.RaftEntry) any { return nil },
+		Applied:           -1,
+		HeartbeatInterval: time.Millisecond,
+		ElectionTimeout:   5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("could not create node: %v", err)
+	}
+	defer node.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for !node.Status().IsLeader {
+		if time.Now().After(deadline) {
+			t.Fatal("node did not become leader")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	status := node.Status()
+	if status.Ready {
+		t.Error("leader without committed entries reported ready")
+	}
+	if status.CommitIndex != 0 || status.AppliedIndex != 0 {
+		t.Errorf("got commit index %d and applied index %d, want 0 and 0", status.CommitIndex, status.AppliedIndex)
+	}
+}
+
+// localTransport delivers requests to the nodes of a test cluster, requests to or from
+// disconnected nodes fail
+type localTransport struct {
+	from    uuid.UUID
+	cluster *localCluster
+}
+
+type localCluster struct {
+	mu           sync.Mutex
+	nodes        map[uuid.UUID]*Node
+	disconnected map[uuid.UUID]bool
+}
+
+func (t localTransport) peer(peer Peer) (*Node, error) {
+	t.cluster.mu.Lock()
+	defer t.cluster.mu.Unlock()
+
+	if t.cluster.disconnected[t.from] || t.cluster.disconnected[peer.ID] {
+		return nil, errors.New("disconnected")
+	}
+
+	return t.cluster.nodes[peer.ID], nil
+}
+
+func (t localTransport) RequestVote(_ context.Context, peer Peer, req common.RaftVoteRequest) (common.RaftVoteResponse, error) {
+	node, err := t.peer(peer)
+	if err != nil {
+		return common.RaftVoteResponse{}, err
+	}
+
+	return node.HandleRequestVote(req)
+}
+
+func (t localTransport) AppendEntries(_ context.Context, peer Peer, req common.RaftAppendRequest) (common.RaftAppendResponse, error) {
+	node, err := t.peer(peer)
+	if err != nil {
+		return common.RaftAppendResponse{}, err
+	}
+
+	return node.HandleAppendEntries(req)
+}
+
+func (t localTransport) InstallSnapshot(_ context.Context, peer Peer, req common.RaftSnapshotRequest) (common.RaftSnapshotResponse, error) {
+	node, err := t.peer(peer)
+	if err != nil {
+		return common.RaftSnapshotResponse{}, err
+	}
+
+	return node.HandleInstallSnapshot(req)
+}
+
+// listStateMachine collects the data of the applied entries
+type listStateMachine struct {
+	mu      sync.Mutex
+	applied int64
+	values  []string
+}
+
+func (m *listStateMachine) apply(entry common.RaftEntry) any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.applied = entry.Index
+	if entry.Data != nil {
+		m.values = append(m.values, string(*entry.Data))
+	}
+
+	return nil
+}
+
+func (m *listStateMachine) snapshot() ([]byte, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := json.Marshal(m.values)
+	return data, m.applied, err
+}
+
+func (m *listStateMachine) restore(data []byte, index int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.applied = index
+	return json.Unmarshal(data, &m.values)
+}
+
+func (m *listStateMachine) list() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.values)
+}
+
+func TestLaggingFollowerInstallsSnapshotOfCompactedLog(t *testing.T) {
+	cluster := &localCluster{nodes: make(map[uuid.UUID]*Node), disconnected: make(map[uuid.UUID]bool)}
+	peers := []Peer{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	machines := make(map[uuid.UUID]*listStateMachine)
+
+	cluster.mu.Lock()
+	for _, self := range peers {
+		machine := &listStateMachine{}
+		machines[self.ID] = machine
+
+		node, err := NewNode(Config{
+			ID: self.ID,
+			Peers: slices.DeleteFunc(slices.Clone(peers), func(p Peer) bool {
+				return p.ID == self.ID
+			}),
+			Storage:           NewMemoryStorage(),
+			Transport:         localTransport{from: self.ID, cluster: cluster},
+			Apply:             machine.apply,
+			Snapshot:          machine.snapshot,
+			Restore:           machine.restore,
+			SnapshotThreshold: 5,
+			HeartbeatInterval: 5 * time.Millisecond,
+			ElectionTimeout:   50 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("could not create node: %v", err)
+		}
+		defer node.Stop()
+
+		cluster.nodes[self.ID] = node
+	}
+	cluster.mu.Unlock()
+
+	leader := waitForLeader(t, cluster)
+
+	var lagging uuid.UUID
+	for _, peer := range peers {
+		if peer.ID != leader.cfg.ID {
+			lagging = peer.ID
+			break
+		}
+	}
+
+	cluster.mu.Lock()
+	cluster.disconnected[lagging] = true
+	cluster.mu.Unlock()
+
+	var want []string
+	for i := range 20 {
+		value := fmt.Sprintf("value-%d", i)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := leader.Propose(ctx, []byte(value))
+		cancel()
+		if err != nil {
+			t.Fatalf("could not propose %s: %v", value, err)
+		}
+		want = append(want, value)
+	}
+
+	leader.mu.Lock()
+	first := leader.firstIndex()
+	leader.mu.Unlock()
+	if first == 0 {
+		t.Fatal("leader did not compact its log")
+	}
+
+	cluster.mu.Lock()
+	cluster.disconnected[lagging] = false
+	cluster.mu.Unlock()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !slices.Equal(machines[lagging].list(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("lagging follower holds %v, want %v", machines[lagging].list(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// waitForLeader returns the node of the cluster that became leader
+func waitForLeader(t *testing.T, cluster *localCluster) *Node {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		cluster.mu.Lock()
+		nodes := slices.Collect(maps.Values(cluster.nodes))
+		cluster.mu.Unlock()
+
+		for _, node := range nodes {
+			if status := node.Status(); status.IsLeader && status.Ready {
+				return node
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("no node became leader")
+	return nil
+}
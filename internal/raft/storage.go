@@ -0,0 +1,352 @@
+package raft
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/google/uuid"
+)
+
+const (
+	hardStateFileName = "raft-state.json"
+	logFileName       = "raft-log.jsonl"
+	snapshotFileName  = "raft-snapshot.json"
+)
+
+// ErrCorruptLog is returned when opening a log that is damaged before its last entry
+var ErrCorruptLog = errors.New("raft log is corrupt")
+
+// HardState is the part of a node's state that must survive restarts for votes to stay safe
+type HardState struct {
+	Term     int64      `json:"term"`
+	VotedFor *uuid.UUID `json:"votedFor,omitempty"`
+}
+
+// Snapshot is the state machine after applying every entry up to Index, it replaces the
+// entries of the log it covers
+type Snapshot struct {
+	Index int64  `json:"index"`
+	Term  int64  `json:"term"`
+	Data  []byte `json:"data"`
+}
+
+// Storage durably keeps the hard state, the log and the latest snapshot of a node, every
+// method must have persisted its change when it returns
+type Storage interface {
+	// Load returns the persisted hard state, the latest snapshot, nil when there is none, and
+	// the log entries following the snapshot
+	Load() (HardState, *Snapshot, []common.RaftEntry, error)
+	SaveHardState(state HardState) error
+	// Append adds entries following the last persisted one
+	Append(entries []common.RaftEntry) error
+	// TruncateFrom drops the entries from index onwards
+	TruncateFrom(index int64) error
+	// SaveSnapshot replaces the latest snapshot, it may run concurrently with the other methods
+	SaveSnapshot(snapshot Snapshot) error
+	// Compact drops the entries up to index, which a saved snapshot covers
+	Compact(index int64) error
+	Close() error
+}
+
+// memoryStorage keeps nothing, the log only lives in the node's memory
+type memoryStorage struct{}
+
+// NewMemoryStorage returns a storage for nodes that do not persist anything, such a
+// node restarts with an empty log and may vote twice in a term
+func NewMemoryStorage() Storage {
+	return memoryStorage{}
+}
+
+func (memoryStorage) Load() (HardState, *Snapshot, []common.RaftEntry, error) {
+	return HardState{}, nil, nil, nil
+}
+func (memoryStorage) SaveHardState(HardState) error   { return nil }
+func (memoryStorage) Append([]common.RaftEntry) error { return nil }
+func (memoryStorage) TruncateFrom(int64) error        { return nil }
+func (memoryStorage) SaveSnapshot(Snapshot) error     { return nil }
+func (memoryStorage) Compact(int64) error             { return nil }
+func (memoryStorage) Close() error                    { return nil }
+
+// fileStorage keeps the hard state and the snapshot in JSON files and the log as JSON lines
+type fileStorage struct {
+	dir     string
+	logFile *os.File
+	// entries mirrors the log file so that truncations and compactions can rewrite it
+	entries []common.RaftEntry
+	// snapshot is the snapshot read on opening, handed out once by Load
+	snapshot *Snapshot
+}
+
+// NewFileStorage opens the Raft files in dir, creating them when missing
+func NewFileStorage(dir string) (Storage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create raft directory: %w", err)
+	}
+
+	entries, err := readLogFile(filepath.Join(dir, logFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := readSnapshotFile(filepath.Join(dir, snapshotFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries covered by a snapshot saved right before a crash are dropped as the
+	// compaction would have
+	if snapshot != nil {
+		entries = slices.DeleteFunc(entries, func(entry common.RaftEntry) bool {
+			return entry.Index <= snapshot.Index
+		})
+	}
+
+	s := &fileStorage{dir: dir, entries: entries, snapshot: snapshot}
+
+	// The log is rewritten so that a torn last line is dropped
+	if err := s.rewriteLog(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *fileStorage) Load() (HardState, *Snapshot, []common.RaftEntry, error) {
+	var state HardState
+
+	snapshot := s.snapshot
+	s.snapshot = nil
+
+	data, err := os.ReadFile(filepath.Join(s.dir, hardStateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, snapshot, s.entries, nil
+	}
+	if err != nil {
+		return state, nil, nil, fmt.Errorf("could not read raft state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, nil, nil, fmt.Errorf("could not decode raft state: %w", err)
+	}
+
+	return state, snapshot, s.entries, nil
+}
+
+func (s *fileStorage) SaveHardState(state HardState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not encode raft state: %w", err)
+	}
+
+	return writeFileAtomic(filepath.Join(s.dir, hardStateFileName), data)
+}
+
+func (s *fileStorage) Append(entries []common.RaftEntry) error {
+	w := bufio.NewWriter(s.logFile)
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("could not encode raft entry: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("could not write raft log: %w", err)
+	}
+
+	if err := s.logFile.Sync(); err != nil {
+		return fmt.Errorf("could not sync raft log: %w", err)
+	}
+
+	s.entries = append(s.entries, entries...)
+
+	return nil
+}
+
+func (s *fileStorage) TruncateFrom(index int64) error {
+	for i, entry := range s.entries {
+		if entry.Index >= index {
+			s.entries = s.entries[:i]
+			break
+		}
+	}
+
+	return s.rewriteLog()
+}
+
+func (s *fileStorage) SaveSnapshot(snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("could not encode raft snapshot: %w", err)
+	}
+
+	return writeFileAtomic(filepath.Join(s.dir, snapshotFileName), data)
+}
+
+func (s *fileStorage) Compact(index int64) error {
+	s.entries = slices.DeleteFunc(s.entries, func(entry common.RaftEntry) bool {
+		return entry.Index <= index
+	})
+
+	return s.rewriteLog()
+}
+
+func (s *fileStorage) Close() error {
+	if s.logFile == nil {
+		return nil
+	}
+
+	err := s.logFile.Close()
+	s.logFile = nil
+	return err
+}
+
+// rewriteLog replaces the log file with the entries kept in memory and reopens it for appending
+func (s *fileStorage) rewriteLog() error {
+	if s.logFile != nil {
+		if err := s.logFile.Close(); err != nil {
+			return fmt.Errorf("could not close raft log: %w", err)
+		}
+		s.logFile = nil
+	}
+
+	path := filepath.Join(s.dir, logFileName)
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("could not create raft log: %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, entry := range s.entries {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return fmt.Errorf("could not encode raft entry: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write raft log: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("could not sync raft log: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close raft log: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("could not replace raft log: %w", err)
+	}
+
+	s.logFile, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not open raft log: %w", err)
+	}
+
+	return nil
+}
+
+// readLogFile decodes the entries of a log file. A torn last line left by a crash is ignored,
+// an undecodable line followed by other entries fails since the entries after it may have committed.
+func readLogFile(path string) ([]common.RaftEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open raft log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []common.RaftEntry
+	// corrupt is the number of the first line that could not be decoded, 0 when none
+	corrupt := 0
+	r := bufio.NewReader(f)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("could not read raft log: %w", err)
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			if corrupt > 0 {
+				return nil, fmt.Errorf("%w: undecodable entry at line %d is followed by other entries",
+					ErrCorruptLog, corrupt)
+			}
+
+			var entry common.RaftEntry
+			if decodeErr := json.Unmarshal(line, &entry); decodeErr != nil {
+				corrupt = lineNumber
+			} else {
+				entries = append(entries, entry)
+			}
+		}
+
+		if err != nil {
+			return entries, nil
+		}
+	}
+}
+
+// readSnapshotFile decodes the snapshot file, returning nil when there is none
+func readSnapshotFile(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read raft snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("could not decode raft snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// writeFileAtomic replaces the file at path with data
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", filepath.Base(path), err)
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write %s: %w", filepath.Base(path), err)
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("could not sync %s: %w", filepath.Base(path), err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close %s: %w", filepath.Base(path), err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("could not replace %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
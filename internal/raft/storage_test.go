@@ -0,0 +1,84 @@
+package raft
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+)
+
+func TestNewFileStorageDropsTornLastLine(t *testing.T) {
+	dir := t.TempDir()
+	log := `{"index":1,"term":1}` + "\n" + `{"index":2,"te`
+	if err := os.WriteFile(filepath.Join(dir, logFileName), []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	storage, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("could not open storage: %v", err)
+	}
+	defer storage.Close()
+
+	_, _, entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("could not load storage: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Index != 1 {
+		t.Errorf("got entries %+v, want only the entry at index 1", entries)
+	}
+}
+
+func TestNewFileStorageFailsOnCorruptEntryBeforeOthers(t *testing.T) {
+	dir := t.TempDir()
+	log := `{"index":1,"term":1}` + "\n" + `{"index":2,"te` + "\n" + `{"index":3,"term":1}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, logFileName), []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileStorage(dir); !errors.Is(err, ErrCorruptLog) {
+		t.Fatalf("got error %v, want %v", err, ErrCorruptLog)
+	}
+}
+
+func TestFileStorageKeepsSnapshotAndEntriesAfterIt(t *testing.T) {
+	dir := t.TempDir()
+
+	storage, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("could not open storage: %v", err)
+	}
+
+	entries := []common.RaftEntry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 2}}
+	if err := storage.Append(entries); err != nil {
+		t.Fatalf("could not append: %v", err)
+	}
+	if err := storage.SaveSnapshot(Snapshot{Index: 2, Term: 1, Data: []byte("state")}); err != nil {
+		t.Fatalf("could not save snapshot: %v", err)
+	}
+	if err := storage.Compact(2); err != nil {
+		t.Fatalf("could not compact: %v", err)
+	}
+	if err := storage.Close(); err != nil {
+		t.Fatalf("could not close storage: %v", err)
+	}
+
+	storage, err = NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("could not reopen storage: %v", err)
+	}
+	defer storage.Close()
+
+	_, snapshot, loaded, err := storage.Load()
+	if err != nil {
+		t.Fatalf("could not load storage: %v", err)
+	}
+	if snapshot == nil || snapshot.Index != 2 || string(snapshot.Data) != "state" {
+		t.Errorf("got snapshot %+v, want the snapshot at index 2", snapshot)
+	}
+	if len(loaded) != 1 || loaded[0].Index != 3 {
+		t.Errorf("got entries %+v, want only the entry at index 3", loaded)
+	}
+}
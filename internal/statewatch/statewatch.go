@@ -0,0 +1,71 @@
+package statewatch
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/computer-technology-team/distributed-kvstore/api/common"
+	"github.com/computer-technology-team/distributed-kvstore/api/controller"
+)
+
+const (
+	// minBackoff and maxBackoff bound the delay between retries to an unreachable controller
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 5 * time.Second
+)
+
+// Receiver is kept current by Watch
+type Receiver interface {
+	// StateVersion returns the version of the state the receiver holds, 0 before it got any
+	StateVersion() int64
+	// ApplyState applies a state newer than the one the receiver holds
+	ApplyState(state common.State) error
+}
+
+// Watch long-polls the controller for states newer than the one the receiver holds and
+// applies them until ctx is done. Every poll waits at most timeout for the state to change.
+func Watch(ctx context.Context, client controller.ClientWithResponsesInterface, receiver Receiver,
+	timeout time.Duration) {
+	timeoutSeconds := max(int(timeout/time.Second), 1)
+	backoff := minBackoff
+
+	for ctx.Err() == nil {
+		version := receiver.StateVersion()
+		resp, err := client.GetStateWithResponse(ctx, &controller.GetStateParams{
+			SinceVersion:   &version,
+			TimeoutSeconds: &timeoutSeconds,
+		})
+
+		switch {
+		case err != nil:
+			slog.Warn("could not watch state", "version", version, "retry_in", backoff, "error", err)
+		case resp.JSON200 != nil:
+			backoff = minBackoff
+			if resp.JSON200.Version <= receiver.StateVersion() {
+				continue
+			}
+
+			err := receiver.ApplyState(*resp.JSON200)
+			if err == nil {
+				continue
+			}
+			slog.Warn("could not apply watched state", "version", resp.JSON200.Version,
+				"retry_in", backoff, "error", err)
+		case resp.StatusCode() == http.StatusNotModified:
+			backoff = minBackoff
+			continue
+		default:
+			slog.Warn("could not watch state", "version", version, "retry_in", backoff,
+				"response_status_code", resp.StatusCode())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
@@ -78,6 +78,7 @@ add_nodes() {
       --health-start-period 5s \
       --restart unless-stopped \
       -v "$(pwd)/${DEFAULT_CONFIG_DIR}:/app/config" \
+      -v "${node_name}-data:/var/lib/kvstore" \
       "$DEFAULT_IMAGE_NAME" \
       ./kvstore servenode --config /app/config/node.yaml
     